@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+// component is a part of the service with a start and a stop hook, either
+// of which may be nil.
+type component struct {
+	name string
+	// start runs the component until it is stopped, like a server's
+	// ListenAndServe. An error stops the service.
+	start func() error
+	// stop returns once the component has drained, or ctx is done.
+	stop func(ctx context.Context) error
+	// timeout bounds stop.
+	timeout time.Duration
+}
+
+// lifecycle starts components in the order they are registered and stops them
+// in reverse, so that each is registered after the components it depends on:
+// the database before the workers using it, the workers before the servers.
+type lifecycle struct {
+	components []component
+}
+
+func (l *lifecycle) register(c component) {
+	l.components = append(l.components, c)
+}
+
+// run starts every component, then stops them all when a signal arrives or a
+// component fails. Each stop gets its own timeout, so that a slow component
+// does not eat into the drain of the next one.
+func (l *lifecycle) run(signals <-chan os.Signal) error {
+	failed := make(chan error, len(l.components))
+	for _, c := range l.components {
+		if c.start == nil {
+			continue
+		}
+		go func() {
+			if err := c.start(); err != nil {
+				failed <- fmt.Errorf("%s: %w", c.name, err)
+			}
+		}()
+	}
+
+	var errs []error
+	select {
+	case sig := <-signals:
+		logger.Default().Info("shutting down", "signal", sig.String())
+	case err := <-failed:
+		logger.Default().Error("component failed, shutting down", "error", err.Error())
+		errs = append(errs, err)
+	}
+
+	for i := len(l.components) - 1; i >= 0; i-- {
+		c := l.components[i]
+		if c.stop == nil {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		err := c.stop(ctx)
+		cancel()
+		if err != nil {
+			logger.Default().Error("failed to stop "+c.name, "error", err.Error())
+			errs = append(errs, fmt.Errorf("%s: %w", c.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// background runs fn until stopped, for workers taking a context.
+func background(fn func(ctx context.Context)) (start func() error, stop func(ctx context.Context) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	start = func() error {
+		defer close(done)
+		fn(ctx)
+		return nil
+	}
+	stop = func(stopCtx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-stopCtx.Done():
+			return stopCtx.Err()
+		}
+	}
+	return start, stop
+}
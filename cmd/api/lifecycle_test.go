@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycle_Run(t *testing.T) {
+	t.Run("stops components in reverse order on a signal", func(t *testing.T) {
+		// given
+		var mu sync.Mutex
+		var stopped []string
+		app := &lifecycle{}
+		for _, name := range []string{"database", "scheduler", "http server"} {
+			app.register(component{
+				name: name,
+				stop: func(ctx context.Context) error {
+					mu.Lock()
+					defer mu.Unlock()
+					stopped = append(stopped, name)
+					return nil
+				},
+				timeout: time.Second,
+			})
+		}
+		signals := make(chan os.Signal, 1)
+		signals <- syscall.SIGTERM
+
+		// when
+		err := app.run(signals)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"http server", "scheduler", "database"}, stopped)
+	})
+
+	t.Run("stops every component when one fails", func(t *testing.T) {
+		// given
+		var stopped bool
+		app := &lifecycle{}
+		app.register(component{
+			name:    "database",
+			stop:    func(ctx context.Context) error { stopped = true; return nil },
+			timeout: time.Second,
+		})
+		app.register(component{
+			name:  "http server",
+			start: func() error { return errors.New("address already in use") },
+		})
+
+		// when
+		err := app.run(make(chan os.Signal))
+
+		// then
+		assert.EqualError(t, err, "http server: address already in use")
+		assert.True(t, stopped)
+	})
+
+	t.Run("gives each component its own timeout", func(t *testing.T) {
+		// given
+		start, stop := background(func(ctx context.Context) {
+			<-ctx.Done()
+			time.Sleep(time.Second)
+		})
+		var stopped bool
+		app := &lifecycle{}
+		app.register(component{
+			name:    "database",
+			stop:    func(ctx context.Context) error { stopped = ctx.Err() == nil; return nil },
+			timeout: time.Second,
+		})
+		app.register(component{name: "scheduler", start: start, stop: stop, timeout: 10 * time.Millisecond})
+		signals := make(chan os.Signal, 1)
+		signals <- syscall.SIGTERM
+
+		// when
+		err := app.run(signals)
+
+		// then
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.True(t, stopped)
+	})
+}
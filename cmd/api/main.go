@@ -2,71 +2,322 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"google.golang.org/grpc"
+
+	"github.com/nubank/pismo-code-assessment/docs"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/auth"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/cli"
 	"github.com/nubank/pismo-code-assessment/internal/infrastructure/config"
-	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/fx"
+	grpcserver "github.com/nubank/pismo-code-assessment/internal/infrastructure/grpc/server"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/grpc/service"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/health"
 	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/handler"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/middleware"
 	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/router"
 	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/server"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/metrics"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/ratelimit"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/scheduler"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/telemetry"
 	"github.com/nubank/pismo-code-assessment/internal/usecase/account"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/apikey"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/card"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/dispute"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/fraud"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/scheduledtransaction"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/spendingcontrol"
 	"github.com/nubank/pismo-code-assessment/internal/usecase/transaction"
 	"github.com/nubank/pismo-code-assessment/pkg/logger"
 )
 
 func main() {
-	cfg := config.Load()
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%s\n", err)
+		os.Exit(2)
+	}
+
+	if cfg.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	logger.Init(cfg.Environment)
+	logger.Init(cfg.Environment, logger.Redaction{Mode: logger.RedactionMode(cfg.Log.Redaction), HashKey: []byte(cfg.Log.HashKey)})
+
+	var store *storage
+	if cfg.Storage == "memory" {
+		logger.Default().Warn("storing data in memory, it is lost on exit")
+		store = newMemoryStorage()
+	} else {
+		store, err = openPostgres(cfg)
+		if err != nil {
+			logger.Default().Error("failed to open storage", "error", err.Error())
+			os.Exit(1)
+		}
+	}
+
+	if len(cfg.Args) > 0 && cfg.Args[0] == "migrate" {
+		if store.migrator == nil {
+			fmt.Fprintln(os.Stderr, "migrate requires postgres storage")
+			os.Exit(2)
+		}
+		if err := cli.Migrate(context.Background(), store.migrator, cfg.Args[1:], os.Stdout); err != nil {
+			if errors.Is(err, cli.ErrMigrateUsage) {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(2)
+			}
+			logger.Default().Error("failed to migrate database", "error", err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	if cfg.Database.AutoMigrate && store.migrator != nil {
+		applied, err := store.migrator.Up(context.Background())
+		if err != nil {
+			logger.Default().Error("failed to migrate database", "error", err.Error())
+			os.Exit(1)
+		}
+		for _, migration := range applied {
+			logger.Default().Info("applied migration", "version", migration.Version, "name", migration.Name)
+		}
+	}
+
+	shutdownTracing, err := telemetry.Setup(context.Background(), telemetry.Config{
+		ServiceName: cfg.Tracing.ServiceName,
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.OTLPEndpoint,
+		Insecure:    cfg.Tracing.OTLPInsecure,
+	})
+	if err != nil {
+		logger.Default().Error("failed to set up tracing", "error", err.Error())
+		os.Exit(1)
+	}
+
+	fxRates, err := fx.ParseRates(cfg.FX.Rates)
+	if err != nil {
+		logger.Default().Error("failed to parse fx rates", "error", err.Error())
+		os.Exit(1)
+	}
 
-	db, err := database.NewPostgresDB(cfg.Database)
+	rateLimits, err := ratelimit.ParsePolicies(cfg.RateLimit.Policies)
 	if err != nil {
-		logger.Default().Error("failed to connect to database", "error", err.Error())
+		logger.Default().Error("failed to parse rate limits", "error", err.Error())
 		os.Exit(1)
 	}
-	defer db.Close()
 
 	// Repositories
-	accountRepo := database.NewAccountRepository(db)
-	transactionRepo := database.NewTransactionRepository(db)
+	accountRepo := store.accounts
+	transactionRepo := store.transactions
+	spendingControlsRepo := store.spendingControls
+	cardRepo := store.cards
+	disputeRepo := store.disputes
+	scheduledTransactionRepo := store.scheduledTransactions
+	apiKeyRepo := store.apiKeys
 
 	// Account use cases and handler
 	createAccount := account.NewCreateAccount(accountRepo)
 	getAccount := account.NewGetAccount(accountRepo)
 	accountHandler := handler.NewAccountHandler(createAccount, getAccount)
 
+	// Card use cases and handler
+	issueCard := card.NewIssueCard(cardRepo)
+	blockCard := card.NewBlockCard(cardRepo)
+	cardHandler := handler.NewCardHandler(issueCard, blockCard)
+
 	// Transaction use cases and handler
-	createTransaction := transaction.NewCreateTransaction(transactionRepo)
+	fxRateProvider := fx.NewStaticRateProvider(fxRates)
+	fraudEngine := fraud.NewEngine(store.fraudRules, store.fraudAssessments, transactionRepo, cfg.Fraud.RulesRefreshInterval)
+	createTransaction := transaction.NewCreateTransaction(transactionRepo, accountRepo, cardRepo, fxRateProvider, spendingControlsRepo, fraudEngine)
+	listTransactions := transaction.NewListTransactions(transactionRepo, accountRepo)
 	transactionHandler := handler.NewTransactionHandler(createTransaction)
 
-	// Health handler
-	healthHandler := handler.NewHealthHandler(db)
+	// Dispute use cases and handler
+	openDispute := dispute.NewOpenDispute(disputeRepo, transactionRepo, createTransaction)
+	listDisputes := dispute.NewListDisputes(disputeRepo, transactionRepo)
+	updateDispute := dispute.NewUpdateDispute(disputeRepo, transactionRepo, createTransaction)
+	disputeHandler := handler.NewDisputeHandler(openDispute, listDisputes, updateDispute)
+
+	// Scheduled transaction use cases and handler
+	createScheduledTransaction := scheduledtransaction.NewCreateScheduledTransaction(scheduledTransactionRepo)
+	listScheduledTransactions := scheduledtransaction.NewListScheduledTransactions(scheduledTransactionRepo, accountRepo)
+	getScheduledTransaction := scheduledtransaction.NewGetScheduledTransaction(scheduledTransactionRepo)
+	updateScheduledTransaction := scheduledtransaction.NewUpdateScheduledTransaction(scheduledTransactionRepo)
+	cancelScheduledTransaction := scheduledtransaction.NewCancelScheduledTransaction(scheduledTransactionRepo)
+	scheduledTransactionHandler := handler.NewScheduledTransactionHandler(
+		createScheduledTransaction,
+		listScheduledTransactions,
+		getScheduledTransaction,
+		updateScheduledTransaction,
+		cancelScheduledTransaction,
+	)
 
-	r := router.New(accountHandler, transactionHandler, healthHandler)
+	// Spending controls use cases and handler
+	getSpendingControls := spendingcontrol.NewGetSpendingControls(spendingControlsRepo, accountRepo)
+	updateSpendingControls := spendingcontrol.NewUpdateSpendingControls(spendingControlsRepo)
+	spendingControlsHandler := handler.NewSpendingControlsHandler(getSpendingControls, updateSpendingControls)
+
+	// Health checks, liveness left free of dependencies so that a database
+	// outage does not restart every instance
+	checker := health.NewChecker(cfg.Health.CheckTimeout)
+	store.registerChecks(checker, cfg.Health.PoolSaturation)
+	healthHandler := handler.NewHealthHandler(checker)
+
+	// Authentication, left nil to disable it. The gRPC API shares it, through
+	// an interface left nil too so that the server sees it disabled.
+	var authMiddleware *middleware.Auth
+	var grpcAuth grpcserver.Authenticator
+	if cfg.Auth.Enabled {
+		authenticateAPIKey := apikey.NewAuthenticateAPIKey(apiKeyRepo)
+		if cfg.Auth.JWT.JWKS != "" {
+			jwks := auth.NewJWKS(cfg.Auth.JWT.JWKS, cfg.Auth.JWT.JWKSRefreshInterval)
+			verifier := auth.NewJWTVerifier(jwks, auth.JWTConfig{
+				Issuer:       cfg.Auth.JWT.Issuer,
+				Audience:     cfg.Auth.JWT.Audience,
+				ScopesClaim:  cfg.Auth.JWT.ScopesClaim,
+				AccountClaim: cfg.Auth.JWT.AccountClaim,
+			})
+			authMiddleware = middleware.NewAuth(authenticateAPIKey, verifier)
+		} else {
+			authMiddleware = middleware.NewAuth(authenticateAPIKey, nil)
+		}
+		grpcAuth = authMiddleware
+	} else {
+		logger.Default().Warn("authentication is disabled")
+	}
 
-	srv := server.New(cfg.Server.Port, r)
+	// Rate limiting, left nil when no route is limited
+	var limiter *middleware.RateLimiter
+	if len(rateLimits) > 0 {
+		switch cfg.RateLimit.Store {
+		case "memory":
+			limiter = middleware.NewRateLimiter(ratelimit.NewMemoryStore(), rateLimits)
+		case "postgres":
+			limiter = middleware.NewRateLimiter(ratelimit.NewPostgresStore(store.db), rateLimits)
+		}
+	}
 
-	go func() {
-		if err := srv.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			logger.Default().Error("failed to start server", "error", err.Error())
+	// Request validation against the OpenAPI document
+	var validator *middleware.Validator
+	if cfg.Validation.Requests {
+		validator, err = middleware.NewValidator(docs.OpenAPI, cfg.Validation.Responses)
+		if err != nil {
+			logger.Default().Error("failed to load openapi document", "error", err.Error())
 			os.Exit(1)
 		}
-	}()
+	}
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	r := router.New(accountHandler, transactionHandler, healthHandler, spendingControlsHandler, cardHandler, disputeHandler, scheduledTransactionHandler, authMiddleware, limiter, validator)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	timeouts := server.Timeouts{
+		Read:       cfg.Server.ReadTimeout,
+		ReadHeader: cfg.Server.ReadHeaderTimeout,
+		Write:      cfg.Server.WriteTimeout,
+		Idle:       cfg.Server.IdleTimeout,
+	}
+	var tlsConfig *tls.Config
+	if cfg.Server.TLS.CertFile != "" {
+		tlsConfig, err = server.NewTLSConfig(server.TLS{
+			CertFile:     cfg.Server.TLS.CertFile,
+			KeyFile:      cfg.Server.TLS.KeyFile,
+			ClientCAFile: cfg.Server.TLS.ClientCAFile,
+			ClientAuth:   cfg.Server.TLS.ClientAuth,
+			MinVersion:   cfg.Server.TLS.MinVersion,
+		})
+		if err != nil {
+			logger.Default().Error("failed to load tls certificates", "error", err.Error())
+			os.Exit(1)
+		}
+	}
+	srv := server.New(cfg.Server.Port, r, timeouts, tlsConfig)
+
+	// Metrics server
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("GET /metrics", metrics.Handler())
+	metricsSrv := server.New(cfg.Metrics.Port, metricsMux, timeouts, nil)
+
+	// gRPC server
+	pismoService := service.NewPismoService(createAccount, getAccount, createTransaction, listTransactions)
+	grpcSrv := grpcserver.New(cfg.GRPC.Port, pismoService, grpcAuth)
+
+	// Components start in this order and stop in reverse: the servers drain
+	// first, then the scheduler, then spans are flushed and the pool closed.
+	app := &lifecycle{}
+	app.register(component{
+		name:    "storage",
+		stop:    func(context.Context) error { return store.close() },
+		timeout: cfg.Server.ShutdownTimeout,
+	})
+	app.register(component{
+		name: "tracing",
+		// Flush the spans of the requests that just drained.
+		stop:    shutdownTracing,
+		timeout: cfg.Server.ShutdownTimeout,
+	})
+	if cfg.Scheduler.Interval > 0 {
+		runDueScheduledTransactions := scheduledtransaction.NewRunDueScheduledTransactions(scheduledTransactionRepo, transactionRepo, createTransaction, cfg.Scheduler.BatchSize)
+		start, stop := background(scheduler.New(store.leaderLock, runDueScheduledTransactions, cfg.Scheduler.Interval).Run)
+		app.register(component{name: "scheduler", start: start, stop: stop, timeout: cfg.Scheduler.ShutdownTimeout})
+	}
+	app.register(component{
+		name:    "metrics server",
+		start:   serve(metricsSrv.Start, http.ErrServerClosed),
+		stop:    metricsSrv.Shutdown,
+		timeout: cfg.Server.ShutdownTimeout,
+	})
+	app.register(component{
+		name:    "grpc server",
+		start:   serve(grpcSrv.Start, grpc.ErrServerStopped),
+		stop:    grpcSrv.Shutdown,
+		timeout: cfg.Server.ShutdownTimeout,
+	})
+	app.register(component{
+		name:    "http server",
+		start:   serve(srv.Start, http.ErrServerClosed),
+		stop:    srv.Shutdown,
+		timeout: cfg.Server.ShutdownTimeout,
+	})
+	app.register(component{
+		name: "readiness",
+		// Fail readiness first and give load balancers time to notice,
+		// while the servers keep serving.
+		stop: func(ctx context.Context) error {
+			checker.Drain()
+			if cfg.Server.DrainDelay > 0 {
+				logger.Default().Info("draining before shutdown", "delay", cfg.Server.DrainDelay.String())
+				time.Sleep(cfg.Server.DrainDelay)
+			}
+			return nil
+		},
+		timeout: cfg.Server.DrainDelay,
+	})
 
-	if err := srv.Shutdown(ctx); err != nil {
-		logger.Default().Error("server forced to shutdown", "error", err.Error())
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	if err := app.run(quit); err != nil {
 		os.Exit(1)
 	}
 }
+
+// serve adapts a server's Start, which returns closed once shut down.
+func serve(start func() error, closed error) func() error {
+	return func() error {
+		if err := start(); err != nil && !errors.Is(err, closed) {
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/config"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database/pgxdb"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/health"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/memory"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/metrics"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/scheduler"
+)
+
+// storage holds the repositories of the configured backend.
+type storage struct {
+	// db and migrator are nil with memory storage, pool unless the pgx
+	// driver is configured.
+	db       *sql.DB
+	pool     *pgxpool.Pool
+	migrator *database.Migrator
+
+	accounts              domain.AccountRepository
+	transactions          domain.TransactionRepository
+	spendingControls      domain.SpendingControlsRepository
+	cards                 domain.CardRepository
+	fraudRules            domain.FraudRuleRepository
+	fraudAssessments      domain.FraudAssessmentRepository
+	disputes              domain.DisputeRepository
+	scheduledTransactions domain.ScheduledTransactionRepository
+	apiKeys               domain.APIKeyRepository
+	leaderLock            interface {
+		TryAcquire(ctx context.Context) (bool, error)
+		Release(ctx context.Context) error
+	}
+}
+
+func openPostgres(cfg *config.Config) (*storage, error) {
+	sqlPool, pgxPool := cfg.Database.Pools()
+	db, err := database.NewPostgresDB(sqlPool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	metrics.RegisterDB(db)
+
+	s := &storage{
+		db:                    db,
+		migrator:              migrator,
+		accounts:              database.NewAccountRepository(db),
+		transactions:          metrics.NewTransactionRepository(database.NewTransactionRepository(db)),
+		spendingControls:      database.NewSpendingControlsRepository(db),
+		cards:                 database.NewCardRepository(db),
+		fraudRules:            database.NewFraudRuleRepository(db),
+		fraudAssessments:      database.NewFraudAssessmentRepository(db),
+		disputes:              database.NewDisputeRepository(db),
+		scheduledTransactions: database.NewScheduledTransactionRepository(db),
+		apiKeys:               database.NewAPIKeyRepository(db),
+		leaderLock:            database.NewAdvisoryLock(db, scheduler.LeaderLockKey),
+	}
+
+	// Accounts and transactions are served by the configured driver.
+	if cfg.Database.Driver == "pgx" {
+		s.pool, err = pgxdb.NewPool(context.Background(), pgxPool)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to connect to database: %w", err)
+		}
+		metrics.RegisterPgxPool(s.pool)
+		s.accounts = pgxdb.NewAccountRepository(s.pool)
+		s.transactions = metrics.NewTransactionRepository(pgxdb.NewTransactionRepository(s.pool))
+	}
+
+	return s, nil
+}
+
+// newMemoryStorage keeps everything in memory, with no fraud rule.
+func newMemoryStorage() *storage {
+	accounts := memory.NewAccountRepository()
+	cards := memory.NewCardRepository(accounts)
+	transactions := memory.NewTransactionRepository(accounts, cards)
+
+	return &storage{
+		accounts:              accounts,
+		transactions:          metrics.NewTransactionRepository(transactions),
+		spendingControls:      memory.NewSpendingControlsRepository(accounts),
+		cards:                 cards,
+		fraudRules:            memory.NewFraudRuleRepository(),
+		fraudAssessments:      memory.NewFraudAssessmentRepository(),
+		disputes:              memory.NewDisputeRepository(transactions),
+		scheduledTransactions: memory.NewScheduledTransactionRepository(accounts),
+		apiKeys:               memory.NewAPIKeyRepository(),
+		leaderLock:            memory.LeaderLock{},
+	}
+}
+
+// registerChecks adds the checks of the database, none with memory storage.
+func (s *storage) registerChecks(checker *health.Checker, poolSaturation float64) {
+	if s.db == nil {
+		return
+	}
+
+	checker.Register("database", health.Database(s.db), health.Ready, health.Startup)
+	checker.Register("connection_pool", health.PoolSaturation(s.db, poolSaturation), health.Ready)
+	checker.Register("migrations", health.Migrations(s.migrator), health.Startup)
+	if s.pool != nil {
+		checker.Register("database_pgx", s.pool.Ping, health.Ready, health.Startup)
+		checker.Register("connection_pool_pgx", health.PgxPoolSaturation(s.pool, poolSaturation), health.Ready)
+	}
+}
+
+func (s *storage) close() error {
+	if s.pool != nil {
+		s.pool.Close()
+	}
+	if s.db != nil {
+		return s.db.Close()
+	}
+	return nil
+}
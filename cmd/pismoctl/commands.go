@@ -0,0 +1,282 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/cli"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/account"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/apikey"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/transaction"
+)
+
+const usage = `usage: pismoctl [-o table|json] <command> [arguments]
+
+commands:
+  accounts create --document N [--currency C]
+  accounts get ID
+  transactions create --account ID --operation-type N --amount A
+                      [--currency C] [--description D] [--external-id E]
+  transactions list --account ID
+  balances --account ID
+  discharge replay --account ID
+  api-keys create --client ID --scopes S[,S...]
+  api-keys list
+  api-keys revoke ID
+  migrate <command>   run "pismoctl migrate" for its commands
+
+scopes: accounts:read, accounts:write, transactions:write, disputes:resolve`
+
+var errUsage = errors.New(usage)
+
+// app holds what the commands need, built once from the service configuration.
+type app struct {
+	printer           *cli.Printer
+	out               io.Writer
+	migrator          *database.Migrator
+	createAccount     *account.CreateAccount
+	getAccount        *account.GetAccount
+	createTransaction *transaction.CreateTransaction
+	listTransactions  *transaction.ListTransactions
+	getBalance        *transaction.GetBalance
+	replayDischarge   *transaction.ReplayDischarge
+	createAPIKey      *apikey.CreateAPIKey
+	listAPIKeys       *apikey.ListAPIKeys
+	revokeAPIKey      *apikey.RevokeAPIKey
+}
+
+func (a *app) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "accounts":
+		return a.accounts(ctx, args)
+	case "transactions":
+		return a.transactions(ctx, args)
+	case "balances":
+		return a.balances(ctx, args)
+	case "discharge":
+		return a.discharge(ctx, args)
+	case "api-keys":
+		return a.apiKeys(ctx, args)
+	case "migrate":
+		return cli.Migrate(ctx, a.migrator, args, a.out)
+	}
+
+	return errUsage
+}
+
+func (a *app) accounts(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "create":
+		flags := newFlagSet("accounts create")
+		document := flags.String("document", "", "document number")
+		currency := flags.String("currency", "", "settlement currency")
+		if err := parseFlags(flags, args[1:]); err != nil {
+			return err
+		}
+
+		created, err := a.createAccount.Execute(ctx, *document, *currency)
+		if err != nil {
+			return err
+		}
+		return a.printer.Print(newAccountView(created))
+	case "get":
+		if len(args) != 2 {
+			return errUsage
+		}
+		accountID, err := parseID(args[1])
+		if err != nil {
+			return err
+		}
+
+		found, err := a.getAccount.Execute(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		return a.printer.Print(newAccountView(found))
+	}
+
+	return errUsage
+}
+
+func (a *app) transactions(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "create":
+		flags := newFlagSet("transactions create")
+		accountID := flags.Int64("account", 0, "account ID")
+		operationTypeID := flags.Int("operation-type", 0, "operation type ID")
+		amount := flags.Float64("amount", 0, "amount, always positive")
+		var details domain.TransactionDetails
+		flags.StringVar(&details.Currency, "currency", "", "currency the transaction was made in")
+		flags.StringVar(&details.Description, "description", "", "description")
+		flags.StringVar(&details.ExternalID, "external-id", "", "idempotency key")
+		if err := parseFlags(flags, args[1:]); err != nil {
+			return err
+		}
+		if *accountID <= 0 {
+			return fmt.Errorf("%s: --account is required\n\n%w", flags.Name(), errUsage)
+		}
+
+		created, err := a.createTransaction.Execute(ctx, *accountID, *operationTypeID, *amount, details)
+		if err != nil {
+			return err
+		}
+		return a.printer.Print(newTransactionView(created))
+	case "list":
+		accountID, err := accountFlag("transactions list", args[1:])
+		if err != nil {
+			return err
+		}
+
+		transactions, err := a.listTransactions.Execute(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		return a.printer.Print(newTransactionsView(transactions))
+	}
+
+	return errUsage
+}
+
+func (a *app) balances(ctx context.Context, args []string) error {
+	accountID, err := accountFlag("balances", args)
+	if err != nil {
+		return err
+	}
+
+	balance, err := a.getBalance.Execute(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	return a.printer.Print(newBalanceView(balance))
+}
+
+func (a *app) discharge(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "replay" {
+		return errUsage
+	}
+
+	accountID, err := accountFlag("discharge replay", args[1:])
+	if err != nil {
+		return err
+	}
+
+	changed, err := a.replayDischarge.Execute(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	return a.printer.Print(newTransactionsView(changed))
+}
+
+func (a *app) apiKeys(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "create":
+		flags := newFlagSet("api-keys create")
+		clientID := flags.String("client", "", "client the key is issued to")
+		scopes := flags.String("scopes", "", "comma separated scopes")
+		if err := parseFlags(flags, args[1:]); err != nil {
+			return err
+		}
+
+		var parsed []domain.Scope
+		for _, scope := range strings.Split(*scopes, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				parsed = append(parsed, domain.Scope(scope))
+			}
+		}
+
+		created, key, err := a.createAPIKey.Execute(ctx, *clientID, parsed)
+		if err != nil {
+			return err
+		}
+		return a.printer.Print(newAPIKeyView(created, key))
+	case "list":
+		if err := parseFlags(newFlagSet("api-keys list"), args[1:]); err != nil {
+			return err
+		}
+
+		keys, err := a.listAPIKeys.Execute(ctx)
+		if err != nil {
+			return err
+		}
+		return a.printer.Print(newAPIKeysView(keys))
+	case "revoke":
+		if len(args) != 2 {
+			return errUsage
+		}
+		apiKeyID, err := parseID(args[1])
+		if err != nil {
+			return err
+		}
+
+		if err := a.revokeAPIKey.Execute(ctx, apiKeyID); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.out, "revoked api key %d\n", apiKeyID)
+		return nil
+	}
+
+	return errUsage
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+// parseFlags parses args and rejects leftover positional arguments, reporting
+// both as usage errors.
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%s: %v\n\n%w", flags.Name(), err, errUsage)
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("%s: unexpected argument %q\n\n%w", flags.Name(), flags.Arg(0), errUsage)
+	}
+	return nil
+}
+
+// accountFlag parses commands whose only argument is a required --account.
+func accountFlag(name string, args []string) (int64, error) {
+	flags := newFlagSet(name)
+	accountID := flags.Int64("account", 0, "account ID")
+	if err := parseFlags(flags, args); err != nil {
+		return 0, err
+	}
+	if *accountID <= 0 {
+		return 0, fmt.Errorf("%s: --account is required\n\n%w", name, errUsage)
+	}
+	return *accountID, nil
+}
+
+func parseID(value string) (int64, error) {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID %q\n\n%w", value, errUsage)
+	}
+	return id, nil
+}
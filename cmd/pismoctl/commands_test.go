@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/cli"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/account"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/apikey"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/transaction"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestApp(t *testing.T, format string, accountRepo domain.AccountRepository, transactionRepo domain.TransactionRepository, apiKeyRepo domain.APIKeyRepository) (*app, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	printer, err := cli.NewPrinter(out, format)
+	assert.NoError(t, err)
+
+	return &app{
+		printer:          printer,
+		out:              out,
+		createAccount:    account.NewCreateAccount(accountRepo),
+		getAccount:       account.NewGetAccount(accountRepo),
+		listTransactions: transaction.NewListTransactions(transactionRepo, accountRepo),
+		getBalance:       transaction.NewGetBalance(transactionRepo, accountRepo),
+		replayDischarge:  transaction.NewReplayDischarge(transactionRepo, accountRepo),
+		createAPIKey:     apikey.NewCreateAPIKey(apiKeyRepo),
+		listAPIKeys:      apikey.NewListAPIKeys(apiKeyRepo),
+		revokeAPIKey:     apikey.NewRevokeAPIKey(apiKeyRepo),
+	}, out
+}
+
+func TestApp_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	mockTransactionRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+
+	mockAccountRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&domain.Account{ID: 1, DocumentNumber: "12345678900", Currency: "BRL"}, nil).AnyTimes()
+
+	eventDate := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("prints an account as a table", func(t *testing.T) {
+		// given
+		a, out := newTestApp(t, "table", mockAccountRepo, mockTransactionRepo, mockAPIKeyRepo)
+
+		// when
+		err := a.run(context.Background(), []string{"accounts", "get", "1"})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "ACCOUNT ID  DOCUMENT NUMBER  CURRENCY\n1           12345678900      BRL\n", out.String())
+	})
+
+	t.Run("creates an account and prints it as json", func(t *testing.T) {
+		// given
+		a, out := newTestApp(t, "json", mockAccountRepo, mockTransactionRepo, mockAPIKeyRepo)
+
+		// when
+		mockAccountRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&domain.Account{ID: 2, DocumentNumber: "98765432100", Currency: "BRL"}, nil)
+
+		err := a.run(context.Background(), []string{"accounts", "create", "--document", "98765432100"})
+
+		// then
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"account_id": 2, "document_number": "98765432100", "currency": "BRL"}`, out.String())
+	})
+
+	t.Run("lists the ledger", func(t *testing.T) {
+		// given
+		a, out := newTestApp(t, "json", mockAccountRepo, mockTransactionRepo, mockAPIKeyRepo)
+		transactions := []*domain.Transaction{
+			{ID: 10, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.0, Balance: -50.0, EventDate: eventDate, SettlementCurrency: "BRL"},
+		}
+
+		// when
+		mockTransactionRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return(transactions, nil)
+
+		err := a.run(context.Background(), []string{"transactions", "list", "--account", "1"})
+
+		// then
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{
+			"transaction_id": 10,
+			"account_id": 1,
+			"operation_type_id": 1,
+			"amount": -50,
+			"balance": -50,
+			"currency": "BRL",
+			"event_date": "2026-01-15T10:00:00Z"
+		}]`, out.String())
+	})
+
+	t.Run("prints balances", func(t *testing.T) {
+		// given
+		a, out := newTestApp(t, "table", mockAccountRepo, mockTransactionRepo, mockAPIKeyRepo)
+		transactions := []*domain.Transaction{
+			{ID: 10, AccountID: 1, Amount: -50.0, Balance: -30.0},
+			{ID: 11, AccountID: 1, Amount: 20.0, Balance: 0},
+		}
+
+		// when
+		mockTransactionRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return(transactions, nil)
+
+		err := a.run(context.Background(), []string{"balances", "--account", "1"})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "ACCOUNT ID  OUTSTANDING  CREDIT  NET\n1           30.00        0.00    -30.00\n", out.String())
+	})
+
+	t.Run("replays discharge and prints the changed transactions", func(t *testing.T) {
+		// given
+		a, out := newTestApp(t, "json", mockAccountRepo, mockTransactionRepo, mockAPIKeyRepo)
+		transactions := []*domain.Transaction{
+			{ID: 10, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.0, Balance: -50.0, EventDate: eventDate},
+			{ID: 11, AccountID: 1, OperationTypeID: domain.OperationTypePayment, Amount: 20.0, Balance: 20.0, EventDate: eventDate},
+		}
+
+		// when
+		mockTransactionRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return(transactions, nil)
+		mockTransactionRepo.EXPECT().UpdateBalances(gomock.Any(), transactions).Return(nil)
+
+		err := a.run(context.Background(), []string{"discharge", "replay", "--account", "1"})
+
+		// then
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), `"balance": -30`)
+		assert.Contains(t, out.String(), `"balance": 0`)
+	})
+
+	t.Run("creates an api key and shows it once", func(t *testing.T) {
+		// given
+		a, out := newTestApp(t, "json", mockAccountRepo, mockTransactionRepo, mockAPIKeyRepo)
+
+		// when
+		mockAPIKeyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, apiKey *domain.APIKey) (*domain.APIKey, error) {
+			apiKey.ID = 3
+			return apiKey, nil
+		})
+
+		err := a.run(context.Background(), []string{"api-keys", "create", "--client", "billing", "--scopes", "accounts:read, transactions:write"})
+
+		// then
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), `"api_key_id": 3`)
+		assert.Contains(t, out.String(), `"key": "pismo_`)
+		assert.Contains(t, out.String(), `"transactions:write"`)
+	})
+
+	t.Run("lists api keys without the keys", func(t *testing.T) {
+		// given
+		a, out := newTestApp(t, "table", mockAccountRepo, mockTransactionRepo, mockAPIKeyRepo)
+		revokedAt := eventDate.Add(time.Hour)
+
+		// when
+		mockAPIKeyRepo.EXPECT().List(gomock.Any()).Return([]*domain.APIKey{
+			{ID: 3, ClientID: "billing", Prefix: "pismo_ab12cd34", Scopes: []domain.Scope{domain.ScopeAccountsRead}, CreatedAt: eventDate, RevokedAt: &revokedAt},
+		}, nil)
+
+		err := a.run(context.Background(), []string{"api-keys", "list"})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "API KEY ID  CLIENT ID  PREFIX          SCOPES         CREATED AT            REVOKED AT\n"+
+			"3           billing    pismo_ab12cd34  accounts:read  2026-01-15T10:00:00Z  2026-01-15T11:00:00Z\n", out.String())
+	})
+
+	t.Run("revokes an api key", func(t *testing.T) {
+		// given
+		a, out := newTestApp(t, "table", mockAccountRepo, mockTransactionRepo, mockAPIKeyRepo)
+
+		// when
+		mockAPIKeyRepo.EXPECT().Revoke(gomock.Any(), int64(3), gomock.Any()).Return(nil)
+
+		err := a.run(context.Background(), []string{"api-keys", "revoke", "3"})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "revoked api key 3\n", out.String())
+	})
+
+	t.Run("returns domain errors", func(t *testing.T) {
+		// given
+		a, _ := newTestApp(t, "table", mockAccountRepo, mockTransactionRepo, mockAPIKeyRepo)
+
+		// when
+		mockAccountRepo.EXPECT().FindByID(gomock.Any(), int64(999)).Return(nil, domain.ErrAccountNotFound)
+
+		err := a.run(context.Background(), []string{"balances", "--account", "999"})
+
+		// then
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+
+	t.Run("returns usage error for invalid arguments", func(t *testing.T) {
+		a, _ := newTestApp(t, "table", mockAccountRepo, mockTransactionRepo, mockAPIKeyRepo)
+		invalid := [][]string{
+			nil,
+			{"unknown"},
+			{"accounts"},
+			{"accounts", "get"},
+			{"accounts", "get", "abc"},
+			{"accounts", "create", "--unknown"},
+			{"transactions", "list"},
+			{"transactions", "create", "--amount", "10"},
+			{"balances", "--account", "1", "extra"},
+			{"discharge", "--account", "1"},
+			{"api-keys"},
+			{"api-keys", "revoke"},
+		}
+
+		for _, args := range invalid {
+			err := a.run(context.Background(), args)
+
+			assert.ErrorIs(t, err, errUsage, "args %v", args)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+// Command pismoctl operates the service from a terminal. It talks to the
+// database directly through the same use cases as the API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/cli"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/config"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database/pgxdb"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/fx"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/account"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/apikey"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/fraud"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/transaction"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+func main() {
+	flags := flag.NewFlagSet("pismoctl", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	output := flags.String("o", string(cli.FormatTable), "output format, table or json")
+	if err := flags.Parse(os.Args[1:]); err != nil || flags.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	printer, err := cli.NewPrinter(os.Stdout, *output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// Flags belong to pismoctl, so only the file and the environment apply.
+	cfg, err := config.Load(nil)
+	if err != nil {
+		fail(fmt.Errorf("invalid configuration:\n%w", err))
+	}
+
+	// Data stored in memory belongs to the process holding it.
+	if cfg.Storage == "memory" {
+		fail(errors.New("pismoctl requires postgres storage"))
+	}
+
+	logger.Init(cfg.Environment, logger.Redaction{Mode: logger.RedactionMode(cfg.Log.Redaction), HashKey: []byte(cfg.Log.HashKey)})
+
+	sqlPool, pgxPool := cfg.Database.Pools()
+	db, err := database.NewPostgresDB(sqlPool)
+	if err != nil {
+		fail(fmt.Errorf("failed to connect to database: %w", err))
+	}
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		fail(fmt.Errorf("failed to load migrations: %w", err))
+	}
+
+	fxRates, err := fx.ParseRates(cfg.FX.Rates)
+	if err != nil {
+		fail(fmt.Errorf("failed to parse fx rates: %w", err))
+	}
+
+	// Repositories, accounts and transactions served by the configured driver
+	var accountRepo domain.AccountRepository = database.NewAccountRepository(db)
+	var transactionRepo domain.TransactionRepository = database.NewTransactionRepository(db)
+	if cfg.Database.Driver == "pgx" {
+		pool, err := pgxdb.NewPool(context.Background(), pgxPool)
+		if err != nil {
+			fail(fmt.Errorf("failed to connect to database: %w", err))
+		}
+		defer pool.Close()
+
+		accountRepo = pgxdb.NewAccountRepository(pool)
+		transactionRepo = pgxdb.NewTransactionRepository(pool)
+	}
+	spendingControlsRepo := database.NewSpendingControlsRepository(db)
+	cardRepo := database.NewCardRepository(db)
+	fraudRuleRepo := database.NewFraudRuleRepository(db)
+	fraudAssessmentRepo := database.NewFraudAssessmentRepository(db)
+	apiKeyRepo := database.NewAPIKeyRepository(db)
+
+	fraudEngine := fraud.NewEngine(fraudRuleRepo, fraudAssessmentRepo, transactionRepo, cfg.Fraud.RulesRefreshInterval)
+
+	a := &app{
+		printer:           printer,
+		out:               os.Stdout,
+		migrator:          migrator,
+		createAccount:     account.NewCreateAccount(accountRepo),
+		getAccount:        account.NewGetAccount(accountRepo),
+		createTransaction: transaction.NewCreateTransaction(transactionRepo, accountRepo, cardRepo, fx.NewStaticRateProvider(fxRates), spendingControlsRepo, fraudEngine),
+		listTransactions:  transaction.NewListTransactions(transactionRepo, accountRepo),
+		getBalance:        transaction.NewGetBalance(transactionRepo, accountRepo),
+		replayDischarge:   transaction.NewReplayDischarge(transactionRepo, accountRepo),
+		createAPIKey:      apikey.NewCreateAPIKey(apiKeyRepo),
+		listAPIKeys:       apikey.NewListAPIKeys(apiKeyRepo),
+		revokeAPIKey:      apikey.NewRevokeAPIKey(apiKeyRepo),
+	}
+
+	if err := a.run(context.Background(), flags.Args()); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, cli.ErrMigrateUsage) {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "pismoctl:", err)
+	os.Exit(1)
+}
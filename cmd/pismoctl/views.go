@@ -0,0 +1,196 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+type accountView struct {
+	AccountID      int64  `json:"account_id"`
+	DocumentNumber string `json:"document_number"`
+	Currency       string `json:"currency"`
+}
+
+func newAccountView(account *domain.Account) accountView {
+	return accountView{
+		AccountID:      account.ID,
+		DocumentNumber: account.DocumentNumber,
+		Currency:       account.Currency,
+	}
+}
+
+func (v accountView) Header() []string {
+	return []string{"ACCOUNT ID", "DOCUMENT NUMBER", "CURRENCY"}
+}
+
+func (v accountView) Rows() [][]string {
+	return [][]string{{formatID(v.AccountID), v.DocumentNumber, v.Currency}}
+}
+
+type transactionView struct {
+	TransactionID   int64     `json:"transaction_id"`
+	AccountID       int64     `json:"account_id"`
+	OperationTypeID int       `json:"operation_type_id"`
+	Amount          float64   `json:"amount"`
+	Balance         float64   `json:"balance"`
+	Currency        string    `json:"currency,omitempty"`
+	EventDate       time.Time `json:"event_date"`
+	ExternalID      string    `json:"external_id,omitempty"`
+	Description     string    `json:"description,omitempty"`
+}
+
+func newTransactionView(transaction *domain.Transaction) transactionView {
+	return transactionView{
+		TransactionID:   transaction.ID,
+		AccountID:       transaction.AccountID,
+		OperationTypeID: int(transaction.OperationTypeID),
+		Amount:          transaction.Amount,
+		Balance:         transaction.Balance,
+		Currency:        transaction.SettlementCurrency,
+		EventDate:       transaction.EventDate.UTC(),
+		ExternalID:      transaction.ExternalID,
+		Description:     transaction.Description,
+	}
+}
+
+func (v transactionView) Header() []string {
+	return transactionsView{}.Header()
+}
+
+func (v transactionView) Rows() [][]string {
+	return transactionsView{v}.Rows()
+}
+
+// transactionsView prints a ledger, one transaction per row.
+type transactionsView []transactionView
+
+func newTransactionsView(transactions []*domain.Transaction) transactionsView {
+	view := make(transactionsView, 0, len(transactions))
+	for _, transaction := range transactions {
+		view = append(view, newTransactionView(transaction))
+	}
+	return view
+}
+
+func (v transactionsView) Header() []string {
+	return []string{"TRANSACTION ID", "EVENT DATE", "OPERATION TYPE", "AMOUNT", "BALANCE", "CURRENCY", "DESCRIPTION"}
+}
+
+func (v transactionsView) Rows() [][]string {
+	rows := make([][]string, 0, len(v))
+	for _, transaction := range v {
+		rows = append(rows, []string{
+			formatID(transaction.TransactionID),
+			transaction.EventDate.Format(time.RFC3339),
+			strconv.Itoa(transaction.OperationTypeID),
+			formatAmount(transaction.Amount),
+			formatAmount(transaction.Balance),
+			transaction.Currency,
+			transaction.Description,
+		})
+	}
+	return rows
+}
+
+type balanceView struct {
+	AccountID   int64   `json:"account_id"`
+	Outstanding float64 `json:"outstanding"`
+	Credit      float64 `json:"credit"`
+	Net         float64 `json:"net"`
+}
+
+func newBalanceView(balance *domain.AccountBalance) balanceView {
+	return balanceView{
+		AccountID:   balance.AccountID,
+		Outstanding: balance.Outstanding,
+		Credit:      balance.Credit,
+		Net:         balance.Net(),
+	}
+}
+
+func (v balanceView) Header() []string {
+	return []string{"ACCOUNT ID", "OUTSTANDING", "CREDIT", "NET"}
+}
+
+func (v balanceView) Rows() [][]string {
+	return [][]string{{formatID(v.AccountID), formatAmount(v.Outstanding), formatAmount(v.Credit), formatAmount(v.Net)}}
+}
+
+type apiKeyView struct {
+	APIKeyID  int64      `json:"api_key_id"`
+	ClientID  string     `json:"client_id"`
+	Prefix    string     `json:"prefix"`
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	// Key is only known right after the key is created.
+	Key string `json:"key,omitempty"`
+}
+
+func newAPIKeyView(apiKey *domain.APIKey, key string) apiKeyView {
+	view := apiKeyView{
+		APIKeyID:  apiKey.ID,
+		ClientID:  apiKey.ClientID,
+		Prefix:    apiKey.Prefix,
+		CreatedAt: apiKey.CreatedAt.UTC(),
+		RevokedAt: apiKey.RevokedAt,
+		Key:       key,
+	}
+	for _, scope := range apiKey.Scopes {
+		view.Scopes = append(view.Scopes, string(scope))
+	}
+	return view
+}
+
+func (v apiKeyView) Header() []string {
+	return []string{"API KEY ID", "CLIENT ID", "SCOPES", "KEY"}
+}
+
+func (v apiKeyView) Rows() [][]string {
+	return [][]string{{formatID(v.APIKeyID), v.ClientID, strings.Join(v.Scopes, ","), v.Key}}
+}
+
+// apiKeysView lists keys by their prefix, the keys themselves are not stored.
+type apiKeysView []apiKeyView
+
+func newAPIKeysView(keys []*domain.APIKey) apiKeysView {
+	view := make(apiKeysView, 0, len(keys))
+	for _, key := range keys {
+		view = append(view, newAPIKeyView(key, ""))
+	}
+	return view
+}
+
+func (v apiKeysView) Header() []string {
+	return []string{"API KEY ID", "CLIENT ID", "PREFIX", "SCOPES", "CREATED AT", "REVOKED AT"}
+}
+
+func (v apiKeysView) Rows() [][]string {
+	rows := make([][]string, 0, len(v))
+	for _, key := range v {
+		revokedAt := "-"
+		if key.RevokedAt != nil {
+			revokedAt = key.RevokedAt.UTC().Format(time.RFC3339)
+		}
+		rows = append(rows, []string{
+			formatID(key.APIKeyID),
+			key.ClientID,
+			key.Prefix,
+			strings.Join(key.Scopes, ","),
+			key.CreatedAt.Format(time.RFC3339),
+			revokedAt,
+		})
+	}
+	return rows
+}
+
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
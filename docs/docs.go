@@ -0,0 +1,8 @@
+// Package docs embeds the API documentation, so that the service can check
+// requests against the same document clients read.
+package docs
+
+import _ "embed"
+
+//go:embed openapi.yaml
+var OpenAPI []byte
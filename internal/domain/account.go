@@ -10,17 +10,29 @@ type AccountRepository interface {
 	FindByID(ctx context.Context, ID int64) (*Account, error)
 }
 
+// DefaultCurrency is the settlement currency of accounts created without one.
+const DefaultCurrency = "BRL"
+
 type Account struct {
 	ID             int64
 	DocumentNumber string
+	Currency       string
 }
 
-func NewAccount(documentNumber string) (*Account, error) {
+func NewAccount(documentNumber string, currency string) (*Account, error) {
 	if documentNumber == "" {
 		return nil, ErrInvalidDocumentNumber
 	}
 
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	if !currencyPattern.MatchString(currency) {
+		return nil, ErrInvalidCurrency
+	}
+
 	return &Account{
 		DocumentNumber: documentNumber,
+		Currency:       currency,
 	}, nil
 }
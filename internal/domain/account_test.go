@@ -8,18 +8,33 @@ import (
 
 func TestNewAccount(t *testing.T) {
 	t.Run("creates account when provided document number is not empty", func(t *testing.T) {
-		account, err := NewAccount("12345678900")
+		account, err := NewAccount("12345678900", "USD")
 
 		assert.NoError(t, err)
 		assert.NotNil(t, account)
 		assert.Equal(t, "12345678900", account.DocumentNumber)
+		assert.Equal(t, "USD", account.Currency)
 		assert.Equal(t, int64(0), account.ID)
 	})
 
+	t.Run("uses default currency when none is provided", func(t *testing.T) {
+		account, err := NewAccount("12345678900", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultCurrency, account.Currency)
+	})
+
 	t.Run("returns error when document number is empty", func(t *testing.T) {
-		account, err := NewAccount("")
+		account, err := NewAccount("", "")
 
 		assert.Nil(t, account)
 		assert.ErrorIs(t, err, ErrInvalidDocumentNumber)
 	})
+
+	t.Run("returns error when currency is invalid", func(t *testing.T) {
+		account, err := NewAccount("12345678900", "real")
+
+		assert.Nil(t, account)
+		assert.ErrorIs(t, err, ErrInvalidCurrency)
+	})
 }
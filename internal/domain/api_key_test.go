@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	t.Run("generates a key and stores only its hash", func(t *testing.T) {
+		apiKey, key, err := NewAPIKey(" billing ", []Scope{ScopeTransactionsWrite, ScopeAccountsRead, ScopeAccountsRead})
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(key, "pismo_"))
+		assert.Len(t, key, len("pismo_")+48)
+		assert.Equal(t, "billing", apiKey.ClientID)
+		assert.Equal(t, key[:14], apiKey.Prefix)
+		assert.Equal(t, HashAPIKey(key), apiKey.Hash)
+		assert.NotContains(t, apiKey.Hash, key)
+		assert.Equal(t, []Scope{ScopeAccountsRead, ScopeTransactionsWrite}, apiKey.Scopes)
+		assert.False(t, apiKey.IsRevoked())
+	})
+
+	t.Run("generates a different key each time", func(t *testing.T) {
+		_, first, _ := NewAPIKey("billing", []Scope{ScopeAccountsRead})
+		_, second, _ := NewAPIKey("billing", []Scope{ScopeAccountsRead})
+
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("issues keys resolving disputes", func(t *testing.T) {
+		apiKey, _, err := NewAPIKey("back-office", []Scope{ScopeDisputesResolve})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Scope{ScopeDisputesResolve}, apiKey.Scopes)
+	})
+
+	t.Run("requires a client ID", func(t *testing.T) {
+		_, _, err := NewAPIKey("  ", []Scope{ScopeAccountsRead})
+
+		assert.ErrorIs(t, err, ErrInvalidClientID)
+	})
+
+	t.Run("requires valid scopes", func(t *testing.T) {
+		_, _, err := NewAPIKey("billing", nil)
+		assert.ErrorIs(t, err, ErrInvalidScope)
+
+		_, _, err = NewAPIKey("billing", []Scope{"accounts:delete"})
+		assert.ErrorIs(t, err, ErrInvalidScope)
+	})
+}
+
+func TestAPIKey_HasScope(t *testing.T) {
+	apiKey := &APIKey{Scopes: []Scope{ScopeAccountsRead}}
+
+	assert.True(t, apiKey.HasScope(ScopeAccountsRead))
+	assert.False(t, apiKey.HasScope(ScopeAccountsWrite))
+}
+
+func TestAPIKey_IsRevoked(t *testing.T) {
+	revokedAt := time.Now()
+
+	assert.False(t, (&APIKey{}).IsRevoked())
+	assert.True(t, (&APIKey{RevokedAt: &revokedAt}).IsRevoked())
+}
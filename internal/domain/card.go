@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CardType string
+
+const (
+	CardTypePhysical CardType = "physical"
+	CardTypeVirtual  CardType = "virtual"
+)
+
+func (c CardType) IsValid() bool {
+	return c == CardTypePhysical || c == CardTypeVirtual
+}
+
+type CardStatus string
+
+const (
+	CardStatusActive  CardStatus = "active"
+	CardStatusBlocked CardStatus = "blocked"
+)
+
+const (
+	// issuerBIN is the bank identification number prefixed to issued PANs.
+	issuerBIN = "400000"
+	panLength = 16
+
+	cardValidityYears = 4
+)
+
+//go:generate mockgen -source=card.go -destination=mocks/card_mock.go -package=mocks
+type CardRepository interface {
+	Create(ctx context.Context, card *Card) (*Card, error)
+	FindByID(ctx context.Context, ID int64) (*Card, error)
+	UpdateStatus(ctx context.Context, card *Card) (*Card, error)
+}
+
+// Card never holds the full PAN, only its masked form and the token used to
+// reference it with the card network.
+type Card struct {
+	ID        int64
+	AccountID int64
+	MaskedPAN string
+	Last4     string
+	Token     string
+	ExpiresAt time.Time
+	Status    CardStatus
+	Type      CardType
+	CreatedAt time.Time
+}
+
+func NewCard(accountID int64, cardType CardType) (*Card, error) {
+	if !cardType.IsValid() {
+		return nil, ErrInvalidCardType
+	}
+
+	pan, err := generatePAN()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	// Cards are valid through the last instant of their expiry month.
+	expiresAt := time.Date(now.Year()+cardValidityYears, now.Month()+1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)
+
+	return &Card{
+		AccountID: accountID,
+		MaskedPAN: pan[:6] + strings.Repeat("*", len(pan)-10) + pan[len(pan)-4:],
+		Last4:     pan[len(pan)-4:],
+		Token:     uuid.New().String(),
+		ExpiresAt: expiresAt,
+		Status:    CardStatusActive,
+		Type:      cardType,
+		CreatedAt: now,
+	}, nil
+}
+
+func (c *Card) Block() {
+	c.Status = CardStatusBlocked
+}
+
+func (c *Card) IsExpired(at time.Time) bool {
+	return at.After(c.ExpiresAt)
+}
+
+// CanTransact returns why the card cannot be used at the given instant, if any.
+func (c *Card) CanTransact(at time.Time) error {
+	if c.Status == CardStatusBlocked {
+		return ErrCardBlocked
+	}
+	if c.IsExpired(at) {
+		return ErrCardExpired
+	}
+	return nil
+}
+
+// generatePAN returns a random PAN under issuerBIN with a valid Luhn check digit.
+func generatePAN() (string, error) {
+	digits := []byte(issuerBIN)
+	for len(digits) < panLength-1 {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		digits = append(digits, byte('0'+n.Int64()))
+	}
+
+	return string(append(digits, luhnCheckDigit(digits))), nil
+}
+
+func luhnCheckDigit(digits []byte) byte {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCard(t *testing.T) {
+	t.Run("issues active card with masked PAN", func(t *testing.T) {
+		card, err := NewCard(1, CardTypeVirtual)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), card.AccountID)
+		assert.Equal(t, CardStatusActive, card.Status)
+		assert.Equal(t, CardTypeVirtual, card.Type)
+		assert.Len(t, card.MaskedPAN, panLength)
+		assert.Regexp(t, `^400000\*{6}[0-9]{4}$`, card.MaskedPAN)
+		assert.Equal(t, card.MaskedPAN[12:], card.Last4)
+		assert.NotEmpty(t, card.Token)
+		assert.True(t, card.ExpiresAt.After(time.Now().AddDate(cardValidityYears, -1, 0)))
+	})
+
+	t.Run("returns error for invalid card type", func(t *testing.T) {
+		card, err := NewCard(1, "plastic")
+
+		assert.Nil(t, card)
+		assert.ErrorIs(t, err, ErrInvalidCardType)
+	})
+}
+
+func TestCard_CanTransact(t *testing.T) {
+	now := time.Now()
+
+	t.Run("accepts active card within validity", func(t *testing.T) {
+		card := &Card{Status: CardStatusActive, ExpiresAt: now.Add(time.Hour)}
+
+		assert.NoError(t, card.CanTransact(now))
+	})
+
+	t.Run("rejects blocked card", func(t *testing.T) {
+		card := &Card{Status: CardStatusActive, ExpiresAt: now.Add(time.Hour)}
+		card.Block()
+
+		assert.ErrorIs(t, card.CanTransact(now), ErrCardBlocked)
+	})
+
+	t.Run("rejects expired card", func(t *testing.T) {
+		card := &Card{Status: CardStatusActive, ExpiresAt: now.Add(-time.Hour)}
+
+		assert.ErrorIs(t, card.CanTransact(now), ErrCardExpired)
+	})
+}
+
+func TestLuhnCheckDigit(t *testing.T) {
+	assert.Equal(t, byte('1'), luhnCheckDigit([]byte("411111111111111")))
+	assert.Equal(t, byte('3'), luhnCheckDigit([]byte("7992739871")))
+}
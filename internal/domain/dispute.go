@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+type DisputeStatus string
+
+const (
+	DisputeStatusOpened      DisputeStatus = "opened"
+	DisputeStatusUnderReview DisputeStatus = "under_review"
+	DisputeStatusWon         DisputeStatus = "won"
+	DisputeStatusLost        DisputeStatus = "lost"
+)
+
+func (s DisputeStatus) IsValid() bool {
+	switch s {
+	case DisputeStatusOpened, DisputeStatusUnderReview, DisputeStatusWon, DisputeStatusLost:
+		return true
+	}
+	return false
+}
+
+func (s DisputeStatus) IsResolved() bool {
+	return s == DisputeStatusWon || s == DisputeStatusLost
+}
+
+// disputeTransitions lists the statuses each status can move to. Resolved
+// disputes are final.
+var disputeTransitions = map[DisputeStatus][]DisputeStatus{
+	DisputeStatusOpened:      {DisputeStatusUnderReview, DisputeStatusWon, DisputeStatusLost},
+	DisputeStatusUnderReview: {DisputeStatusWon, DisputeStatusLost},
+}
+
+//go:generate mockgen -source=dispute.go -destination=mocks/dispute_mock.go -package=mocks
+type DisputeRepository interface {
+	Create(ctx context.Context, dispute *Dispute) (*Dispute, error)
+	FindByID(ctx context.Context, ID int64) (*Dispute, error)
+	ListByTransactionID(ctx context.Context, transactionID int64) ([]*Dispute, error)
+	Update(ctx context.Context, dispute *Dispute) (*Dispute, error)
+}
+
+// DisputeEvent records a status change along with the note left by whoever
+// made it.
+type DisputeEvent struct {
+	ID        int64
+	Status    DisputeStatus
+	Note      string
+	CreatedAt time.Time
+}
+
+// Dispute is a customer's challenge of a debit. Amount is positive and in the
+// account's settlement currency.
+type Dispute struct {
+	ID                             int64
+	TransactionID                  int64
+	AccountID                      int64
+	Amount                         float64
+	Reason                         string
+	Status                         DisputeStatus
+	ProvisionalCreditTransactionID int64
+	FinalTransactionID             int64
+	Events                         []DisputeEvent
+	OpenedAt                       time.Time
+	UpdatedAt                      time.Time
+	ResolvedAt                     time.Time
+}
+
+func NewDispute(transaction *Transaction, reason, note string) (*Dispute, error) {
+	if !transaction.OperationTypeID.IsDebit() || transaction.OperationTypeID.IsAdjustment() {
+		return nil, ErrTransactionNotDisputable
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrInvalidDisputeReason
+	}
+
+	now := time.Now().UTC()
+
+	return &Dispute{
+		TransactionID: transaction.ID,
+		AccountID:     transaction.AccountID,
+		Amount:        math.Abs(transaction.Amount),
+		Reason:        reason,
+		Status:        DisputeStatusOpened,
+		Events:        []DisputeEvent{{Status: DisputeStatusOpened, Note: note, CreatedAt: now}},
+		OpenedAt:      now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// LoadedStatus is the status the dispute was loaded with, the one of its last
+// persisted event. Repositories only save a dispute still in that status, so
+// that of two concurrent transitions only the first is saved.
+func (d *Dispute) LoadedStatus() DisputeStatus {
+	for i := len(d.Events) - 1; i >= 0; i-- {
+		if d.Events[i].ID != 0 {
+			return d.Events[i].Status
+		}
+	}
+	return d.Status
+}
+
+// Transition moves the dispute to status and appends an event for it.
+func (d *Dispute) Transition(status DisputeStatus, note string, at time.Time) error {
+	if !status.IsValid() {
+		return ErrInvalidDisputeStatus
+	}
+
+	if !slices.Contains(disputeTransitions[d.Status], status) {
+		return ErrInvalidDisputeTransition
+	}
+
+	d.Status = status
+	d.UpdatedAt = at
+	if status.IsResolved() {
+		d.ResolvedAt = at
+	}
+	d.Events = append(d.Events, DisputeEvent{Status: status, Note: note, CreatedAt: at})
+
+	return nil
+}
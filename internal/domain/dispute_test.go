@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDispute(t *testing.T) {
+	t.Run("opens dispute for the debited amount", func(t *testing.T) {
+		transaction := &Transaction{ID: 10, AccountID: 1, OperationTypeID: OperationTypePurchase, Amount: -50.0}
+
+		dispute, err := NewDispute(transaction, "item not received", "customer called")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), dispute.TransactionID)
+		assert.Equal(t, int64(1), dispute.AccountID)
+		assert.Equal(t, 50.0, dispute.Amount)
+		assert.Equal(t, DisputeStatusOpened, dispute.Status)
+		assert.Len(t, dispute.Events, 1)
+		assert.Equal(t, "customer called", dispute.Events[0].Note)
+		assert.True(t, dispute.ResolvedAt.IsZero())
+	})
+
+	t.Run("returns error when reason is blank", func(t *testing.T) {
+		transaction := &Transaction{OperationTypeID: OperationTypePurchase, Amount: -50.0}
+
+		dispute, err := NewDispute(transaction, "  ", "")
+
+		assert.Nil(t, dispute)
+		assert.ErrorIs(t, err, ErrInvalidDisputeReason)
+	})
+
+	t.Run("returns error for transactions that are not disputable", func(t *testing.T) {
+		for _, operationType := range []OperationType{OperationTypePayment, OperationTypeCreditVoucher, OperationTypeDisputeReversal} {
+			transaction := &Transaction{OperationTypeID: operationType, Amount: 50.0}
+
+			dispute, err := NewDispute(transaction, "fraud", "")
+
+			assert.Nil(t, dispute)
+			assert.ErrorIs(t, err, ErrTransactionNotDisputable, "operation type %d", operationType)
+		}
+	})
+}
+
+func TestDispute_Transition(t *testing.T) {
+	at := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	t.Run("moves through review to resolution", func(t *testing.T) {
+		dispute := &Dispute{Status: DisputeStatusOpened}
+
+		assert.NoError(t, dispute.Transition(DisputeStatusUnderReview, "evidence requested", at))
+		assert.True(t, dispute.ResolvedAt.IsZero())
+		assert.NoError(t, dispute.Transition(DisputeStatusWon, "merchant did not respond", at))
+
+		assert.Equal(t, DisputeStatusWon, dispute.Status)
+		assert.Equal(t, at, dispute.ResolvedAt)
+		assert.Len(t, dispute.Events, 2)
+		assert.Equal(t, "merchant did not respond", dispute.Events[1].Note)
+	})
+
+	t.Run("resolves directly from opened", func(t *testing.T) {
+		dispute := &Dispute{Status: DisputeStatusOpened}
+
+		assert.NoError(t, dispute.Transition(DisputeStatusLost, "", at))
+		assert.Equal(t, DisputeStatusLost, dispute.Status)
+	})
+
+	t.Run("rejects changes to resolved disputes", func(t *testing.T) {
+		dispute := &Dispute{Status: DisputeStatusLost}
+
+		assert.ErrorIs(t, dispute.Transition(DisputeStatusWon, "", at), ErrInvalidDisputeTransition)
+		assert.Equal(t, DisputeStatusLost, dispute.Status)
+	})
+
+	t.Run("rejects moving back to opened", func(t *testing.T) {
+		dispute := &Dispute{Status: DisputeStatusUnderReview}
+
+		assert.ErrorIs(t, dispute.Transition(DisputeStatusOpened, "", at), ErrInvalidDisputeTransition)
+	})
+
+	t.Run("rejects unknown status", func(t *testing.T) {
+		dispute := &Dispute{Status: DisputeStatusOpened}
+
+		assert.ErrorIs(t, dispute.Transition("pending", "", at), ErrInvalidDisputeStatus)
+	})
+}
+
+func TestDispute_LoadedStatus(t *testing.T) {
+	t.Run("is the status of the last persisted event", func(t *testing.T) {
+		dispute := &Dispute{
+			Status: DisputeStatusUnderReview,
+			Events: []DisputeEvent{{ID: 1, Status: DisputeStatusOpened}, {ID: 2, Status: DisputeStatusUnderReview}},
+		}
+
+		require.NoError(t, dispute.Transition(DisputeStatusLost, "", time.Now()))
+
+		assert.Equal(t, DisputeStatusUnderReview, dispute.LoadedStatus())
+	})
+
+	t.Run("is the current status without persisted events", func(t *testing.T) {
+		dispute := &Dispute{Status: DisputeStatusOpened}
+
+		assert.Equal(t, DisputeStatusOpened, dispute.LoadedStatus())
+	})
+}
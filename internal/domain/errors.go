@@ -5,11 +5,39 @@ type ErrorKind int
 const (
 	KindValidation ErrorKind = iota
 	KindNotFound
+	// KindConflict is for requests clashing with the current state, such as
+	// duplicates or disallowed state transitions.
+	KindConflict
+	KindForbidden
+	// KindUnavailable is for failures of a dependency that are expected to
+	// clear up, so that clients can retry.
+	KindUnavailable
 )
 
+func (k ErrorKind) String() string {
+	switch k {
+	case KindValidation:
+		return "validation"
+	case KindNotFound:
+		return "not_found"
+	case KindConflict:
+		return "conflict"
+	case KindForbidden:
+		return "forbidden"
+	case KindUnavailable:
+		return "unavailable"
+	}
+	return "unknown"
+}
+
 type Error struct {
-	Kind    ErrorKind
+	Kind ErrorKind
+	// Code identifies the error for clients, unlike Message it never changes.
+	Code    string
 	Message string
+	// Field is the request field a validation error is about, when it is
+	// always the same one.
+	Field string
 }
 
 func (e *Error) Error() string {
@@ -17,9 +45,54 @@ func (e *Error) Error() string {
 }
 
 var (
-	ErrInvalidDocumentNumber = &Error{KindValidation, "document number is required"}
-	ErrAccountAlreadyExists  = &Error{KindValidation, "account already exists"}
-	ErrAccountNotFound       = &Error{KindNotFound, "account was not found"}
-	ErrInvalidOperationType  = &Error{KindValidation, "invalid operation type"}
-	ErrInvalidAmount         = &Error{KindValidation, "amount must be greater than zero"}
+	ErrInvalidDocumentNumber = &Error{KindValidation, "invalid_document_number", "document number is required", "document_number"}
+	ErrAccountAlreadyExists  = &Error{KindConflict, "account_already_exists", "account already exists", ""}
+	ErrAccountNotFound       = &Error{KindNotFound, "account_not_found", "account was not found", ""}
+	ErrInvalidOperationType  = &Error{KindValidation, "invalid_operation_type", "invalid operation type", ""}
+	ErrInvalidAmount         = &Error{KindValidation, "invalid_amount", "amount must be greater than zero", "amount"}
+
+	ErrTransactionNotFound         = &Error{KindNotFound, "transaction_not_found", "transaction was not found", ""}
+	ErrTransactionAlreadyExists    = &Error{KindConflict, "transaction_already_exists", "transaction with this external id already exists", ""}
+	ErrInvalidCurrency             = &Error{KindValidation, "invalid_currency", "currency must be a three-letter ISO 4217 code", "currency"}
+	ErrInvalidMerchantCategoryCode = &Error{KindValidation, "invalid_merchant_category_code", "merchant category code must have four digits", "merchant_category_code"}
+	ErrFXRateUnavailable           = &Error{KindValidation, "fx_rate_unavailable", "no exchange rate available for currency", "currency"}
+
+	ErrSpendingControlsNotFound       = &Error{KindNotFound, "spending_controls_not_found", "spending controls were not found", ""}
+	ErrInvalidTimeWindow              = &Error{KindValidation, "invalid_time_window", "time window must be in HH:MM format", "time_windows"}
+	ErrInvalidTimezone                = &Error{KindValidation, "invalid_timezone", "invalid timezone", "timezone"}
+	ErrOperationTypeNotAllowed        = &Error{KindValidation, "operation_type_not_allowed", "transaction declined by spending control: operation type is not allowed", ""}
+	ErrMerchantCategoryBlocked        = &Error{KindValidation, "merchant_category_blocked", "transaction declined by spending control: merchant category is blocked", ""}
+	ErrTransactionAmountLimitExceeded = &Error{KindValidation, "amount_limit_exceeded", "transaction declined by spending control: amount exceeds the per-transaction limit", ""}
+	ErrOutsideAllowedTimeWindow       = &Error{KindValidation, "outside_allowed_time_window", "transaction declined by spending control: outside of allowed time windows", ""}
+
+	ErrTransactionDeclinedByFraud = &Error{KindValidation, "declined_by_fraud", "transaction declined by fraud prevention", ""}
+
+	ErrCardNotFound    = &Error{KindNotFound, "card_not_found", "card was not found", ""}
+	ErrInvalidCardType = &Error{KindValidation, "invalid_card_type", "card type must be physical or virtual", "type"}
+	ErrCardBlocked     = &Error{KindValidation, "card_blocked", "card is blocked", ""}
+	ErrCardExpired     = &Error{KindValidation, "card_expired", "card is expired", ""}
+
+	ErrDisputeNotFound          = &Error{KindNotFound, "dispute_not_found", "dispute was not found", ""}
+	ErrDisputeAlreadyOpen       = &Error{KindConflict, "dispute_already_open", "transaction already has an unresolved dispute", ""}
+	ErrDisputeAlreadyWon        = &Error{KindConflict, "dispute_already_won", "transaction was already refunded by a won dispute", ""}
+	ErrDisputeNotCredited       = &Error{KindConflict, "dispute_not_credited", "dispute has no provisional credit yet, open it again to post it", ""}
+	ErrTransactionNotDisputable = &Error{KindValidation, "transaction_not_disputable", "only purchases and withdrawals can be disputed", ""}
+	ErrInvalidDisputeReason     = &Error{KindValidation, "invalid_dispute_reason", "dispute reason is required", "reason"}
+	ErrInvalidDisputeStatus     = &Error{KindValidation, "invalid_dispute_status", "dispute status must be opened, under_review, won or lost", "status"}
+	ErrInvalidDisputeTransition = &Error{KindConflict, "invalid_dispute_transition", "dispute cannot move to the requested status", ""}
+
+	ErrScheduledTransactionNotFound = &Error{KindNotFound, "scheduled_transaction_not_found", "scheduled transaction was not found", ""}
+	ErrInvalidScheduleAmountType    = &Error{KindValidation, "invalid_amount_type", "amount type must be fixed, or full_balance for payments", "amount_type"}
+	ErrInvalidScheduleRecurrence    = &Error{KindValidation, "invalid_recurrence", "recurrence must be once or monthly", "recurrence"}
+	ErrInvalidScheduleDayOfMonth    = &Error{KindValidation, "invalid_day_of_month", "day of month must be between 1 and 31", "day_of_month"}
+	ErrScheduleNotActive            = &Error{KindConflict, "schedule_not_active", "scheduled transaction is no longer active", ""}
+
+	ErrAPIKeyNotFound  = &Error{KindNotFound, "api_key_not_found", "api key was not found", ""}
+	ErrInvalidAPIKey   = &Error{KindValidation, "invalid_api_key", "api key is missing, invalid or revoked", ""}
+	ErrInvalidToken    = &Error{KindValidation, "invalid_token", "token is invalid or expired", ""}
+	ErrInvalidClientID = &Error{KindValidation, "invalid_client_id", "client id is required", "client_id"}
+	ErrInvalidScope    = &Error{KindValidation, "invalid_scope", "scopes must be accounts:read, accounts:write, transactions:write or disputes:resolve", "scopes"}
+
+	ErrAccountAccessDenied = &Error{KindForbidden, "account_access_denied", "access to this account is not allowed", ""}
+	ErrServiceUnavailable  = &Error{KindUnavailable, "service_unavailable", "service is temporarily unavailable, try again later", ""}
 )
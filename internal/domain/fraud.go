@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type FraudDecision string
+
+const (
+	FraudDecisionApprove FraudDecision = "approve"
+	FraudDecisionReview  FraudDecision = "review"
+	FraudDecisionDecline FraudDecision = "decline"
+)
+
+func (d FraudDecision) IsValid() bool {
+	switch d {
+	case FraudDecisionApprove, FraudDecisionReview, FraudDecisionDecline:
+		return true
+	}
+	return false
+}
+
+// Severity orders decisions so the strictest outcome of several rules wins.
+func (d FraudDecision) Severity() int {
+	switch d {
+	case FraudDecisionReview:
+		return 1
+	case FraudDecisionDecline:
+		return 2
+	}
+	return 0
+}
+
+type FraudRuleType string
+
+const (
+	// FraudRuleVelocityCount triggers when the account makes more than MaxCount
+	// debits within Window.
+	FraudRuleVelocityCount FraudRuleType = "velocity_count"
+	// FraudRuleVelocityAmount triggers when the account debits more than
+	// MaxAmount within Window.
+	FraudRuleVelocityAmount FraudRuleType = "velocity_amount"
+	// FraudRuleSpike triggers when a debit is SpikeMultiplier times larger than
+	// the account's average debit, once it has at least MinHistory debits.
+	FraudRuleSpike FraudRuleType = "spike"
+)
+
+type FraudRule struct {
+	ID              int64
+	Name            string
+	Type            FraudRuleType
+	Action          FraudDecision
+	Window          time.Duration
+	MaxCount        int
+	MaxAmount       float64
+	SpikeMultiplier float64
+	MinHistory      int
+	Enabled         bool
+}
+
+type FraudAssessment struct {
+	ID              int64
+	AccountID       int64
+	TransactionID   int64
+	OperationTypeID OperationType
+	Amount          float64
+	Decision        FraudDecision
+	TriggeredRules  []string
+	CreatedAt       time.Time
+}
+
+//go:generate mockgen -source=fraud.go -destination=mocks/fraud_mock.go -package=mocks
+type FraudRuleRepository interface {
+	ListEnabled(ctx context.Context) ([]*FraudRule, error)
+}
+
+type FraudAssessmentRepository interface {
+	Create(ctx context.Context, assessment *FraudAssessment) (*FraudAssessment, error)
+}
+
+// FraudScreener decides whether an incoming debit should be approved,
+// declined or approved but flagged for review.
+type FraudScreener interface {
+	Assess(ctx context.Context, transaction *Transaction) (*FraudAssessment, error)
+	Record(ctx context.Context, assessment *FraudAssessment) error
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=fx.go -destination=mocks/fx_mock.go -package=mocks
+type FXRateProvider interface {
+	// Rate returns how many units of the to currency one unit of the from
+	// currency buys at the given instant. It returns ErrFXRateUnavailable when
+	// the pair is not quoted.
+	Rate(ctx context.Context, from, to string, at time.Time) (float64, error)
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: card.go
+//
+// Generated by this command:
+//
+//	mockgen -source=card.go -destination=mocks/card_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/nubank/pismo-code-assessment/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCardRepository is a mock of CardRepository interface.
+type MockCardRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCardRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCardRepositoryMockRecorder is the mock recorder for MockCardRepository.
+type MockCardRepositoryMockRecorder struct {
+	mock *MockCardRepository
+}
+
+// NewMockCardRepository creates a new mock instance.
+func NewMockCardRepository(ctrl *gomock.Controller) *MockCardRepository {
+	mock := &MockCardRepository{ctrl: ctrl}
+	mock.recorder = &MockCardRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCardRepository) EXPECT() *MockCardRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCardRepository) Create(ctx context.Context, card *domain.Card) (*domain.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, card)
+	ret0, _ := ret[0].(*domain.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCardRepositoryMockRecorder) Create(ctx, card any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCardRepository)(nil).Create), ctx, card)
+}
+
+// FindByID mocks base method.
+func (m *MockCardRepository) FindByID(ctx context.Context, ID int64) (*domain.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*domain.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockCardRepositoryMockRecorder) FindByID(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCardRepository)(nil).FindByID), ctx, ID)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockCardRepository) UpdateStatus(ctx context.Context, card *domain.Card) (*domain.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, card)
+	ret0, _ := ret[0].(*domain.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockCardRepositoryMockRecorder) UpdateStatus(ctx, card any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockCardRepository)(nil).UpdateStatus), ctx, card)
+}
@@ -0,0 +1,149 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fraud.go
+//
+// Generated by this command:
+//
+//	mockgen -source=fraud.go -destination=mocks/fraud_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/nubank/pismo-code-assessment/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFraudRuleRepository is a mock of FraudRuleRepository interface.
+type MockFraudRuleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFraudRuleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFraudRuleRepositoryMockRecorder is the mock recorder for MockFraudRuleRepository.
+type MockFraudRuleRepositoryMockRecorder struct {
+	mock *MockFraudRuleRepository
+}
+
+// NewMockFraudRuleRepository creates a new mock instance.
+func NewMockFraudRuleRepository(ctrl *gomock.Controller) *MockFraudRuleRepository {
+	mock := &MockFraudRuleRepository{ctrl: ctrl}
+	mock.recorder = &MockFraudRuleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFraudRuleRepository) EXPECT() *MockFraudRuleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListEnabled mocks base method.
+func (m *MockFraudRuleRepository) ListEnabled(ctx context.Context) ([]*domain.FraudRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnabled", ctx)
+	ret0, _ := ret[0].([]*domain.FraudRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnabled indicates an expected call of ListEnabled.
+func (mr *MockFraudRuleRepositoryMockRecorder) ListEnabled(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnabled", reflect.TypeOf((*MockFraudRuleRepository)(nil).ListEnabled), ctx)
+}
+
+// MockFraudAssessmentRepository is a mock of FraudAssessmentRepository interface.
+type MockFraudAssessmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFraudAssessmentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFraudAssessmentRepositoryMockRecorder is the mock recorder for MockFraudAssessmentRepository.
+type MockFraudAssessmentRepositoryMockRecorder struct {
+	mock *MockFraudAssessmentRepository
+}
+
+// NewMockFraudAssessmentRepository creates a new mock instance.
+func NewMockFraudAssessmentRepository(ctrl *gomock.Controller) *MockFraudAssessmentRepository {
+	mock := &MockFraudAssessmentRepository{ctrl: ctrl}
+	mock.recorder = &MockFraudAssessmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFraudAssessmentRepository) EXPECT() *MockFraudAssessmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFraudAssessmentRepository) Create(ctx context.Context, assessment *domain.FraudAssessment) (*domain.FraudAssessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, assessment)
+	ret0, _ := ret[0].(*domain.FraudAssessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFraudAssessmentRepositoryMockRecorder) Create(ctx, assessment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFraudAssessmentRepository)(nil).Create), ctx, assessment)
+}
+
+// MockFraudScreener is a mock of FraudScreener interface.
+type MockFraudScreener struct {
+	ctrl     *gomock.Controller
+	recorder *MockFraudScreenerMockRecorder
+	isgomock struct{}
+}
+
+// MockFraudScreenerMockRecorder is the mock recorder for MockFraudScreener.
+type MockFraudScreenerMockRecorder struct {
+	mock *MockFraudScreener
+}
+
+// NewMockFraudScreener creates a new mock instance.
+func NewMockFraudScreener(ctrl *gomock.Controller) *MockFraudScreener {
+	mock := &MockFraudScreener{ctrl: ctrl}
+	mock.recorder = &MockFraudScreenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFraudScreener) EXPECT() *MockFraudScreenerMockRecorder {
+	return m.recorder
+}
+
+// Assess mocks base method.
+func (m *MockFraudScreener) Assess(ctx context.Context, transaction *domain.Transaction) (*domain.FraudAssessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assess", ctx, transaction)
+	ret0, _ := ret[0].(*domain.FraudAssessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assess indicates an expected call of Assess.
+func (mr *MockFraudScreenerMockRecorder) Assess(ctx, transaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assess", reflect.TypeOf((*MockFraudScreener)(nil).Assess), ctx, transaction)
+}
+
+// Record mocks base method.
+func (m *MockFraudScreener) Record(ctx context.Context, assessment *domain.FraudAssessment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, assessment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockFraudScreenerMockRecorder) Record(ctx, assessment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockFraudScreener)(nil).Record), ctx, assessment)
+}
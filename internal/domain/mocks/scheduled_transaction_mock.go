@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: scheduled_transaction.go
+//
+// Generated by this command:
+//
+//	mockgen -source=scheduled_transaction.go -destination=mocks/scheduled_transaction_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/nubank/pismo-code-assessment/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockScheduledTransactionRepository is a mock of ScheduledTransactionRepository interface.
+type MockScheduledTransactionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduledTransactionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockScheduledTransactionRepositoryMockRecorder is the mock recorder for MockScheduledTransactionRepository.
+type MockScheduledTransactionRepositoryMockRecorder struct {
+	mock *MockScheduledTransactionRepository
+}
+
+// NewMockScheduledTransactionRepository creates a new mock instance.
+func NewMockScheduledTransactionRepository(ctrl *gomock.Controller) *MockScheduledTransactionRepository {
+	mock := &MockScheduledTransactionRepository{ctrl: ctrl}
+	mock.recorder = &MockScheduledTransactionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduledTransactionRepository) EXPECT() *MockScheduledTransactionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockScheduledTransactionRepository) Create(ctx context.Context, schedule *domain.ScheduledTransaction) (*domain.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, schedule)
+	ret0, _ := ret[0].(*domain.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockScheduledTransactionRepositoryMockRecorder) Create(ctx, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockScheduledTransactionRepository)(nil).Create), ctx, schedule)
+}
+
+// FindByID mocks base method.
+func (m *MockScheduledTransactionRepository) FindByID(ctx context.Context, ID int64) (*domain.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*domain.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockScheduledTransactionRepositoryMockRecorder) FindByID(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockScheduledTransactionRepository)(nil).FindByID), ctx, ID)
+}
+
+// ListByAccountID mocks base method.
+func (m *MockScheduledTransactionRepository) ListByAccountID(ctx context.Context, accountID int64) ([]*domain.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]*domain.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAccountID indicates an expected call of ListByAccountID.
+func (mr *MockScheduledTransactionRepositoryMockRecorder) ListByAccountID(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccountID", reflect.TypeOf((*MockScheduledTransactionRepository)(nil).ListByAccountID), ctx, accountID)
+}
+
+// ListDue mocks base method.
+func (m *MockScheduledTransactionRepository) ListDue(ctx context.Context, at time.Time, limit int) ([]*domain.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDue", ctx, at, limit)
+	ret0, _ := ret[0].([]*domain.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDue indicates an expected call of ListDue.
+func (mr *MockScheduledTransactionRepositoryMockRecorder) ListDue(ctx, at, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDue", reflect.TypeOf((*MockScheduledTransactionRepository)(nil).ListDue), ctx, at, limit)
+}
+
+// Update mocks base method.
+func (m *MockScheduledTransactionRepository) Update(ctx context.Context, schedule *domain.ScheduledTransaction) (*domain.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, schedule)
+	ret0, _ := ret[0].(*domain.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockScheduledTransactionRepositoryMockRecorder) Update(ctx, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockScheduledTransactionRepository)(nil).Update), ctx, schedule)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: spending_controls.go
+//
+// Generated by this command:
+//
+//	mockgen -source=spending_controls.go -destination=mocks/spending_controls_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/nubank/pismo-code-assessment/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSpendingControlsRepository is a mock of SpendingControlsRepository interface.
+type MockSpendingControlsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSpendingControlsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSpendingControlsRepositoryMockRecorder is the mock recorder for MockSpendingControlsRepository.
+type MockSpendingControlsRepositoryMockRecorder struct {
+	mock *MockSpendingControlsRepository
+}
+
+// NewMockSpendingControlsRepository creates a new mock instance.
+func NewMockSpendingControlsRepository(ctrl *gomock.Controller) *MockSpendingControlsRepository {
+	mock := &MockSpendingControlsRepository{ctrl: ctrl}
+	mock.recorder = &MockSpendingControlsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSpendingControlsRepository) EXPECT() *MockSpendingControlsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByAccountID mocks base method.
+func (m *MockSpendingControlsRepository) FindByAccountID(ctx context.Context, accountID int64) (*domain.SpendingControls, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAccountID", ctx, accountID)
+	ret0, _ := ret[0].(*domain.SpendingControls)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAccountID indicates an expected call of FindByAccountID.
+func (mr *MockSpendingControlsRepositoryMockRecorder) FindByAccountID(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAccountID", reflect.TypeOf((*MockSpendingControlsRepository)(nil).FindByAccountID), ctx, accountID)
+}
+
+// Save mocks base method.
+func (m *MockSpendingControlsRepository) Save(ctx context.Context, controls *domain.SpendingControls) (*domain.SpendingControls, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, controls)
+	ret0, _ := ret[0].(*domain.SpendingControls)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSpendingControlsRepositoryMockRecorder) Save(ctx, controls any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSpendingControlsRepository)(nil).Save), ctx, controls)
+}
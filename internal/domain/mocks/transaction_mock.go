@@ -56,6 +56,36 @@ func (mr *MockTransactionRepositoryMockRecorder) Create(ctx, transaction any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransactionRepository)(nil).Create), ctx, transaction)
 }
 
+// FindByExternalID mocks base method.
+func (m *MockTransactionRepository) FindByExternalID(ctx context.Context, accountID int64, externalID string) (*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByExternalID", ctx, accountID, externalID)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByExternalID indicates an expected call of FindByExternalID.
+func (mr *MockTransactionRepositoryMockRecorder) FindByExternalID(ctx, accountID, externalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByExternalID", reflect.TypeOf((*MockTransactionRepository)(nil).FindByExternalID), ctx, accountID, externalID)
+}
+
+// FindByID mocks base method.
+func (m *MockTransactionRepository) FindByID(ctx context.Context, ID int64) (*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockTransactionRepositoryMockRecorder) FindByID(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTransactionRepository)(nil).FindByID), ctx, ID)
+}
+
 // ListByAccountID mocks base method.
 func (m *MockTransactionRepository) ListByAccountID(ctx context.Context, accountID int64) ([]*domain.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -71,17 +101,16 @@ func (mr *MockTransactionRepositoryMockRecorder) ListByAccountID(ctx, accountID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccountID", reflect.TypeOf((*MockTransactionRepository)(nil).ListByAccountID), ctx, accountID)
 }
 
-// UpdateBalance mocks base method.
-func (m *MockTransactionRepository) UpdateBalance(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
+// UpdateBalances mocks base method.
+func (m *MockTransactionRepository) UpdateBalances(ctx context.Context, transactions []*domain.Transaction) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateBalance", ctx, transaction)
-	ret0, _ := ret[0].(*domain.Transaction)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateBalances", ctx, transactions)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// UpdateBalance indicates an expected call of UpdateBalance.
-func (mr *MockTransactionRepositoryMockRecorder) UpdateBalance(ctx, transaction any) *gomock.Call {
+// UpdateBalances indicates an expected call of UpdateBalances.
+func (mr *MockTransactionRepositoryMockRecorder) UpdateBalances(ctx, transactions any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBalance", reflect.TypeOf((*MockTransactionRepository)(nil).UpdateBalance), ctx, transaction)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBalances", reflect.TypeOf((*MockTransactionRepository)(nil).UpdateBalances), ctx, transactions)
 }
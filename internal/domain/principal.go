@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"slices"
+)
+
+// Principal is the authenticated caller of the API, whether it presented an
+// API key or a token.
+type Principal struct {
+	ClientID string
+	Scopes   []Scope
+	// AccountID restricts the caller to a single account when non-zero, as for
+	// tokens issued to customer-facing apps.
+	AccountID int64
+}
+
+func (p *Principal) HasScope(scope Scope) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+func (p *Principal) CanAccessAccount(accountID int64) bool {
+	return p.AccountID == 0 || p.AccountID == accountID
+}
+
+type principalContextKey struct{}
+
+// ContextWithPrincipal stores the authenticated caller in ctx, so that use
+// cases can check it against the accounts they reach.
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller stored in ctx, if any.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok
+}
+
+// AuthorizeAccount returns ErrAccountAccessDenied when the caller of ctx is
+// restricted to another account. Calls without a caller, made with
+// authentication disabled or by background jobs, reach every account.
+func AuthorizeAccount(ctx context.Context, accountID int64) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if ok && !principal.CanAccessAccount(accountID) {
+		return ErrAccountAccessDenied
+	}
+	return nil
+}
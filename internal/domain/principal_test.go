@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal_HasScope(t *testing.T) {
+	principal := &Principal{Scopes: []Scope{ScopeAccountsRead}}
+
+	assert.True(t, principal.HasScope(ScopeAccountsRead))
+	assert.False(t, principal.HasScope(ScopeTransactionsWrite))
+}
+
+func TestPrincipal_CanAccessAccount(t *testing.T) {
+	t.Run("unrestricted principals access every account", func(t *testing.T) {
+		principal := &Principal{}
+
+		assert.True(t, principal.CanAccessAccount(1))
+		assert.True(t, principal.CanAccessAccount(2))
+	})
+
+	t.Run("restricted principals only access their own account", func(t *testing.T) {
+		principal := &Principal{AccountID: 1}
+
+		assert.True(t, principal.CanAccessAccount(1))
+		assert.False(t, principal.CanAccessAccount(2))
+	})
+}
+
+func TestAuthorizeAccount(t *testing.T) {
+	t.Run("lets calls without a principal reach every account", func(t *testing.T) {
+		assert.NoError(t, AuthorizeAccount(context.Background(), 2))
+	})
+
+	t.Run("denies restricted principals other accounts", func(t *testing.T) {
+		ctx := ContextWithPrincipal(context.Background(), &Principal{AccountID: 1})
+
+		assert.NoError(t, AuthorizeAccount(ctx, 1))
+		assert.ErrorIs(t, AuthorizeAccount(ctx, 2), ErrAccountAccessDenied)
+	})
+}
+
+func TestAPIKey_Principal(t *testing.T) {
+	apiKey := &APIKey{ClientID: "billing", Scopes: []Scope{ScopeAccountsRead}}
+
+	assert.Equal(t, &Principal{ClientID: "billing", Scopes: []Scope{ScopeAccountsRead}}, apiKey.Principal())
+}
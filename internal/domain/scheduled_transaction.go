@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type ScheduleRecurrence string
+
+const (
+	ScheduleRecurrenceOnce    ScheduleRecurrence = "once"
+	ScheduleRecurrenceMonthly ScheduleRecurrence = "monthly"
+)
+
+type ScheduleAmountType string
+
+const (
+	// ScheduleAmountFixed posts the scheduled amount as is.
+	ScheduleAmountFixed ScheduleAmountType = "fixed"
+	// ScheduleAmountFullBalance pays whatever the account owes when the
+	// schedule runs. Only valid for payments.
+	ScheduleAmountFullBalance ScheduleAmountType = "full_balance"
+)
+
+type ScheduleStatus string
+
+const (
+	ScheduleStatusActive    ScheduleStatus = "active"
+	ScheduleStatusCompleted ScheduleStatus = "completed"
+	ScheduleStatusCancelled ScheduleStatus = "cancelled"
+)
+
+//go:generate mockgen -source=scheduled_transaction.go -destination=mocks/scheduled_transaction_mock.go -package=mocks
+type ScheduledTransactionRepository interface {
+	Create(ctx context.Context, schedule *ScheduledTransaction) (*ScheduledTransaction, error)
+	FindByID(ctx context.Context, ID int64) (*ScheduledTransaction, error)
+	ListByAccountID(ctx context.Context, accountID int64) ([]*ScheduledTransaction, error)
+	// ListDue returns up to limit active schedules whose next run is at or
+	// before at, oldest first.
+	ListDue(ctx context.Context, at time.Time, limit int) ([]*ScheduledTransaction, error)
+	Update(ctx context.Context, schedule *ScheduledTransaction) (*ScheduledTransaction, error)
+}
+
+// ScheduleDefinition is what the customer asks for: which transaction to post
+// and when. One-off schedules run at StartsAt, monthly ones on DayOfMonth of
+// every month from StartsAt on, at StartsAt's time of day. Days past the end of
+// a month fall on its last day.
+type ScheduleDefinition struct {
+	OperationTypeID OperationType
+	Amount          float64
+	AmountType      ScheduleAmountType
+	Recurrence      ScheduleRecurrence
+	DayOfMonth      int
+	StartsAt        time.Time
+	Description     string
+}
+
+func (d ScheduleDefinition) Validate() error {
+	if !d.OperationTypeID.IsValid() || d.OperationTypeID.IsAdjustment() {
+		return ErrInvalidOperationType
+	}
+
+	switch d.AmountType {
+	case ScheduleAmountFixed:
+		if d.Amount <= 0 {
+			return ErrInvalidAmount
+		}
+	case ScheduleAmountFullBalance:
+		if d.OperationTypeID != OperationTypePayment {
+			return ErrInvalidScheduleAmountType
+		}
+	default:
+		return ErrInvalidScheduleAmountType
+	}
+
+	switch d.Recurrence {
+	case ScheduleRecurrenceOnce:
+	case ScheduleRecurrenceMonthly:
+		if d.DayOfMonth < 1 || d.DayOfMonth > 31 {
+			return ErrInvalidScheduleDayOfMonth
+		}
+	default:
+		return ErrInvalidScheduleRecurrence
+	}
+
+	return nil
+}
+
+// ScheduledTransaction tracks a schedule and its runs. The run due at NextRunAt
+// is posted with an external ID derived from it so a run retried after a crash
+// is never posted twice.
+type ScheduledTransaction struct {
+	ID        int64
+	AccountID int64
+	ScheduleDefinition
+	Status            ScheduleStatus
+	NextRunAt         time.Time
+	LastRunAt         time.Time
+	LastTransactionID int64
+	LastError         string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func NewScheduledTransaction(accountID int64, definition ScheduleDefinition, now time.Time) (*ScheduledTransaction, error) {
+	schedule := &ScheduledTransaction{
+		AccountID: accountID,
+		Status:    ScheduleStatusActive,
+		CreatedAt: now,
+	}
+
+	if err := schedule.Redefine(definition, now); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// Redefine replaces the schedule definition and recomputes the next run.
+func (s *ScheduledTransaction) Redefine(definition ScheduleDefinition, now time.Time) error {
+	if s.Status != ScheduleStatusActive {
+		return ErrScheduleNotActive
+	}
+
+	if definition.AmountType == "" {
+		definition.AmountType = ScheduleAmountFixed
+	}
+	if err := definition.Validate(); err != nil {
+		return err
+	}
+
+	if definition.StartsAt.IsZero() {
+		definition.StartsAt = now
+	}
+	definition.StartsAt = definition.StartsAt.UTC()
+	if definition.Recurrence == ScheduleRecurrenceOnce {
+		definition.DayOfMonth = 0
+	}
+	if definition.AmountType == ScheduleAmountFullBalance {
+		definition.Amount = 0
+	}
+
+	s.ScheduleDefinition = definition
+	s.NextRunAt = definition.StartsAt
+	if definition.Recurrence == ScheduleRecurrenceMonthly {
+		s.NextRunAt = monthlyRun(definition.StartsAt.Year(), definition.StartsAt.Month(), definition)
+		if s.NextRunAt.Before(definition.StartsAt) {
+			s.NextRunAt = monthlyRun(definition.StartsAt.Year(), definition.StartsAt.Month()+1, definition)
+		}
+	}
+	s.UpdatedAt = now
+
+	return nil
+}
+
+// RunExternalID identifies the transaction posted for the run due at NextRunAt.
+func (s *ScheduledTransaction) RunExternalID() string {
+	return fmt.Sprintf("schedule-%d-%d", s.ID, s.NextRunAt.Unix())
+}
+
+// Advance records the outcome of the run due at NextRunAt and moves on to the
+// next one, completing one-off schedules.
+func (s *ScheduledTransaction) Advance(transactionID int64, runErr string, at time.Time) {
+	s.LastRunAt = at
+	s.LastTransactionID = transactionID
+	s.LastError = runErr
+	s.UpdatedAt = at
+
+	if s.Recurrence == ScheduleRecurrenceOnce {
+		s.Status = ScheduleStatusCompleted
+		return
+	}
+	s.NextRunAt = monthlyRun(s.NextRunAt.Year(), s.NextRunAt.Month()+1, s.ScheduleDefinition)
+}
+
+func (s *ScheduledTransaction) Cancel(at time.Time) {
+	s.Status = ScheduleStatusCancelled
+	s.UpdatedAt = at
+}
+
+// monthlyRun returns the run in the given month, clamping DayOfMonth to the
+// month's last day.
+func monthlyRun(year int, month time.Month, definition ScheduleDefinition) time.Time {
+	firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	day := definition.DayOfMonth
+	if day > lastDay {
+		day = lastDay
+	}
+
+	clock := definition.StartsAt
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, clock.Hour(), clock.Minute(), clock.Second(), 0, time.UTC)
+}
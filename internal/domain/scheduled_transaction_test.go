@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleDefinition_Validate(t *testing.T) {
+	valid := ScheduleDefinition{
+		OperationTypeID: OperationTypePayment,
+		Amount:          100.0,
+		AmountType:      ScheduleAmountFixed,
+		Recurrence:      ScheduleRecurrenceMonthly,
+		DayOfMonth:      10,
+	}
+
+	t.Run("accepts valid definitions", func(t *testing.T) {
+		fullBalance := valid
+		fullBalance.AmountType = ScheduleAmountFullBalance
+		fullBalance.Amount = 0
+
+		once := valid
+		once.Recurrence = ScheduleRecurrenceOnce
+		once.DayOfMonth = 0
+
+		assert.NoError(t, valid.Validate())
+		assert.NoError(t, fullBalance.Validate())
+		assert.NoError(t, once.Validate())
+	})
+
+	tests := []struct {
+		name   string
+		modify func(d *ScheduleDefinition)
+		err    error
+	}{
+		{"adjustment operation type", func(d *ScheduleDefinition) { d.OperationTypeID = OperationTypeCreditVoucher }, ErrInvalidOperationType},
+		{"fixed amount without amount", func(d *ScheduleDefinition) { d.Amount = 0 }, ErrInvalidAmount},
+		{"full balance for purchases", func(d *ScheduleDefinition) {
+			d.OperationTypeID = OperationTypePurchase
+			d.AmountType = ScheduleAmountFullBalance
+		}, ErrInvalidScheduleAmountType},
+		{"unknown amount type", func(d *ScheduleDefinition) { d.AmountType = "half" }, ErrInvalidScheduleAmountType},
+		{"unknown recurrence", func(d *ScheduleDefinition) { d.Recurrence = "weekly" }, ErrInvalidScheduleRecurrence},
+		{"day of month out of range", func(d *ScheduleDefinition) { d.DayOfMonth = 32 }, ErrInvalidScheduleDayOfMonth},
+	}
+
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			definition := valid
+			tt.modify(&definition)
+
+			assert.ErrorIs(t, definition.Validate(), tt.err)
+		})
+	}
+}
+
+func TestNewScheduledTransaction(t *testing.T) {
+	now := time.Date(2024, 1, 20, 9, 30, 0, 0, time.UTC)
+
+	t.Run("schedules one-off run at start", func(t *testing.T) {
+		startsAt := now.Add(48 * time.Hour)
+		schedule, err := NewScheduledTransaction(1, ScheduleDefinition{
+			OperationTypeID: OperationTypePurchase,
+			Amount:          30.0,
+			AmountType:      ScheduleAmountFixed,
+			Recurrence:      ScheduleRecurrenceOnce,
+			StartsAt:        startsAt,
+		}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ScheduleStatusActive, schedule.Status)
+		assert.Equal(t, startsAt, schedule.NextRunAt)
+	})
+
+	t.Run("runs one-off schedule without start immediately", func(t *testing.T) {
+		schedule, err := NewScheduledTransaction(1, ScheduleDefinition{
+			OperationTypeID: OperationTypePayment,
+			Amount:          30.0,
+			AmountType:      ScheduleAmountFixed,
+			Recurrence:      ScheduleRecurrenceOnce,
+		}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, now, schedule.NextRunAt)
+	})
+
+	t.Run("schedules monthly run in the next month when day has passed", func(t *testing.T) {
+		schedule, err := NewScheduledTransaction(1, ScheduleDefinition{
+			OperationTypeID: OperationTypePayment,
+			AmountType:      ScheduleAmountFullBalance,
+			Recurrence:      ScheduleRecurrenceMonthly,
+			DayOfMonth:      10,
+		}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 2, 10, 9, 30, 0, 0, time.UTC), schedule.NextRunAt)
+	})
+
+	t.Run("schedules monthly run in the same month when day is ahead", func(t *testing.T) {
+		schedule, err := NewScheduledTransaction(1, ScheduleDefinition{
+			OperationTypeID: OperationTypePayment,
+			Amount:          50.0,
+			AmountType:      ScheduleAmountFixed,
+			Recurrence:      ScheduleRecurrenceMonthly,
+			DayOfMonth:      25,
+		}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 25, 9, 30, 0, 0, time.UTC), schedule.NextRunAt)
+	})
+
+	t.Run("returns error when definition is invalid", func(t *testing.T) {
+		schedule, err := NewScheduledTransaction(1, ScheduleDefinition{OperationTypeID: OperationTypePayment, AmountType: ScheduleAmountFixed}, now)
+
+		assert.Nil(t, schedule)
+		assert.ErrorIs(t, err, ErrInvalidAmount)
+	})
+}
+
+func TestScheduledTransaction_Advance(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("moves monthly schedule to the next month keeping the requested day", func(t *testing.T) {
+		schedule, _ := NewScheduledTransaction(1, ScheduleDefinition{
+			OperationTypeID: OperationTypePayment,
+			Amount:          50.0,
+			AmountType:      ScheduleAmountFixed,
+			Recurrence:      ScheduleRecurrenceMonthly,
+			DayOfMonth:      31,
+		}, now)
+		assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), schedule.NextRunAt)
+
+		schedule.Advance(7, "", now)
+		assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), schedule.NextRunAt)
+
+		schedule.Advance(8, "", now)
+		assert.Equal(t, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), schedule.NextRunAt)
+		assert.Equal(t, int64(8), schedule.LastTransactionID)
+		assert.Equal(t, ScheduleStatusActive, schedule.Status)
+	})
+
+	t.Run("completes one-off schedule", func(t *testing.T) {
+		schedule := &ScheduledTransaction{ScheduleDefinition: ScheduleDefinition{Recurrence: ScheduleRecurrenceOnce}, Status: ScheduleStatusActive}
+
+		schedule.Advance(0, "account was not found", now)
+
+		assert.Equal(t, ScheduleStatusCompleted, schedule.Status)
+		assert.Equal(t, "account was not found", schedule.LastError)
+	})
+
+	t.Run("derives run external id from the due date", func(t *testing.T) {
+		schedule := &ScheduledTransaction{ID: 3, NextRunAt: time.Unix(1706659200, 0)}
+
+		assert.Equal(t, "schedule-3-1706659200", schedule.RunExternalID())
+	})
+}
+
+func TestScheduledTransaction_Redefine(t *testing.T) {
+	t.Run("returns error when schedule is cancelled", func(t *testing.T) {
+		schedule := &ScheduledTransaction{Status: ScheduleStatusCancelled}
+
+		err := schedule.Redefine(ScheduleDefinition{
+			OperationTypeID: OperationTypePayment,
+			Amount:          10.0,
+			AmountType:      ScheduleAmountFixed,
+			Recurrence:      ScheduleRecurrenceOnce,
+		}, time.Now())
+
+		assert.ErrorIs(t, err, ErrScheduleNotActive)
+	})
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"slices"
+	"time"
+)
+
+//go:generate mockgen -source=spending_controls.go -destination=mocks/spending_controls_mock.go -package=mocks
+type SpendingControlsRepository interface {
+	FindByAccountID(ctx context.Context, accountID int64) (*SpendingControls, error)
+	Save(ctx context.Context, controls *SpendingControls) (*SpendingControls, error)
+}
+
+// TimeWindow is a time of day range in "15:04" format. A window whose end is
+// before its start wraps around midnight.
+type TimeWindow struct {
+	Start string
+	End   string
+}
+
+func (w TimeWindow) contains(minuteOfDay int) bool {
+	start, _ := parseTimeOfDay(w.Start)
+	end, _ := parseTimeOfDay(w.End)
+
+	if start <= end {
+		return minuteOfDay >= start && minuteOfDay < end
+	}
+	return minuteOfDay >= start || minuteOfDay < end
+}
+
+// SpendingControls is the rule set an account owner sets to restrict how the
+// account can be used. Zero values disable the corresponding rule.
+type SpendingControls struct {
+	AccountID             int64
+	BlockedMCCs           []string
+	MaxTransactionAmount  float64
+	AllowedOperationTypes []OperationType
+	TimeWindows           []TimeWindow
+	Timezone              string
+}
+
+func NewSpendingControls(
+	accountID int64,
+	blockedMCCs []string,
+	maxTransactionAmount float64,
+	allowedOperationTypes []OperationType,
+	timeWindows []TimeWindow,
+	timezone string,
+) (*SpendingControls, error) {
+	for _, mcc := range blockedMCCs {
+		if !merchantCategoryCodePattern.MatchString(mcc) {
+			return nil, ErrInvalidMerchantCategoryCode
+		}
+	}
+
+	if maxTransactionAmount < 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	for _, operationType := range allowedOperationTypes {
+		if !operationType.IsValid() {
+			return nil, ErrInvalidOperationType
+		}
+	}
+
+	for _, window := range timeWindows {
+		if _, ok := parseTimeOfDay(window.Start); !ok {
+			return nil, ErrInvalidTimeWindow
+		}
+		if _, ok := parseTimeOfDay(window.End); !ok {
+			return nil, ErrInvalidTimeWindow
+		}
+	}
+
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, ErrInvalidTimezone
+	}
+
+	return &SpendingControls{
+		AccountID:             accountID,
+		BlockedMCCs:           blockedMCCs,
+		MaxTransactionAmount:  maxTransactionAmount,
+		AllowedOperationTypes: allowedOperationTypes,
+		TimeWindows:           timeWindows,
+		Timezone:              timezone,
+	}, nil
+}
+
+// Evaluate returns the error naming the first rule the transaction violates.
+// Amounts are compared in the account's settlement currency.
+func (c *SpendingControls) Evaluate(transaction *Transaction) error {
+	if len(c.AllowedOperationTypes) > 0 && !slices.Contains(c.AllowedOperationTypes, transaction.OperationTypeID) {
+		return ErrOperationTypeNotAllowed
+	}
+
+	if !transaction.OperationTypeID.IsDebit() {
+		return nil
+	}
+
+	if transaction.MerchantCategoryCode != "" && slices.Contains(c.BlockedMCCs, transaction.MerchantCategoryCode) {
+		return ErrMerchantCategoryBlocked
+	}
+
+	if c.MaxTransactionAmount > 0 && math.Abs(transaction.Amount) > c.MaxTransactionAmount {
+		return ErrTransactionAmountLimitExceeded
+	}
+
+	if len(c.TimeWindows) > 0 && !c.withinTimeWindows(transaction.EventDate) {
+		return ErrOutsideAllowedTimeWindow
+	}
+
+	return nil
+}
+
+func (c *SpendingControls) withinTimeWindows(at time.Time) bool {
+	location, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	local := at.In(location)
+	minuteOfDay := local.Hour()*60 + local.Minute()
+
+	for _, window := range c.TimeWindows {
+		if window.contains(minuteOfDay) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTimeOfDay(value string) (int, bool) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, false
+	}
+	return parsed.Hour()*60 + parsed.Minute(), true
+}
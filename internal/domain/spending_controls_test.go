@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSpendingControls(t *testing.T) {
+	t.Run("creates spending controls with default timezone", func(t *testing.T) {
+		controls, err := NewSpendingControls(1, []string{"7995"}, 100, []OperationType{OperationTypePurchase}, []TimeWindow{{Start: "08:00", End: "18:00"}}, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), controls.AccountID)
+		assert.Equal(t, "UTC", controls.Timezone)
+	})
+
+	t.Run("returns error for invalid merchant category code", func(t *testing.T) {
+		_, err := NewSpendingControls(1, []string{"79"}, 0, nil, nil, "")
+
+		assert.ErrorIs(t, err, ErrInvalidMerchantCategoryCode)
+	})
+
+	t.Run("returns error for negative amount limit", func(t *testing.T) {
+		_, err := NewSpendingControls(1, nil, -1, nil, nil, "")
+
+		assert.ErrorIs(t, err, ErrInvalidAmount)
+	})
+
+	t.Run("returns error for invalid operation type", func(t *testing.T) {
+		_, err := NewSpendingControls(1, nil, 0, []OperationType{9}, nil, "")
+
+		assert.ErrorIs(t, err, ErrInvalidOperationType)
+	})
+
+	t.Run("returns error for invalid time window", func(t *testing.T) {
+		_, err := NewSpendingControls(1, nil, 0, nil, []TimeWindow{{Start: "8am", End: "18:00"}}, "")
+
+		assert.ErrorIs(t, err, ErrInvalidTimeWindow)
+	})
+
+	t.Run("returns error for invalid timezone", func(t *testing.T) {
+		_, err := NewSpendingControls(1, nil, 0, nil, nil, "Mars/Olympus")
+
+		assert.ErrorIs(t, err, ErrInvalidTimezone)
+	})
+}
+
+func TestSpendingControls_Evaluate(t *testing.T) {
+	noon := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("accepts transaction when no rule is set", func(t *testing.T) {
+		controls := &SpendingControls{Timezone: "UTC"}
+		transaction := &Transaction{OperationTypeID: OperationTypePurchase, Amount: -1000, EventDate: noon}
+
+		assert.NoError(t, controls.Evaluate(transaction))
+	})
+
+	t.Run("rejects operation type not allowed", func(t *testing.T) {
+		controls := &SpendingControls{AllowedOperationTypes: []OperationType{OperationTypePurchase, OperationTypePayment}}
+		transaction := &Transaction{OperationTypeID: OperationTypeWithdrawal, Amount: -10, EventDate: noon}
+
+		assert.ErrorIs(t, controls.Evaluate(transaction), ErrOperationTypeNotAllowed)
+	})
+
+	t.Run("rejects blocked merchant category", func(t *testing.T) {
+		controls := &SpendingControls{BlockedMCCs: []string{"7995"}}
+		transaction := &Transaction{
+			OperationTypeID:    OperationTypePurchase,
+			Amount:             -10,
+			EventDate:          noon,
+			TransactionDetails: TransactionDetails{MerchantCategoryCode: "7995"},
+		}
+
+		assert.ErrorIs(t, controls.Evaluate(transaction), ErrMerchantCategoryBlocked)
+	})
+
+	t.Run("rejects amount above the limit", func(t *testing.T) {
+		controls := &SpendingControls{MaxTransactionAmount: 100}
+		transaction := &Transaction{OperationTypeID: OperationTypePurchase, Amount: -100.01, EventDate: noon}
+
+		assert.ErrorIs(t, controls.Evaluate(transaction), ErrTransactionAmountLimitExceeded)
+	})
+
+	t.Run("rejects transaction outside time windows", func(t *testing.T) {
+		controls := &SpendingControls{TimeWindows: []TimeWindow{{Start: "08:00", End: "11:00"}}, Timezone: "UTC"}
+		transaction := &Transaction{OperationTypeID: OperationTypePurchase, Amount: -10, EventDate: noon}
+
+		assert.ErrorIs(t, controls.Evaluate(transaction), ErrOutsideAllowedTimeWindow)
+	})
+
+	t.Run("evaluates time windows in the configured timezone", func(t *testing.T) {
+		controls := &SpendingControls{TimeWindows: []TimeWindow{{Start: "08:00", End: "10:00"}}, Timezone: "America/Sao_Paulo"}
+		transaction := &Transaction{OperationTypeID: OperationTypePurchase, Amount: -10, EventDate: noon}
+
+		assert.NoError(t, controls.Evaluate(transaction))
+	})
+
+	t.Run("accepts time windows wrapping midnight", func(t *testing.T) {
+		controls := &SpendingControls{TimeWindows: []TimeWindow{{Start: "22:00", End: "02:00"}}, Timezone: "UTC"}
+		transaction := &Transaction{OperationTypeID: OperationTypePurchase, Amount: -10, EventDate: time.Date(2024, 1, 10, 1, 0, 0, 0, time.UTC)}
+
+		assert.NoError(t, controls.Evaluate(transaction))
+	})
+
+	t.Run("does not restrict payments", func(t *testing.T) {
+		controls := &SpendingControls{MaxTransactionAmount: 10, TimeWindows: []TimeWindow{{Start: "08:00", End: "09:00"}}}
+		transaction := &Transaction{OperationTypeID: OperationTypePayment, Amount: 500, EventDate: noon}
+
+		assert.NoError(t, controls.Evaluate(transaction))
+	})
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"math"
+	"regexp"
 	"time"
 )
 
@@ -12,34 +14,102 @@ const (
 	OperationTypeInstallmentPurchase OperationType = 2
 	OperationTypeWithdrawal          OperationType = 3
 	OperationTypePayment             OperationType = 4
+	OperationTypeCreditVoucher       OperationType = 5
+	OperationTypeDisputeReversal     OperationType = 6
 )
 
 func (o OperationType) IsValid() bool {
 	switch o {
-	case OperationTypePurchase, OperationTypeInstallmentPurchase, OperationTypeWithdrawal, OperationTypePayment:
+	case OperationTypePurchase, OperationTypeInstallmentPurchase, OperationTypeWithdrawal, OperationTypePayment,
+		OperationTypeCreditVoucher, OperationTypeDisputeReversal:
 		return true
 	}
 	return false
 }
 
+func (o OperationType) String() string {
+	switch o {
+	case OperationTypePurchase:
+		return "purchase"
+	case OperationTypeInstallmentPurchase:
+		return "installment_purchase"
+	case OperationTypeWithdrawal:
+		return "withdrawal"
+	case OperationTypePayment:
+		return "payment"
+	case OperationTypeCreditVoucher:
+		return "credit_voucher"
+	case OperationTypeDisputeReversal:
+		return "dispute_reversal"
+	}
+	return "unknown"
+}
+
 func (o OperationType) IsDebit() bool {
-	return o == OperationTypePurchase || o == OperationTypeInstallmentPurchase || o == OperationTypeWithdrawal
+	return o == OperationTypePurchase || o == OperationTypeInstallmentPurchase || o == OperationTypeWithdrawal ||
+		o == OperationTypeDisputeReversal
+}
+
+// IsAdjustment reports whether the operation is posted by the issuer itself,
+// such as dispute credits, rather than initiated by the customer.
+func (o OperationType) IsAdjustment() bool {
+	return o == OperationTypeCreditVoucher || o == OperationTypeDisputeReversal
 }
 
 //go:generate mockgen -source=transaction.go -destination=mocks/transaction_mock.go -package=mocks
 type TransactionRepository interface {
 	Create(ctx context.Context, transaction *Transaction) (*Transaction, error)
 	ListByAccountID(ctx context.Context, accountID int64) ([]*Transaction, error)
-	UpdateBalance(ctx context.Context, transaction *Transaction) (*Transaction, error)
+	// UpdateBalances stores the balances of the transactions at once: either
+	// all of them are updated or none is.
+	UpdateBalances(ctx context.Context, transactions []*Transaction) error
+	FindByExternalID(ctx context.Context, accountID int64, externalID string) (*Transaction, error)
+	FindByID(ctx context.Context, ID int64) (*Transaction, error)
+}
+
+var (
+	currencyPattern             = regexp.MustCompile(`^[A-Z]{3}$`)
+	merchantCategoryCodePattern = regexp.MustCompile(`^[0-9]{4}$`)
+)
+
+// TransactionDetails holds optional references that tie a transaction back to
+// the card network authorization and the merchant.
+type TransactionDetails struct {
+	CardID               int64
+	ExternalID           string
+	Description          string
+	MerchantName         string
+	MerchantCategoryCode string
+	Currency             string
+	Metadata             map[string]any
 }
 
+func (d TransactionDetails) Validate() error {
+	if d.Currency != "" && !currencyPattern.MatchString(d.Currency) {
+		return ErrInvalidCurrency
+	}
+
+	if d.MerchantCategoryCode != "" && !merchantCategoryCodePattern.MatchString(d.MerchantCategoryCode) {
+		return ErrInvalidMerchantCategoryCode
+	}
+
+	return nil
+}
+
+// Transaction amounts are kept in the account's settlement currency. The amount
+// in the currency the transaction was made in is kept in OriginalAmount, along
+// with the rate applied to convert it.
 type Transaction struct {
-	ID              int64
-	AccountID       int64
-	OperationTypeID OperationType
-	Amount          float64
-	EventDate       time.Time
-	Balance         float64
+	ID                 int64
+	AccountID          int64
+	OperationTypeID    OperationType
+	Amount             float64
+	EventDate          time.Time
+	Balance            float64
+	OriginalAmount     float64
+	SettlementCurrency string
+	FXRate             float64
+	TransactionDetails
 }
 
 func NewTransaction(accountID int64, operationTypeID OperationType, amount float64, balance float64) (*Transaction, error) {
@@ -64,6 +134,92 @@ func NewTransaction(accountID int64, operationTypeID OperationType, amount float
 	}, nil
 }
 
+// Convert records the original amount and currency and converts Amount to the
+// settlement currency using rate. Transactions without a currency are assumed
+// to be in the settlement currency.
+func (t *Transaction) Convert(settlementCurrency string, rate float64) {
+	if t.Currency == "" {
+		t.Currency = settlementCurrency
+	}
+
+	t.OriginalAmount = t.Amount
+	t.SettlementCurrency = settlementCurrency
+	t.FXRate = rate
+	t.Amount = math.Round(t.Amount*rate*100) / 100
+}
+
 func (t *Transaction) IsNegative() bool {
 	return t.Balance < 0
 }
+
+// AccountBalance summarizes an account's ledger: the debt still owed on debits
+// and the credit left over from payments that had nothing to discharge.
+type AccountBalance struct {
+	AccountID   int64
+	Outstanding float64
+	Credit      float64
+}
+
+func NewAccountBalance(accountID int64, transactions []*Transaction) *AccountBalance {
+	balance := &AccountBalance{AccountID: accountID}
+	for _, transaction := range transactions {
+		if transaction.Balance < 0 {
+			balance.Outstanding -= transaction.Balance
+		} else {
+			balance.Credit += transaction.Balance
+		}
+	}
+
+	balance.Outstanding = math.Round(balance.Outstanding*100) / 100
+	balance.Credit = math.Round(balance.Credit*100) / 100
+
+	return balance
+}
+
+// Net is the credit minus the outstanding debt, negative when the account owes.
+func (b *AccountBalance) Net() float64 {
+	return math.Round((b.Credit-b.Outstanding)*100) / 100
+}
+
+// Rebalance recomputes every balance from scratch, in the given chronological
+// order, by letting each credit discharge the debits posted before it, oldest
+// first. It returns the transactions whose balance changed.
+func Rebalance(transactions []*Transaction) []*Transaction {
+	previous := make([]float64, len(transactions))
+	for i, transaction := range transactions {
+		previous[i] = transaction.Balance
+		transaction.Balance = transaction.Amount
+	}
+
+	for i, transaction := range transactions {
+		if transaction.OperationTypeID.IsDebit() {
+			continue
+		}
+
+		for _, past := range transactions[:i] {
+			if transaction.Balance <= 0 {
+				break
+			}
+			if !past.OperationTypeID.IsDebit() || !past.IsNegative() {
+				continue
+			}
+
+			if transaction.Balance >= -past.Balance {
+				transaction.Balance += past.Balance
+				past.Balance = 0
+			} else {
+				past.Balance += transaction.Balance
+				transaction.Balance = 0
+			}
+		}
+	}
+
+	var changed []*Transaction
+	for i, transaction := range transactions {
+		if transaction.Balance != previous[i] {
+			changed = append(changed, transaction)
+		}
+	}
+
+	return changed
+}
@@ -13,6 +13,8 @@ func TestOperationType_IsValid(t *testing.T) {
 			OperationTypeInstallmentPurchase,
 			OperationTypeWithdrawal,
 			OperationTypePayment,
+			OperationTypeCreditVoucher,
+			OperationTypeDisputeReversal,
 		}
 
 		for _, opType := range validTypes {
@@ -21,7 +23,7 @@ func TestOperationType_IsValid(t *testing.T) {
 	})
 
 	t.Run("returns false for invalid operation types", func(t *testing.T) {
-		invalidTypes := []OperationType{0, 5, 100, -1}
+		invalidTypes := []OperationType{0, 7, 100, -1}
 
 		for _, opType := range invalidTypes {
 			assert.False(t, opType.IsValid())
@@ -35,6 +37,7 @@ func TestOperationType_IsDebit(t *testing.T) {
 			OperationTypePurchase,
 			OperationTypeInstallmentPurchase,
 			OperationTypeWithdrawal,
+			OperationTypeDisputeReversal,
 		}
 
 		for _, opType := range debitTypes {
@@ -44,6 +47,35 @@ func TestOperationType_IsDebit(t *testing.T) {
 
 	t.Run("returns false for credit operations", func(t *testing.T) {
 		assert.False(t, OperationTypePayment.IsDebit())
+		assert.False(t, OperationTypeCreditVoucher.IsDebit())
+	})
+}
+
+func TestOperationType_IsAdjustment(t *testing.T) {
+	t.Run("returns true for issuer adjustments", func(t *testing.T) {
+		assert.True(t, OperationTypeCreditVoucher.IsAdjustment())
+		assert.True(t, OperationTypeDisputeReversal.IsAdjustment())
+	})
+
+	t.Run("returns false for customer operations", func(t *testing.T) {
+		for _, opType := range []OperationType{OperationTypePurchase, OperationTypeInstallmentPurchase, OperationTypeWithdrawal, OperationTypePayment} {
+			assert.False(t, opType.IsAdjustment())
+		}
+	})
+}
+
+func TestOperationType_String(t *testing.T) {
+	t.Run("names every operation type", func(t *testing.T) {
+		assert.Equal(t, "purchase", OperationTypePurchase.String())
+		assert.Equal(t, "installment_purchase", OperationTypeInstallmentPurchase.String())
+		assert.Equal(t, "withdrawal", OperationTypeWithdrawal.String())
+		assert.Equal(t, "payment", OperationTypePayment.String())
+		assert.Equal(t, "credit_voucher", OperationTypeCreditVoucher.String())
+		assert.Equal(t, "dispute_reversal", OperationTypeDisputeReversal.String())
+	})
+
+	t.Run("returns unknown for invalid operation types", func(t *testing.T) {
+		assert.Equal(t, "unknown", OperationType(99).String())
 	})
 }
 
@@ -101,3 +133,122 @@ func TestNewTransaction(t *testing.T) {
 		assert.ErrorIs(t, err, ErrInvalidAmount)
 	})
 }
+
+func TestTransactionDetails_Validate(t *testing.T) {
+	t.Run("accepts empty details", func(t *testing.T) {
+		assert.NoError(t, TransactionDetails{}.Validate())
+	})
+
+	t.Run("accepts valid currency and merchant category code", func(t *testing.T) {
+		details := TransactionDetails{Currency: "USD", MerchantCategoryCode: "5411"}
+
+		assert.NoError(t, details.Validate())
+	})
+
+	t.Run("returns error for invalid currency", func(t *testing.T) {
+		for _, currency := range []string{"usd", "US", "USDT", "12A"} {
+			assert.ErrorIs(t, TransactionDetails{Currency: currency}.Validate(), ErrInvalidCurrency)
+		}
+	})
+
+	t.Run("returns error for invalid merchant category code", func(t *testing.T) {
+		for _, mcc := range []string{"541", "54111", "ABCD"} {
+			assert.ErrorIs(t, TransactionDetails{MerchantCategoryCode: mcc}.Validate(), ErrInvalidMerchantCategoryCode)
+		}
+	})
+}
+
+func TestTransaction_Convert(t *testing.T) {
+	t.Run("converts amount and keeps the original", func(t *testing.T) {
+		transaction := &Transaction{Amount: -10.0, TransactionDetails: TransactionDetails{Currency: "USD"}}
+
+		transaction.Convert("BRL", 5.4321)
+
+		assert.Equal(t, -54.32, transaction.Amount)
+		assert.Equal(t, -10.0, transaction.OriginalAmount)
+		assert.Equal(t, "USD", transaction.Currency)
+		assert.Equal(t, "BRL", transaction.SettlementCurrency)
+		assert.Equal(t, 5.4321, transaction.FXRate)
+	})
+
+	t.Run("assumes settlement currency when currency is empty", func(t *testing.T) {
+		transaction := &Transaction{Amount: 10.0}
+
+		transaction.Convert("BRL", 1)
+
+		assert.Equal(t, 10.0, transaction.Amount)
+		assert.Equal(t, "BRL", transaction.Currency)
+	})
+}
+
+func TestNewAccountBalance(t *testing.T) {
+	t.Run("sums outstanding debt and leftover credit", func(t *testing.T) {
+		transactions := []*Transaction{
+			{Balance: -50.0},
+			{Balance: -23.5},
+			{Balance: 0},
+			{Balance: 10.25},
+		}
+
+		balance := NewAccountBalance(1, transactions)
+
+		assert.Equal(t, int64(1), balance.AccountID)
+		assert.Equal(t, 73.5, balance.Outstanding)
+		assert.Equal(t, 10.25, balance.Credit)
+		assert.Equal(t, -63.25, balance.Net())
+	})
+
+	t.Run("is zero for an empty ledger", func(t *testing.T) {
+		balance := NewAccountBalance(1, nil)
+
+		assert.Equal(t, 0.0, balance.Outstanding)
+		assert.Equal(t, 0.0, balance.Credit)
+	})
+}
+
+func TestRebalance(t *testing.T) {
+	t.Run("discharges earlier debits oldest first", func(t *testing.T) {
+		transactions := []*Transaction{
+			{ID: 1, OperationTypeID: OperationTypePurchase, Amount: -50.0, Balance: -50.0},
+			{ID: 2, OperationTypeID: OperationTypePurchase, Amount: -23.5, Balance: -23.5},
+			{ID: 3, OperationTypeID: OperationTypeWithdrawal, Amount: -18.7, Balance: -18.7},
+			{ID: 4, OperationTypeID: OperationTypePayment, Amount: 60.0, Balance: 60.0},
+			{ID: 5, OperationTypeID: OperationTypePayment, Amount: 100.0, Balance: 100.0},
+		}
+
+		changed := Rebalance(transactions)
+
+		assert.Equal(t, 0.0, transactions[0].Balance)
+		assert.Equal(t, 0.0, transactions[1].Balance)
+		assert.Equal(t, 0.0, transactions[2].Balance)
+		assert.Equal(t, 0.0, transactions[3].Balance)
+		assert.InDelta(t, 67.8, transactions[4].Balance, 0.001)
+		assert.Len(t, changed, 5)
+	})
+
+	t.Run("does not discharge debits posted after the credit", func(t *testing.T) {
+		transactions := []*Transaction{
+			{ID: 1, OperationTypeID: OperationTypePayment, Amount: 60.0, Balance: 60.0},
+			{ID: 2, OperationTypeID: OperationTypePurchase, Amount: -50.0, Balance: -50.0},
+		}
+
+		changed := Rebalance(transactions)
+
+		assert.Equal(t, 60.0, transactions[0].Balance)
+		assert.Equal(t, -50.0, transactions[1].Balance)
+		assert.Empty(t, changed)
+	})
+
+	t.Run("repairs drifted balances", func(t *testing.T) {
+		transactions := []*Transaction{
+			{ID: 1, OperationTypeID: OperationTypePurchase, Amount: -50.0, Balance: -50.0},
+			{ID: 2, OperationTypeID: OperationTypePayment, Amount: 20.0, Balance: 20.0},
+		}
+
+		changed := Rebalance(transactions)
+
+		assert.Equal(t, -30.0, transactions[0].Balance)
+		assert.Equal(t, 0.0, transactions[1].Balance)
+		assert.Len(t, changed, 2)
+	})
+}
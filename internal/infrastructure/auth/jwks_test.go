@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeJWKS(t *testing.T, keys map[string]any) []byte {
+	t.Helper()
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	for kid, key := range keys {
+		switch k := key.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, jwk{
+				Kty: "RSA", Kid: kid, Use: "sig",
+				N: base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+				E: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+			})
+		case *ecdsa.PublicKey:
+			set.Keys = append(set.Keys, jwk{
+				Kty: "EC", Kid: kid, Crv: "P-256",
+				X: base64.RawURLEncoding.EncodeToString(k.X.Bytes()),
+				Y: base64.RawURLEncoding.EncodeToString(k.Y.Bytes()),
+			})
+		}
+	}
+
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	return data
+}
+
+func TestJWKS_Key(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("reads rsa and ec keys from a file", func(t *testing.T) {
+		// given
+		path := filepath.Join(t.TempDir(), "jwks.json")
+		data := encodeJWKS(t, map[string]any{"rsa": &rsaKey.PublicKey, "ec": &ecKey.PublicKey})
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+		jwks := NewJWKS(path, time.Hour)
+
+		// when
+		rsaPublic, rsaErr := jwks.Key(context.Background(), "rsa")
+		ecPublic, ecErr := jwks.Key(context.Background(), "ec")
+
+		// then
+		assert.NoError(t, rsaErr)
+		assert.True(t, rsaKey.PublicKey.Equal(rsaPublic))
+		assert.NoError(t, ecErr)
+		assert.True(t, ecKey.PublicKey.Equal(ecPublic))
+	})
+
+	t.Run("caches keys fetched from a url", func(t *testing.T) {
+		// given
+		var requests atomic.Int32
+		data := encodeJWKS(t, map[string]any{"rsa": &rsaKey.PublicKey})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.Write(data)
+		}))
+		defer server.Close()
+		jwks := NewJWKS(server.URL, time.Hour)
+
+		// when
+		_, err1 := jwks.Key(context.Background(), "rsa")
+		_, err2 := jwks.Key(context.Background(), "rsa")
+
+		// then
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("refetches when the cached keys are stale", func(t *testing.T) {
+		// given
+		var requests atomic.Int32
+		data := encodeJWKS(t, map[string]any{"rsa": &rsaKey.PublicKey})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.Write(data)
+		}))
+		defer server.Close()
+		jwks := NewJWKS(server.URL, time.Hour)
+		_, err := jwks.Key(context.Background(), "rsa")
+		require.NoError(t, err)
+		jwks.fetchedAt = time.Now().Add(-2 * time.Hour)
+
+		// when
+		_, err = jwks.Key(context.Background(), "rsa")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("refetches on an unknown key id to pick up rotated keys", func(t *testing.T) {
+		// given
+		var rotated atomic.Bool
+		before := encodeJWKS(t, map[string]any{"rsa": &rsaKey.PublicKey})
+		after := encodeJWKS(t, map[string]any{"rsa": &rsaKey.PublicKey, "ec": &ecKey.PublicKey})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if rotated.Load() {
+				w.Write(after)
+				return
+			}
+			w.Write(before)
+		}))
+		defer server.Close()
+		jwks := NewJWKS(server.URL, time.Hour)
+		_, err := jwks.Key(context.Background(), "rsa")
+		require.NoError(t, err)
+		rotated.Store(true)
+		jwks.fetchedAt = time.Now().Add(-time.Minute)
+
+		// when
+		key, err := jwks.Key(context.Background(), "ec")
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, ecKey.PublicKey.Equal(key))
+	})
+
+	t.Run("does not refetch on unknown key ids more than once per interval", func(t *testing.T) {
+		// given
+		var requests atomic.Int32
+		data := encodeJWKS(t, map[string]any{"rsa": &rsaKey.PublicKey})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.Write(data)
+		}))
+		defer server.Close()
+		jwks := NewJWKS(server.URL, time.Hour)
+		_, err := jwks.Key(context.Background(), "rsa")
+		require.NoError(t, err)
+
+		// when
+		_, err = jwks.Key(context.Background(), "unknown")
+
+		// then
+		assert.ErrorIs(t, err, errUnknownKey)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("keeps known keys when the key set cannot be fetched", func(t *testing.T) {
+		// given
+		var failing atomic.Bool
+		data := encodeJWKS(t, map[string]any{"rsa": &rsaKey.PublicKey})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if failing.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write(data)
+		}))
+		defer server.Close()
+		jwks := NewJWKS(server.URL, time.Hour)
+		_, err := jwks.Key(context.Background(), "rsa")
+		require.NoError(t, err)
+		failing.Store(true)
+		jwks.fetchedAt = time.Now().Add(-2 * time.Hour)
+
+		// when
+		key, err := jwks.Key(context.Background(), "rsa")
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, rsaKey.PublicKey.Equal(key))
+	})
+
+	t.Run("returns error when the key set is invalid", func(t *testing.T) {
+		// given
+		path := filepath.Join(t.TempDir(), "jwks.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"keys":[{"kty":"EC","kid":"ec","crv":"P-256","x":"AQ","y":"AQ"}]}`), 0o600))
+		jwks := NewJWKS(path, time.Hour)
+
+		// when
+		_, err := jwks.Key(context.Background(), "ec")
+
+		// then
+		assert.Error(t, err)
+	})
+}
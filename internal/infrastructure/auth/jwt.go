@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+// clockSkew tolerates small clock differences between the gateway issuing the
+// tokens and this service.
+const clockSkew = 30 * time.Second
+
+type keySet interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+type JWTConfig struct {
+	Issuer   string
+	Audience string
+	// ScopesClaim names the claim carrying the token scopes, either as a
+	// space-separated string or an array.
+	ScopesClaim string
+	// AccountClaim names the claim restricting the token to an account. Tokens
+	// without it are not restricted, leave empty to ignore it altogether.
+	AccountClaim string
+}
+
+// JWTVerifier validates RS256 and ES256 tokens signed by a key of the key set
+// and maps their claims to a principal.
+type JWTVerifier struct {
+	keys   keySet
+	config JWTConfig
+	parser *jwt.Parser
+}
+
+func NewJWTVerifier(keys keySet, config JWTConfig) *JWTVerifier {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	return &JWTVerifier{
+		keys:   keys,
+		config: config,
+		parser: jwt.NewParser(options...),
+	}
+}
+
+// Verify returns the principal the token was issued to, or
+// domain.ErrInvalidToken when the token cannot be trusted. When the key set
+// cannot be fetched it returns domain.ErrServiceUnavailable instead, so that
+// valid tokens are retried rather than rejected.
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if errors.Is(err, errKeySetUnavailable) {
+		return nil, domain.ErrServiceUnavailable
+	}
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	principal := &domain.Principal{
+		ClientID: clientID(claims),
+		Scopes:   v.scopes(claims),
+	}
+	if principal.ClientID == "" {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if v.config.AccountClaim != "" {
+		if value, ok := claims[v.config.AccountClaim]; ok {
+			accountID, err := parseAccountID(value)
+			if err != nil {
+				return nil, domain.ErrInvalidToken
+			}
+			principal.AccountID = accountID
+		}
+	}
+
+	return principal, nil
+}
+
+// scopes keeps the scopes this service knows about, tokens are often shared
+// with other services and carry theirs too.
+func (v *JWTVerifier) scopes(claims jwt.MapClaims) []domain.Scope {
+	var values []string
+	switch claim := claims[v.config.ScopesClaim].(type) {
+	case string:
+		values = strings.Fields(claim)
+	case []any:
+		for _, value := range claim {
+			if s, ok := value.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	var scopes []domain.Scope
+	for _, value := range values {
+		if scope := domain.Scope(value); scope.IsValid() {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+func clientID(claims jwt.MapClaims) string {
+	for _, name := range []string{"client_id", "azp", "sub"} {
+		if value, ok := claims[name].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func parseAccountID(value any) (int64, error) {
+	var accountID int64
+	switch v := value.(type) {
+	case float64:
+		accountID = int64(v)
+		if float64(accountID) != v {
+			return 0, errors.New("account id is not an integer")
+		}
+	case string:
+		var err error
+		accountID, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+	default:
+		return 0, errors.New("account id has an unexpected type")
+	}
+
+	if accountID <= 0 {
+		return 0, errors.New("account id must be positive")
+	}
+	return accountID, nil
+}
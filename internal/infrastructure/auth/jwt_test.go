@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+type staticKeys map[string]crypto.PublicKey
+
+func (s staticKeys) Key(_ context.Context, kid string) (crypto.PublicKey, error) {
+	key, ok := s[kid]
+	if !ok {
+		return nil, errUnknownKey
+	}
+	return key, nil
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key crypto.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestJWTVerifier_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keys := staticKeys{"rsa": &rsaKey.PublicKey, "ec": &ecKey.PublicKey}
+	verifier := NewJWTVerifier(keys, JWTConfig{
+		Issuer:       "https://gateway.example.com",
+		Audience:     "pismo",
+		ScopesClaim:  "scope",
+		AccountClaim: "account_id",
+	})
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   "https://gateway.example.com",
+			"aud":   "pismo",
+			"sub":   "user-1",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"scope": "accounts:read transactions:write other:scope",
+		}
+	}
+
+	t.Run("maps an rs256 token to a principal", func(t *testing.T) {
+		// given
+		token := sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, validClaims())
+
+		// when
+		principal, err := verifier.Verify(context.Background(), token)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Principal{
+			ClientID: "user-1",
+			Scopes:   []domain.Scope{domain.ScopeAccountsRead, domain.ScopeTransactionsWrite},
+		}, principal)
+	})
+
+	t.Run("maps an es256 token with array scopes and an account", func(t *testing.T) {
+		// given
+		claims := validClaims()
+		claims["client_id"] = "mobile-app"
+		claims["scope"] = []string{"accounts:read"}
+		claims["account_id"] = 42
+		token := sign(t, jwt.SigningMethodES256, "ec", ecKey, claims)
+
+		// when
+		principal, err := verifier.Verify(context.Background(), token)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Principal{
+			ClientID:  "mobile-app",
+			Scopes:    []domain.Scope{domain.ScopeAccountsRead},
+			AccountID: 42,
+		}, principal)
+	})
+
+	t.Run("accepts the account as a string", func(t *testing.T) {
+		// given
+		claims := validClaims()
+		claims["account_id"] = "7"
+		token := sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims)
+
+		// when
+		principal, err := verifier.Verify(context.Background(), token)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), principal.AccountID)
+	})
+
+	invalid := map[string]func() string{
+		"expired": func() string {
+			claims := validClaims()
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			return sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims)
+		},
+		"without expiry": func() string {
+			claims := validClaims()
+			delete(claims, "exp")
+			return sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims)
+		},
+		"from another issuer": func() string {
+			claims := validClaims()
+			claims["iss"] = "https://evil.example.com"
+			return sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims)
+		},
+		"for another audience": func() string {
+			claims := validClaims()
+			claims["aud"] = "other"
+			return sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims)
+		},
+		"signed with an unknown key": func() string {
+			other, err := rsa.GenerateKey(rand.Reader, 2048)
+			require.NoError(t, err)
+			return sign(t, jwt.SigningMethodRS256, "rsa", other, validClaims())
+		},
+		"signed with hmac": func() string {
+			return sign(t, jwt.SigningMethodHS256, "rsa", []byte("secret"), validClaims())
+		},
+		"without subject": func() string {
+			claims := validClaims()
+			delete(claims, "sub")
+			return sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims)
+		},
+		"with a malformed account": func() string {
+			claims := validClaims()
+			claims["account_id"] = "abc"
+			return sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims)
+		},
+		"malformed": func() string {
+			return "not.a.token"
+		},
+	}
+	t.Run("returns unavailable when the key set cannot be fetched", func(t *testing.T) {
+		// given
+		verifier := NewJWTVerifier(NewJWKS(filepath.Join(t.TempDir(), "missing.json"), time.Hour), JWTConfig{ScopesClaim: "scope"})
+		token := sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, validClaims())
+
+		// when
+		principal, err := verifier.Verify(context.Background(), token)
+
+		// then
+		assert.Nil(t, principal)
+		assert.ErrorIs(t, err, domain.ErrServiceUnavailable)
+	})
+
+	for name, token := range invalid {
+		t.Run("rejects a token "+name, func(t *testing.T) {
+			// when
+			principal, err := verifier.Verify(context.Background(), token())
+
+			// then
+			assert.Nil(t, principal)
+			assert.ErrorIs(t, err, domain.ErrInvalidToken)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database"
+)
+
+const migrateUsage = `usage: migrate <command>
+
+commands:
+  up            apply all pending migrations
+  down [N]      roll back the last N applied migrations (default 1)
+  to N          apply or roll back migrations until N is the latest applied
+  status        list migrations and whether they are applied
+  baseline N    record migrations up to N as applied without running them`
+
+// ErrMigrateUsage is returned when the migrate arguments are invalid, its
+// message is the command's usage.
+var ErrMigrateUsage = errors.New(migrateUsage)
+
+// Migrate runs the migrate subcommand given its arguments.
+func Migrate(ctx context.Context, migrator *database.Migrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return ErrMigrateUsage
+	}
+
+	switch args[0] {
+	case "up":
+		ran, err := migrator.Up(ctx)
+		printMigrations(out, "applied", ran)
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return ErrMigrateUsage
+			}
+			steps = n
+		}
+		ran, err := migrator.Down(ctx, steps)
+		printMigrations(out, "rolled back", ran)
+		return err
+	case "to":
+		version, err := versionArg(args)
+		if err != nil {
+			return err
+		}
+		ran, err := migrator.To(ctx, version)
+		printMigrations(out, "migrated", ran)
+		return err
+	case "baseline":
+		version, err := versionArg(args)
+		if err != nil {
+			return err
+		}
+		if err := migrator.Baseline(ctx, version); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "recorded migrations up to %03d as applied\n", version)
+		return nil
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%03d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+	}
+
+	return ErrMigrateUsage
+}
+
+func versionArg(args []string) (int, error) {
+	if len(args) < 2 {
+		return 0, ErrMigrateUsage
+	}
+
+	version, err := strconv.Atoi(args[1])
+	if err != nil || version < 0 {
+		return 0, ErrMigrateUsage
+	}
+
+	return version, nil
+}
+
+func printMigrations(out io.Writer, action string, migrations []database.Migration) {
+	if len(migrations) == 0 {
+		fmt.Fprintln(out, "no migrations to run")
+		return
+	}
+	for _, migration := range migrations {
+		fmt.Fprintf(out, "%s %03d_%s\n", action, migration.Version, migration.Name)
+	}
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Run("returns usage error for invalid arguments", func(t *testing.T) {
+		invalid := [][]string{
+			nil,
+			{"sideways"},
+			{"down", "0"},
+			{"down", "two"},
+			{"to"},
+			{"to", "-1"},
+			{"baseline"},
+		}
+
+		for _, args := range invalid {
+			err := Migrate(context.Background(), nil, args, &bytes.Buffer{})
+
+			assert.ErrorIs(t, err, ErrMigrateUsage, "args %v", args)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+)
+
+var ErrInvalidFormat = errors.New("output format must be table or json")
+
+// Tabular is implemented by values that can be printed as a table. The same
+// value is encoded as is in JSON mode, so it should carry json tags.
+type Tabular interface {
+	Header() []string
+	Rows() [][]string
+}
+
+type Printer struct {
+	out    io.Writer
+	format Format
+}
+
+func NewPrinter(out io.Writer, format string) (*Printer, error) {
+	switch Format(format) {
+	case FormatTable, FormatJSON:
+		return &Printer{out: out, format: Format(format)}, nil
+	}
+
+	return nil, ErrInvalidFormat
+}
+
+func (p *Printer) Print(value Tabular) error {
+	if p.format == FormatJSON {
+		encoder := json.NewEncoder(p.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(value.Header(), "\t"))
+	for _, row := range value.Rows() {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	return w.Flush()
+}
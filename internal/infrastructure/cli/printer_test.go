@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRow struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func (r testRow) Header() []string {
+	return []string{"ID", "NAME"}
+}
+
+func (r testRow) Rows() [][]string {
+	return [][]string{{"1", r.Name}}
+}
+
+func TestNewPrinter(t *testing.T) {
+	t.Run("accepts table and json", func(t *testing.T) {
+		for _, format := range []string{"table", "json"} {
+			printer, err := NewPrinter(&bytes.Buffer{}, format)
+
+			assert.NoError(t, err)
+			assert.NotNil(t, printer)
+		}
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		printer, err := NewPrinter(&bytes.Buffer{}, "yaml")
+
+		assert.Nil(t, printer)
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+}
+
+func TestPrinter_Print(t *testing.T) {
+	t.Run("prints aligned columns in table mode", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		printer, _ := NewPrinter(out, "table")
+
+		// when
+		err := printer.Print(testRow{ID: 1, Name: "savings"})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "ID  NAME\n1   savings\n", out.String())
+	})
+
+	t.Run("encodes the value in json mode", func(t *testing.T) {
+		// given
+		out := &bytes.Buffer{}
+		printer, _ := NewPrinter(out, "json")
+
+		// when
+		err := printer.Print(testRow{ID: 1, Name: "savings"})
+
+		// then
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"id": 1, "name": "savings"}`, out.String())
+	})
+}
@@ -0,0 +1,317 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// setting is one configuration value, which can come from the YAML file
+// under its key, from its environment variable, or from a flag named after
+// its key.
+type setting struct {
+	key string
+	env string
+	// secret values are redacted by Print.
+	secret bool
+	// value points into the Config: a *string, *int, *float64, *bool or
+	// *time.Duration.
+	value any
+}
+
+func (c *Config) settings() []setting {
+	return []setting{
+		{key: "environment", env: "ENVIRONMENT", value: &c.Environment},
+		{key: "storage", env: "STORAGE", value: &c.Storage},
+		{key: "log.redaction", env: "LOG_REDACTION", value: &c.Log.Redaction},
+		{key: "log.hash_key", env: "LOG_HASH_KEY", secret: true, value: &c.Log.HashKey},
+		{key: "server.port", env: "SERVER_PORT", value: &c.Server.Port},
+		{key: "server.read_timeout", env: "SERVER_READ_TIMEOUT", value: &c.Server.ReadTimeout},
+		{key: "server.read_header_timeout", env: "SERVER_READ_HEADER_TIMEOUT", value: &c.Server.ReadHeaderTimeout},
+		{key: "server.write_timeout", env: "SERVER_WRITE_TIMEOUT", value: &c.Server.WriteTimeout},
+		{key: "server.idle_timeout", env: "SERVER_IDLE_TIMEOUT", value: &c.Server.IdleTimeout},
+		{key: "server.drain_delay", env: "SERVER_DRAIN_DELAY", value: &c.Server.DrainDelay},
+		{key: "server.shutdown_timeout", env: "SERVER_SHUTDOWN_TIMEOUT", value: &c.Server.ShutdownTimeout},
+		{key: "server.tls.cert_file", env: "SERVER_TLS_CERT_FILE", value: &c.Server.TLS.CertFile},
+		{key: "server.tls.key_file", env: "SERVER_TLS_KEY_FILE", value: &c.Server.TLS.KeyFile},
+		{key: "server.tls.client_ca_file", env: "SERVER_TLS_CLIENT_CA_FILE", value: &c.Server.TLS.ClientCAFile},
+		{key: "server.tls.client_auth", env: "SERVER_TLS_CLIENT_AUTH", value: &c.Server.TLS.ClientAuth},
+		{key: "server.tls.min_version", env: "SERVER_TLS_MIN_VERSION", value: &c.Server.TLS.MinVersion},
+		{key: "grpc.port", env: "GRPC_PORT", value: &c.GRPC.Port},
+		{key: "metrics.port", env: "METRICS_PORT", value: &c.Metrics.Port},
+		{key: "health.check_timeout", env: "HEALTH_CHECK_TIMEOUT", value: &c.Health.CheckTimeout},
+		{key: "health.pool_saturation", env: "HEALTH_POOL_SATURATION", value: &c.Health.PoolSaturation},
+		{key: "tracing.service_name", env: "TRACING_SERVICE_NAME", value: &c.Tracing.ServiceName},
+		{key: "tracing.exporter", env: "TRACING_EXPORTER", value: &c.Tracing.Exporter},
+		{key: "tracing.otlp_endpoint", env: "TRACING_OTLP_ENDPOINT", value: &c.Tracing.OTLPEndpoint},
+		{key: "tracing.otlp_insecure", env: "TRACING_OTLP_INSECURE", value: &c.Tracing.OTLPInsecure},
+		{key: "auth.enabled", env: "AUTH_ENABLED", value: &c.Auth.Enabled},
+		{key: "auth.jwt.jwks", env: "JWT_JWKS", value: &c.Auth.JWT.JWKS},
+		{key: "auth.jwt.jwks_refresh_interval", env: "JWT_JWKS_REFRESH_INTERVAL", value: &c.Auth.JWT.JWKSRefreshInterval},
+		{key: "auth.jwt.issuer", env: "JWT_ISSUER", value: &c.Auth.JWT.Issuer},
+		{key: "auth.jwt.audience", env: "JWT_AUDIENCE", value: &c.Auth.JWT.Audience},
+		{key: "auth.jwt.scopes_claim", env: "JWT_SCOPES_CLAIM", value: &c.Auth.JWT.ScopesClaim},
+		{key: "auth.jwt.account_claim", env: "JWT_ACCOUNT_CLAIM", value: &c.Auth.JWT.AccountClaim},
+		{key: "rate_limit.policies", env: "RATE_LIMITS", value: &c.RateLimit.Policies},
+		{key: "rate_limit.store", env: "RATE_LIMIT_STORE", value: &c.RateLimit.Store},
+		{key: "validation.requests", env: "OPENAPI_VALIDATE_REQUESTS", value: &c.Validation.Requests},
+		{key: "validation.responses", env: "OPENAPI_VALIDATE_RESPONSES", value: &c.Validation.Responses},
+		{key: "database.driver", env: "DATABASE_DRIVER", value: &c.Database.Driver},
+		{key: "database.url", env: "DATABASE_URL", secret: true, value: &c.Database.URL},
+		{key: "database.max_open_conns", env: "DATABASE_MAX_OPEN_CONNS", value: &c.Database.MaxOpenConns},
+		{key: "database.max_idle_conns", env: "DATABASE_MAX_IDLE_CONNS", value: &c.Database.MaxIdleConns},
+		{key: "database.conn_max_lifetime", env: "DATABASE_CONN_MAX_LIFETIME", value: &c.Database.ConnMaxLifetime},
+		{key: "database.conn_max_idle_time", env: "DATABASE_CONN_MAX_IDLE_TIME", value: &c.Database.ConnMaxIdleTime},
+		{key: "database.min_conns", env: "DATABASE_MIN_CONNS", value: &c.Database.MinConns},
+		{key: "database.health_check_period", env: "DATABASE_HEALTH_CHECK_PERIOD", value: &c.Database.HealthCheckPeriod},
+		{key: "database.statement_cache_capacity", env: "DATABASE_STATEMENT_CACHE_CAPACITY", value: &c.Database.StatementCacheCapacity},
+		{key: "database.auto_migrate", env: "DATABASE_AUTO_MIGRATE", value: &c.Database.AutoMigrate},
+		{key: "fx.rates", env: "FX_RATES", value: &c.FX.Rates},
+		{key: "fraud.rules_refresh_interval", env: "FRAUD_RULES_REFRESH_INTERVAL", value: &c.Fraud.RulesRefreshInterval},
+		{key: "scheduler.interval", env: "SCHEDULER_INTERVAL", value: &c.Scheduler.Interval},
+		{key: "scheduler.batch_size", env: "SCHEDULER_BATCH_SIZE", value: &c.Scheduler.BatchSize},
+		{key: "scheduler.shutdown_timeout", env: "SCHEDULER_SHUTDOWN_TIMEOUT", value: &c.Scheduler.ShutdownTimeout},
+	}
+}
+
+// flagName is the flag of a setting, its key with dashes, like
+// --server-read-timeout for server.read_timeout.
+func (s setting) flagName() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(s.key)
+}
+
+func (s setting) set(raw string) error {
+	switch value := s.value.(type) {
+	case *string:
+		*value = raw
+	case *int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", raw)
+		}
+		*value = n
+	case *float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", raw)
+		}
+		*value = f
+	case *bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", raw)
+		}
+		*value = b
+	case *time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration, like 30s or 5m", raw)
+		}
+		*value = d
+	}
+	return nil
+}
+
+// Load builds the configuration from, in increasing precedence, the
+// defaults, the YAML file named by --config or CONFIG_FILE, the environment
+// and the command line flags in args. A variable with the _FILE suffix, like
+// DATABASE_URL_FILE, reads the value from that file, for Docker and
+// Kubernetes secrets. Every invalid value is reported in the error.
+func Load(args []string) (*Config, error) {
+	cfg := defaults()
+	settings := cfg.settings()
+
+	flags := flag.NewFlagSet("config", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	file := flags.String("config", os.Getenv("CONFIG_FILE"), "path of a YAML configuration file")
+	flags.BoolVar(&cfg.PrintConfig, "print-config", false, "print the configuration, secrets redacted, and exit")
+	flagValues := make(map[string]string)
+	for _, s := range settings {
+		flags.Func(s.flagName(), "overrides "+s.env, func(raw string) error {
+			flagValues[s.key] = raw
+			return nil
+		})
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	cfg.Args = flags.Args()
+
+	var errs []error
+	if *file != "" {
+		values, err := readFile(*file)
+		if err != nil {
+			return nil, err
+		}
+		known := make(map[string]bool, len(settings))
+		for _, s := range settings {
+			known[s.key] = true
+			if raw, ok := values[s.key]; ok {
+				if err := s.set(raw); err != nil {
+					errs = append(errs, fmt.Errorf("%s in %s: %w", s.key, *file, err))
+				}
+			}
+		}
+		var unknown []string
+		for key := range values {
+			if !known[key] {
+				unknown = append(unknown, key)
+			}
+		}
+		sort.Strings(unknown)
+		for _, key := range unknown {
+			errs = append(errs, fmt.Errorf("%s in %s: unknown setting", key, *file))
+		}
+	}
+
+	for _, s := range settings {
+		raw, source, err := lookupEnv(s.env)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if raw == "" {
+			continue
+		}
+		if err := s.set(raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", source, err))
+		}
+	}
+
+	for _, s := range settings {
+		if raw, ok := flagValues[s.key]; ok {
+			if err := s.set(raw); err != nil {
+				errs = append(errs, fmt.Errorf("--%s: %w", s.flagName(), err))
+			}
+		}
+	}
+
+	if cfg.Log.Redaction == "" {
+		// Masked values are easier to debug with, but a few digits of a
+		// document are still personal data, so production hashes them.
+		cfg.Log.Redaction = "mask"
+		if cfg.Environment == "production" {
+			cfg.Log.Redaction = "hash"
+		}
+	}
+
+	// Validating values that failed to parse would report them twice.
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// lookupEnv returns the value of the variable, or the content of the file
+// named by its _FILE variant, along with where it came from. Empty
+// variables are treated as unset.
+func lookupEnv(env string) (string, string, error) {
+	value, path := os.Getenv(env), os.Getenv(env+"_FILE")
+	if path == "" {
+		return value, env, nil
+	}
+	if value != "" {
+		return "", "", fmt.Errorf("%s and %s_FILE are both set", env, env)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("%s_FILE: %w", env, err)
+	}
+	// Secret files usually end with a newline that is not part of the value.
+	return strings.TrimRight(string(data), "\r\n"), env + "_FILE", nil
+}
+
+// readFile reads a YAML file into its settings, keyed like server.port.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	if err := flatten("", doc, values); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+func flatten(prefix string, doc map[string]any, values map[string]string) error {
+	for key, value := range doc {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch value := value.(type) {
+		case map[string]any:
+			if err := flatten(key, value, values); err != nil {
+				return err
+			}
+		case []any:
+			return fmt.Errorf("%s: lists are not supported", key)
+		case nil:
+			values[key] = ""
+		default:
+			values[key] = fmt.Sprint(value)
+		}
+	}
+	return nil
+}
+
+// Print writes the configuration as a YAML file that Load accepts, with
+// secrets redacted.
+func (c *Config) Print(w io.Writer) error {
+	doc := make(map[string]any)
+	for _, s := range c.settings() {
+		var value any
+		switch v := s.value.(type) {
+		case *string:
+			value = *v
+			if s.secret && *v != "" {
+				value = "[redacted]"
+			}
+		case *int:
+			value = *v
+		case *float64:
+			value = *v
+		case *bool:
+			value = *v
+		case *time.Duration:
+			value = v.String()
+		}
+
+		node := doc
+		path := strings.Split(s.key, ".")
+		for _, name := range path[:len(path)-1] {
+			child, ok := node[name].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				node[name] = child
+			}
+			node = child
+		}
+		node[path[len(path)-1]] = value
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/lib/pq"
 	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
 )
 
 const uniqueViolationCode = "23505"
@@ -19,11 +20,14 @@ func NewAccountRepository(db *sql.DB) *AccountRepository {
 	return &AccountRepository{db: db}
 }
 
-func (r *AccountRepository) Create(ctx context.Context, account *domain.Account) (*domain.Account, error) {
-	query := `INSERT INTO accounts (document_number) VALUES ($1) RETURNING account_id`
+func (r *AccountRepository) Create(ctx context.Context, account *domain.Account) (_ *domain.Account, err error) {
+	ctx, span := tracing.StartQuery(ctx, "AccountRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	query := `INSERT INTO accounts (document_number, currency) VALUES ($1, $2) RETURNING account_id`
 
 	var id int64
-	err := r.db.QueryRowContext(ctx, query, account.DocumentNumber).Scan(&id)
+	err = r.db.QueryRowContext(ctx, query, account.DocumentNumber, account.Currency).Scan(&id)
 	if err != nil {
 		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == uniqueViolationCode {
 			return nil, domain.ErrAccountAlreadyExists
@@ -34,17 +38,22 @@ func (r *AccountRepository) Create(ctx context.Context, account *domain.Account)
 	return &domain.Account{
 		ID:             id,
 		DocumentNumber: account.DocumentNumber,
+		Currency:       account.Currency,
 	}, nil
 }
 
-func (r *AccountRepository) FindByID(ctx context.Context, ID int64) (*domain.Account, error) {
+func (r *AccountRepository) FindByID(ctx context.Context, ID int64) (_ *domain.Account, err error) {
+	ctx, span := tracing.StartQuery(ctx, "AccountRepository.FindByID")
+	defer func() { tracing.End(span, err) }()
+
 	var account domain.Account
 
-	query := `SELECT account_id, document_number FROM accounts WHERE account_id = ($1)`
+	query := `SELECT account_id, document_number, currency FROM accounts WHERE account_id = ($1)`
 
-	err := r.db.QueryRowContext(ctx, query, ID).Scan(
+	err = r.db.QueryRowContext(ctx, query, ID).Scan(
 		&account.ID,
 		&account.DocumentNumber,
+		&account.Currency,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -0,0 +1,142 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+const apiKeyColumns = `api_key_id, client_id, key_prefix, key_hash, scopes, created_at, revoked_at`
+
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepository(db *sql.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *domain.APIKey) (_ *domain.APIKey, err error) {
+	ctx, span := tracing.StartQuery(ctx, "APIKeyRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		INSERT INTO api_keys (client_id, key_prefix, key_hash, scopes, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING api_key_id
+	`
+
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	err = r.db.QueryRowContext(ctx, query, key.ClientID, key.Prefix, key.Hash, pq.Array(scopes), key.CreatedAt.UTC()).Scan(&key.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) FindByHash(ctx context.Context, hash string) (_ *domain.APIKey, err error) {
+	ctx, span := tracing.StartQuery(ctx, "APIKeyRepository.FindByHash")
+	defer func() { tracing.End(span, err) }()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, hash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) List(ctx context.Context) (_ []*domain.APIKey, err error) {
+	ctx, span := tracing.StartQuery(ctx, "APIKeyRepository.List")
+	defer func() { tracing.End(span, err) }()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY api_key_id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// Revoke marks the key as revoked, keeping the first revocation time when the
+// key was already revoked.
+func (r *APIKeyRepository) Revoke(ctx context.Context, ID int64, at time.Time) (err error) {
+	ctx, span := tracing.StartQuery(ctx, "APIKeyRepository.Revoke")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, $1)
+		WHERE api_key_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, at.UTC(), ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func scanAPIKey(row rowScanner) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var scopes []string
+	var revokedAt sql.NullTime
+
+	err := row.Scan(
+		&key.ID,
+		&key.ClientID,
+		&key.Prefix,
+		&key.Hash,
+		pq.Array(&scopes),
+		&key.CreatedAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, domain.Scope(scope))
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type CardRepository struct {
+	db *sql.DB
+}
+
+func NewCardRepository(db *sql.DB) *CardRepository {
+	return &CardRepository{db: db}
+}
+
+func (r *CardRepository) Create(ctx context.Context, card *domain.Card) (_ *domain.Card, err error) {
+	ctx, span := tracing.StartQuery(ctx, "CardRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		INSERT INTO cards (account_id, masked_pan, last4, token, expires_at, status, card_type, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING card_id
+	`
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		card.AccountID,
+		card.MaskedPAN,
+		card.Last4,
+		card.Token,
+		card.ExpiresAt,
+		card.Status,
+		card.Type,
+		card.CreatedAt,
+	).Scan(&card.ID)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == foreignKeyViolationCode {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	return card, nil
+}
+
+func (r *CardRepository) FindByID(ctx context.Context, ID int64) (_ *domain.Card, err error) {
+	ctx, span := tracing.StartQuery(ctx, "CardRepository.FindByID")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT card_id, account_id, masked_pan, last4, token, expires_at, status, card_type, created_at
+		FROM cards
+		WHERE card_id = $1
+	`
+
+	var card domain.Card
+	err = r.db.QueryRowContext(ctx, query, ID).Scan(
+		&card.ID,
+		&card.AccountID,
+		&card.MaskedPAN,
+		&card.Last4,
+		&card.Token,
+		&card.ExpiresAt,
+		&card.Status,
+		&card.Type,
+		&card.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrCardNotFound
+		}
+		return nil, err
+	}
+
+	return &card, nil
+}
+
+func (r *CardRepository) UpdateStatus(ctx context.Context, card *domain.Card) (_ *domain.Card, err error) {
+	ctx, span := tracing.StartQuery(ctx, "CardRepository.UpdateStatus")
+	defer func() { tracing.End(span, err) }()
+
+	query := `UPDATE cards SET status = $1 WHERE card_id = $2 RETURNING card_id`
+
+	err = r.db.QueryRowContext(ctx, query, card.Status, card.ID).Scan(&card.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrCardNotFound
+		}
+		return nil, err
+	}
+
+	return card, nil
+}
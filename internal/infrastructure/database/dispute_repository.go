@@ -0,0 +1,303 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+const disputeColumns = `
+	dispute_id, transaction_id, account_id, amount, reason, status,
+	COALESCE(provisional_credit_transaction_id, 0), COALESCE(final_transaction_id, 0),
+	opened_at, updated_at, resolved_at
+`
+
+type DisputeRepository struct {
+	db *sql.DB
+}
+
+func NewDisputeRepository(db *sql.DB) *DisputeRepository {
+	return &DisputeRepository{db: db}
+}
+
+func (r *DisputeRepository) Create(ctx context.Context, dispute *domain.Dispute) (_ *domain.Dispute, err error) {
+	ctx, span := tracing.StartQuery(ctx, "DisputeRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		INSERT INTO disputes (
+			transaction_id, account_id, amount, reason, status,
+			provisional_credit_transaction_id, final_transaction_id, opened_at, updated_at, resolved_at
+		)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, 0), NULLIF($7, 0), $8, $9, $10)
+		RETURNING dispute_id
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		dispute.TransactionID,
+		dispute.AccountID,
+		dispute.Amount,
+		dispute.Reason,
+		dispute.Status,
+		dispute.ProvisionalCreditTransactionID,
+		dispute.FinalTransactionID,
+		dispute.OpenedAt,
+		dispute.UpdatedAt,
+		disputeResolvedAt(dispute),
+	).Scan(&dispute.ID)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok {
+			if pgErr.Code == uniqueViolationCode && strings.Contains(pgErr.Constraint, "open_or_won_transaction_id") {
+				return nil, r.conflict(ctx, dispute.TransactionID)
+			}
+			if pgErr.Code == foreignKeyViolationCode {
+				return nil, domain.ErrTransactionNotFound
+			}
+		}
+		return nil, err
+	}
+
+	if err := insertDisputeEvents(ctx, tx, dispute); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return dispute, nil
+}
+
+// conflict tells which dispute kept a new one on the transaction from being
+// created, the index covering both unresolved and won disputes.
+func (r *DisputeRepository) conflict(ctx context.Context, transactionID int64) error {
+	query := `SELECT EXISTS (SELECT 1 FROM disputes WHERE transaction_id = $1 AND status = 'won')`
+
+	var won bool
+	if err := r.db.QueryRowContext(ctx, query, transactionID).Scan(&won); err != nil {
+		return err
+	}
+	if won {
+		return domain.ErrDisputeAlreadyWon
+	}
+	return domain.ErrDisputeAlreadyOpen
+}
+
+func (r *DisputeRepository) FindByID(ctx context.Context, ID int64) (_ *domain.Dispute, err error) {
+	ctx, span := tracing.StartQuery(ctx, "DisputeRepository.FindByID")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT ` + disputeColumns + `
+		FROM disputes
+		WHERE dispute_id = $1
+	`
+
+	dispute, err := scanDispute(r.db.QueryRowContext(ctx, query, ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrDisputeNotFound
+		}
+		return nil, err
+	}
+
+	if err := r.loadEvents(ctx, []*domain.Dispute{dispute}); err != nil {
+		return nil, err
+	}
+
+	return dispute, nil
+}
+
+func (r *DisputeRepository) ListByTransactionID(ctx context.Context, transactionID int64) (_ []*domain.Dispute, err error) {
+	ctx, span := tracing.StartQuery(ctx, "DisputeRepository.ListByTransactionID")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT ` + disputeColumns + `
+		FROM disputes
+		WHERE transaction_id = $1
+		ORDER BY opened_at ASC, dispute_id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var disputes []*domain.Dispute
+	for rows.Next() {
+		dispute, err := scanDispute(rows)
+		if err != nil {
+			return nil, err
+		}
+		disputes = append(disputes, dispute)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadEvents(ctx, disputes); err != nil {
+		return nil, err
+	}
+
+	return disputes, nil
+}
+
+// Update saves the dispute's current state and any events not yet persisted,
+// unless its status changed since it was loaded.
+func (r *DisputeRepository) Update(ctx context.Context, dispute *domain.Dispute) (_ *domain.Dispute, err error) {
+	ctx, span := tracing.StartQuery(ctx, "DisputeRepository.Update")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		UPDATE disputes
+		SET status = $1,
+			provisional_credit_transaction_id = NULLIF($2, 0),
+			final_transaction_id = NULLIF($3, 0),
+			updated_at = $4,
+			resolved_at = $5
+		WHERE dispute_id = $6 AND status = $7
+		RETURNING dispute_id
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		dispute.Status,
+		dispute.ProvisionalCreditTransactionID,
+		dispute.FinalTransactionID,
+		dispute.UpdatedAt,
+		disputeResolvedAt(dispute),
+		dispute.ID,
+		dispute.LoadedStatus(),
+	).Scan(&dispute.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrInvalidDisputeTransition
+		}
+		return nil, err
+	}
+
+	if err := insertDisputeEvents(ctx, tx, dispute); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return dispute, nil
+}
+
+func (r *DisputeRepository) loadEvents(ctx context.Context, disputes []*domain.Dispute) (err error) {
+	ctx, span := tracing.StartQuery(ctx, "DisputeRepository.loadEvents")
+	defer func() { tracing.End(span, err) }()
+
+	if len(disputes) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*domain.Dispute, len(disputes))
+	ids := make([]int64, 0, len(disputes))
+	for _, dispute := range disputes {
+		byID[dispute.ID] = dispute
+		ids = append(ids, dispute.ID)
+	}
+
+	query := `
+		SELECT event_id, dispute_id, status, note, created_at
+		FROM dispute_events
+		WHERE dispute_id = ANY($1)
+		ORDER BY created_at ASC, event_id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event domain.DisputeEvent
+		var disputeID int64
+		if err := rows.Scan(&event.ID, &disputeID, &event.Status, &event.Note, &event.CreatedAt); err != nil {
+			return err
+		}
+		byID[disputeID].Events = append(byID[disputeID].Events, event)
+	}
+
+	return rows.Err()
+}
+
+// insertDisputeEvents persists the events without an ID, which are the ones
+// appended since the dispute was loaded.
+func insertDisputeEvents(ctx context.Context, tx *sql.Tx, dispute *domain.Dispute) error {
+	query := `
+		INSERT INTO dispute_events (dispute_id, status, note, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING event_id
+	`
+
+	for i := range dispute.Events {
+		event := &dispute.Events[i]
+		if event.ID != 0 {
+			continue
+		}
+
+		err := tx.QueryRowContext(ctx, query, dispute.ID, event.Status, event.Note, event.CreatedAt).Scan(&event.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanDispute(row rowScanner) (*domain.Dispute, error) {
+	dispute := &domain.Dispute{}
+
+	var resolvedAt sql.NullTime
+	err := row.Scan(
+		&dispute.ID,
+		&dispute.TransactionID,
+		&dispute.AccountID,
+		&dispute.Amount,
+		&dispute.Reason,
+		&dispute.Status,
+		&dispute.ProvisionalCreditTransactionID,
+		&dispute.FinalTransactionID,
+		&dispute.OpenedAt,
+		&dispute.UpdatedAt,
+		&resolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	dispute.ResolvedAt = resolvedAt.Time
+
+	return dispute, nil
+}
+
+func disputeResolvedAt(dispute *domain.Dispute) sql.NullTime {
+	return sql.NullTime{Time: dispute.ResolvedAt, Valid: !dispute.ResolvedAt.IsZero()}
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type FraudRuleRepository struct {
+	db *sql.DB
+}
+
+func NewFraudRuleRepository(db *sql.DB) *FraudRuleRepository {
+	return &FraudRuleRepository{db: db}
+}
+
+func (r *FraudRuleRepository) ListEnabled(ctx context.Context) (_ []*domain.FraudRule, err error) {
+	ctx, span := tracing.StartQuery(ctx, "FraudRuleRepository.ListEnabled")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT rule_id, name, rule_type, action, window_seconds, max_count, max_amount, spike_multiplier, min_history, enabled
+		FROM fraud_rules
+		WHERE enabled
+		ORDER BY rule_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []*domain.FraudRule
+	for rows.Next() {
+		rule := &domain.FraudRule{}
+		var windowSeconds int64
+		err := rows.Scan(
+			&rule.ID,
+			&rule.Name,
+			&rule.Type,
+			&rule.Action,
+			&windowSeconds,
+			&rule.MaxCount,
+			&rule.MaxAmount,
+			&rule.SpikeMultiplier,
+			&rule.MinHistory,
+			&rule.Enabled,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rule.Window = time.Duration(windowSeconds) * time.Second
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+type FraudAssessmentRepository struct {
+	db *sql.DB
+}
+
+func NewFraudAssessmentRepository(db *sql.DB) *FraudAssessmentRepository {
+	return &FraudAssessmentRepository{db: db}
+}
+
+func (r *FraudAssessmentRepository) Create(ctx context.Context, assessment *domain.FraudAssessment) (_ *domain.FraudAssessment, err error) {
+	ctx, span := tracing.StartQuery(ctx, "FraudAssessmentRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		INSERT INTO fraud_assessments (account_id, transaction_id, operation_type_id, amount, decision, triggered_rules, created_at)
+		VALUES ($1, NULLIF($2, 0), $3, $4, $5, $6, $7)
+		RETURNING assessment_id
+	`
+
+	triggeredRules := assessment.TriggeredRules
+	if triggeredRules == nil {
+		triggeredRules = []string{}
+	}
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		assessment.AccountID,
+		assessment.TransactionID,
+		assessment.OperationTypeID,
+		assessment.Amount,
+		assessment.Decision,
+		pq.Array(triggeredRules),
+		assessment.CreatedAt,
+	).Scan(&assessment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return assessment, nil
+}
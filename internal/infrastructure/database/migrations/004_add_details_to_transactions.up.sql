@@ -0,0 +1,8 @@
+ALTER TABLE transactions
+    ADD COLUMN external_id VARCHAR(64),
+    ADD COLUMN description VARCHAR(255),
+    ADD COLUMN merchant_name VARCHAR(100),
+    ADD COLUMN merchant_category_code CHAR(4),
+    ADD COLUMN currency CHAR(3),
+    ADD COLUMN metadata JSONB,
+    ADD CONSTRAINT transactions_account_id_external_id_key UNIQUE (account_id, external_id);
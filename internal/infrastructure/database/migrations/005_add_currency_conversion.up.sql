@@ -0,0 +1,19 @@
+ALTER TABLE accounts ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
+
+ALTER TABLE transactions
+    ADD COLUMN original_amount DECIMAL(15,2),
+    ADD COLUMN settlement_currency CHAR(3),
+    ADD COLUMN fx_rate DECIMAL(18,8);
+
+UPDATE transactions t
+SET original_amount = t.amount,
+    settlement_currency = a.currency,
+    fx_rate = 1,
+    currency = COALESCE(t.currency, a.currency)
+FROM accounts a
+WHERE a.account_id = t.account_id;
+
+ALTER TABLE transactions
+    ALTER COLUMN original_amount SET NOT NULL,
+    ALTER COLUMN settlement_currency SET NOT NULL,
+    ALTER COLUMN fx_rate SET NOT NULL;
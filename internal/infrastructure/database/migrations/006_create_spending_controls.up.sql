@@ -0,0 +1,9 @@
+CREATE TABLE spending_controls (
+    account_id INTEGER PRIMARY KEY REFERENCES accounts(account_id),
+    blocked_mccs TEXT[] NOT NULL DEFAULT '{}',
+    max_transaction_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    allowed_operation_types INTEGER[] NOT NULL DEFAULT '{}',
+    time_windows JSONB NOT NULL DEFAULT '[]',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
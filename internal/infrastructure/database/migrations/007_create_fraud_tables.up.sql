@@ -0,0 +1,31 @@
+CREATE TABLE fraud_rules (
+    rule_id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    rule_type VARCHAR(30) NOT NULL CHECK (rule_type IN ('velocity_count', 'velocity_amount', 'spike')),
+    action VARCHAR(10) NOT NULL CHECK (action IN ('approve', 'review', 'decline')),
+    window_seconds INTEGER NOT NULL DEFAULT 0,
+    max_count INTEGER NOT NULL DEFAULT 0,
+    max_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    spike_multiplier DECIMAL(10,2) NOT NULL DEFAULT 0,
+    min_history INTEGER NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO fraud_rules (name, rule_type, action, window_seconds, max_count, max_amount, spike_multiplier, min_history) VALUES
+    ('debits_per_10_minutes', 'velocity_count', 'decline', 600, 10, 0, 0, 0),
+    ('amount_per_hour', 'velocity_amount', 'review', 3600, 0, 10000, 0, 0),
+    ('amount_spike', 'spike', 'review', 0, 0, 0, 10, 5);
+
+CREATE TABLE fraud_assessments (
+    assessment_id SERIAL PRIMARY KEY,
+    account_id INTEGER NOT NULL REFERENCES accounts(account_id),
+    transaction_id INTEGER REFERENCES transactions(transaction_id),
+    operation_type_id INTEGER NOT NULL REFERENCES operation_types(operation_type_id),
+    amount DECIMAL(15,2) NOT NULL,
+    decision VARCHAR(10) NOT NULL,
+    triggered_rules TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX fraud_assessments_account_id_idx ON fraud_assessments (account_id, created_at);
@@ -0,0 +1,15 @@
+CREATE TABLE cards (
+    card_id SERIAL PRIMARY KEY,
+    account_id INTEGER NOT NULL REFERENCES accounts(account_id),
+    masked_pan VARCHAR(19) NOT NULL,
+    last4 CHAR(4) NOT NULL,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    status VARCHAR(10) NOT NULL CHECK (status IN ('active', 'blocked')),
+    card_type VARCHAR(10) NOT NULL CHECK (card_type IN ('physical', 'virtual')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX cards_account_id_idx ON cards (account_id);
+
+ALTER TABLE transactions ADD COLUMN card_id INTEGER REFERENCES cards(card_id);
@@ -0,0 +1,32 @@
+INSERT INTO operation_types (operation_type_id, description) VALUES
+    (5, 'CREDIT VOUCHER'),
+    (6, 'DISPUTE REVERSAL');
+
+CREATE TABLE disputes (
+    dispute_id SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(transaction_id),
+    account_id INTEGER NOT NULL REFERENCES accounts(account_id),
+    amount DECIMAL(15,2) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    status VARCHAR(15) NOT NULL CHECK (status IN ('opened', 'under_review', 'won', 'lost')),
+    provisional_credit_transaction_id INTEGER REFERENCES transactions(transaction_id),
+    final_transaction_id INTEGER REFERENCES transactions(transaction_id),
+    opened_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP
+);
+
+-- A transaction can be disputed again once its previous dispute is resolved,
+-- but never have two unresolved disputes at the same time.
+CREATE UNIQUE INDEX disputes_open_transaction_id_key ON disputes (transaction_id)
+    WHERE status IN ('opened', 'under_review');
+
+CREATE TABLE dispute_events (
+    event_id SERIAL PRIMARY KEY,
+    dispute_id INTEGER NOT NULL REFERENCES disputes(dispute_id) ON DELETE CASCADE,
+    status VARCHAR(15) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX dispute_events_dispute_id_idx ON dispute_events (dispute_id, created_at);
@@ -0,0 +1,21 @@
+CREATE TABLE scheduled_transactions (
+    scheduled_transaction_id SERIAL PRIMARY KEY,
+    account_id INTEGER NOT NULL REFERENCES accounts(account_id),
+    operation_type_id INTEGER NOT NULL REFERENCES operation_types(operation_type_id),
+    amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    amount_type VARCHAR(15) NOT NULL CHECK (amount_type IN ('fixed', 'full_balance')),
+    recurrence VARCHAR(10) NOT NULL CHECK (recurrence IN ('once', 'monthly')),
+    day_of_month INTEGER NOT NULL DEFAULT 0 CHECK (day_of_month BETWEEN 0 AND 31),
+    starts_at TIMESTAMP NOT NULL,
+    description VARCHAR(255),
+    status VARCHAR(10) NOT NULL CHECK (status IN ('active', 'completed', 'cancelled')),
+    next_run_at TIMESTAMP NOT NULL,
+    last_run_at TIMESTAMP,
+    last_transaction_id INTEGER REFERENCES transactions(transaction_id),
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX scheduled_transactions_account_id_idx ON scheduled_transactions (account_id);
+CREATE INDEX scheduled_transactions_due_idx ON scheduled_transactions (next_run_at) WHERE status = 'active';
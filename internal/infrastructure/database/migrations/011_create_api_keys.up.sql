@@ -0,0 +1,11 @@
+CREATE TABLE api_keys (
+    api_key_id SERIAL PRIMARY KEY,
+    client_id VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(20) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX api_keys_client_id_idx ON api_keys (client_id);
@@ -0,0 +1,7 @@
+-- A transaction refunded by a won dispute cannot be disputed again, so a won
+-- dispute blocks new ones just like an unresolved one. Lost disputes still
+-- let the customer try again.
+DROP INDEX disputes_open_transaction_id_key;
+
+CREATE UNIQUE INDEX disputes_open_or_won_transaction_id_key ON disputes (transaction_id)
+    WHERE status IN ('opened', 'under_review', 'won');
@@ -0,0 +1,8 @@
+ALTER TABLE transactions
+    DROP CONSTRAINT transactions_account_id_external_id_key,
+    DROP COLUMN external_id,
+    DROP COLUMN description,
+    DROP COLUMN merchant_name,
+    DROP COLUMN merchant_category_code,
+    DROP COLUMN currency,
+    DROP COLUMN metadata;
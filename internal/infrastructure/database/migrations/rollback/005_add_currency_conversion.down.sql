@@ -0,0 +1,6 @@
+ALTER TABLE transactions
+    DROP COLUMN original_amount,
+    DROP COLUMN settlement_currency,
+    DROP COLUMN fx_rate;
+
+ALTER TABLE accounts DROP COLUMN currency;
@@ -0,0 +1,2 @@
+ALTER TABLE transactions DROP COLUMN card_id;
+DROP TABLE IF EXISTS cards;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS dispute_events;
+DROP TABLE IF EXISTS disputes;
+DELETE FROM operation_types WHERE operation_type_id IN (5, 6);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS disputes_open_or_won_transaction_id_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS disputes_open_transaction_id_key ON disputes (transaction_id)
+    WHERE status IN ('opened', 'under_review');
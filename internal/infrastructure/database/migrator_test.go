@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("pairs embedded migrations with their rollbacks", func(t *testing.T) {
+		migrations, err := loadMigrations(migrationFiles, "migrations")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, migrations)
+		for i, migration := range migrations {
+			assert.Equal(t, i+1, migration.Version)
+			assert.NotEmpty(t, migration.Up, migration.Name)
+			assert.NotEmpty(t, migration.Down, migration.Name)
+		}
+	})
+
+	t.Run("orders migrations by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/010_add_b.up.sql":            {Data: []byte("B")},
+			"migrations/002_add_a.up.sql":            {Data: []byte("A")},
+			"migrations/rollback/002_add_a.down.sql": {Data: []byte("-A")},
+		}
+
+		migrations, err := loadMigrations(fsys, "migrations")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Migration{
+			{Version: 2, Name: "add_a", Up: "A", Down: "-A"},
+			{Version: 10, Name: "add_b", Up: "B"},
+		}, migrations)
+	})
+
+	t.Run("returns error for duplicate versions", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/001_add_a.up.sql": {Data: []byte("A")},
+			"migrations/001_add_b.up.sql": {Data: []byte("B")},
+		}
+
+		_, err := loadMigrations(fsys, "migrations")
+
+		assert.ErrorContains(t, err, "duplicate migration version 1")
+	})
+
+	t.Run("returns error for rollback without migration", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/001_add_a.up.sql":            {Data: []byte("A")},
+			"migrations/rollback/001_add_b.down.sql": {Data: []byte("-B")},
+		}
+
+		_, err := loadMigrations(fsys, "migrations")
+
+		assert.ErrorContains(t, err, "has no matching migration")
+	})
+
+	t.Run("returns error for unexpected files", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/001_add_a.sql": {Data: []byte("A")},
+		}
+
+		_, err := loadMigrations(fsys, "migrations")
+
+		assert.ErrorContains(t, err, "unexpected migration file")
+	})
+}
+
+func TestMigrator_Pending(t *testing.T) {
+	migrations := []Migration{{Version: 1, Name: "a"}, {Version: 2, Name: "b"}}
+
+	t.Run("lists the migrations missing from schema_migrations", func(t *testing.T) {
+		// given
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+		mock.ExpectQuery("SELECT to_regclass").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery("SELECT version FROM schema_migrations").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+		migrator := &Migrator{db: db, migrations: migrations}
+
+		// when
+		pending, err := migrator.Pending(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, migrations[1:], pending)
+	})
+
+	t.Run("lists every migration before the first one", func(t *testing.T) {
+		// given
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+		mock.ExpectQuery("SELECT to_regclass").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		migrator := &Migrator{db: db, migrations: migrations}
+
+		// when
+		pending, err := migrator.Pending(context.Background())
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, migrations, pending)
+	})
+}
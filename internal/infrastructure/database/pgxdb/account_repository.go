@@ -0,0 +1,61 @@
+package pgxdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type AccountRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAccountRepository(pool *pgxpool.Pool) *AccountRepository {
+	return &AccountRepository{pool: pool}
+}
+
+func (r *AccountRepository) Create(ctx context.Context, account *domain.Account) (_ *domain.Account, err error) {
+	ctx, span := tracing.StartQuery(ctx, "AccountRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	query := `INSERT INTO accounts (document_number, currency) VALUES ($1, $2) RETURNING account_id`
+
+	var id int64
+	err = r.pool.QueryRow(ctx, query, account.DocumentNumber, account.Currency).Scan(&id)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &domain.Account{
+		ID:             id,
+		DocumentNumber: account.DocumentNumber,
+		Currency:       account.Currency,
+	}, nil
+}
+
+func (r *AccountRepository) FindByID(ctx context.Context, ID int64) (_ *domain.Account, err error) {
+	ctx, span := tracing.StartQuery(ctx, "AccountRepository.FindByID")
+	defer func() { tracing.End(span, err) }()
+
+	var account domain.Account
+
+	query := `SELECT account_id, document_number, currency FROM accounts WHERE account_id = $1`
+
+	err = r.pool.QueryRow(ctx, query, ID).Scan(
+		&account.ID,
+		&account.DocumentNumber,
+		&account.Currency,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	return &account, nil
+}
@@ -0,0 +1,37 @@
+package pgxdb
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+)
+
+// constraintErrors are the domain errors meant by violations of the schema's
+// constraints, named after the defaults Postgres gave them.
+var constraintErrors = map[string]error{
+	"accounts_document_number_key":            domain.ErrAccountAlreadyExists,
+	"transactions_account_id_fkey":            domain.ErrAccountNotFound,
+	"transactions_operation_type_id_fkey":     domain.ErrInvalidOperationType,
+	"transactions_card_id_fkey":               domain.ErrCardNotFound,
+	"transactions_account_id_external_id_key": domain.ErrTransactionAlreadyExists,
+}
+
+// mapError translates constraint violations into domain errors, and returns
+// any other error as is.
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != uniqueViolationCode && pgErr.Code != foreignKeyViolationCode {
+		return err
+	}
+
+	if domainErr, ok := constraintErrors[pgErr.ConstraintName]; ok {
+		return domainErr
+	}
+	return err
+}
@@ -0,0 +1,48 @@
+package pgxdb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+func TestMapError(t *testing.T) {
+	t.Run("maps constraint violations to domain errors", func(t *testing.T) {
+		// given
+		cases := []struct {
+			err      error
+			expected error
+		}{
+			{&pgconn.PgError{Code: uniqueViolationCode, ConstraintName: "accounts_document_number_key"}, domain.ErrAccountAlreadyExists},
+			{&pgconn.PgError{Code: foreignKeyViolationCode, ConstraintName: "transactions_account_id_fkey"}, domain.ErrAccountNotFound},
+			{&pgconn.PgError{Code: foreignKeyViolationCode, ConstraintName: "transactions_operation_type_id_fkey"}, domain.ErrInvalidOperationType},
+			{&pgconn.PgError{Code: foreignKeyViolationCode, ConstraintName: "transactions_card_id_fkey"}, domain.ErrCardNotFound},
+			{fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: uniqueViolationCode, ConstraintName: "transactions_account_id_external_id_key"}), domain.ErrTransactionAlreadyExists},
+		}
+
+		for _, c := range cases {
+			// when
+			mapped := mapError(c.err)
+
+			// then
+			assert.Equal(t, c.expected, mapped, c.err.Error())
+		}
+	})
+
+	t.Run("returns other errors as is", func(t *testing.T) {
+		// given
+		unknownConstraint := &pgconn.PgError{Code: uniqueViolationCode, ConstraintName: "cards_pkey"}
+		checkViolation := &pgconn.PgError{Code: "23514", ConstraintName: "accounts_document_number_key"}
+		other := errors.New("connection reset")
+
+		// then
+		assert.Equal(t, unknownConstraint, mapError(unknownConstraint))
+		assert.Equal(t, checkViolation, mapError(checkViolation))
+		assert.Equal(t, other, mapError(other))
+	})
+}
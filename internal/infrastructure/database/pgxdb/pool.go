@@ -0,0 +1,67 @@
+// Package pgxdb implements the account and transaction repositories on a
+// native pgx connection pool, which skips database/sql and keeps the
+// statements it prepares on each connection.
+package pgxdb
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/config"
+)
+
+func NewPool(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
+	poolConfig, err := newPoolConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+func newPoolConfig(cfg config.DatabaseConfig) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.URL)
+	if err != nil {
+		// pgx leaves the password out of its errors.
+		return nil, fmt.Errorf("failed to parse database url: %w", err)
+	}
+
+	poolConfig.MaxConns = int32(cfg.MaxOpenConns)
+	poolConfig.MinConns = int32(cfg.MinConns)
+	poolConfig.MaxConnLifetime = unlimited(cfg.ConnMaxLifetime)
+	poolConfig.MaxConnIdleTime = unlimited(cfg.ConnMaxIdleTime)
+	poolConfig.HealthCheckPeriod = cfg.HealthCheckPeriod
+
+	// Cached statements are prepared once per connection and then only
+	// bound and executed, saving a round trip on every query.
+	poolConfig.ConnConfig.StatementCacheCapacity = cfg.StatementCacheCapacity
+	poolConfig.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+	if cfg.StatementCacheCapacity == 0 {
+		poolConfig.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeExec
+	}
+
+	return poolConfig, nil
+}
+
+// unlimited reads a zero duration as no limit, like database/sql does, where
+// pgx would close connections right away.
+func unlimited(d time.Duration) time.Duration {
+	if d == 0 {
+		return math.MaxInt64
+	}
+	return d
+}
@@ -0,0 +1,206 @@
+package pgxdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+const transactionColumns = `
+	transaction_id, account_id, operation_type_id, amount, event_date, balance,
+	original_amount, settlement_currency, fx_rate, COALESCE(card_id, 0),
+	COALESCE(external_id, ''), COALESCE(description, ''), COALESCE(merchant_name, ''),
+	COALESCE(merchant_category_code, ''), COALESCE(currency, ''), metadata
+`
+
+type TransactionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewTransactionRepository(pool *pgxpool.Pool) *TransactionRepository {
+	return &TransactionRepository{pool: pool}
+}
+
+func (r *TransactionRepository) Create(ctx context.Context, transaction *domain.Transaction) (_ *domain.Transaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "TransactionRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		INSERT INTO transactions (
+			account_id, operation_type_id, amount, event_date, balance,
+			original_amount, settlement_currency, fx_rate, card_id,
+			external_id, description, merchant_name, merchant_category_code, currency, metadata
+		)
+		VALUES (
+			$1, $2, $3, $4, $5,
+			$6, $7, $8, NULLIF($9, 0),
+			NULLIF($10, ''), NULLIF($11, ''), NULLIF($12, ''), NULLIF($13, ''), NULLIF($14, ''), $15
+		)
+		RETURNING transaction_id
+	`
+
+	// pgx encodes metadata as JSON, and a nil map as NULL.
+	var id int64
+	err = r.pool.QueryRow(
+		ctx,
+		query,
+		transaction.AccountID,
+		transaction.OperationTypeID,
+		transaction.Amount,
+		transaction.EventDate,
+		transaction.Balance,
+		transaction.OriginalAmount,
+		transaction.SettlementCurrency,
+		transaction.FXRate,
+		transaction.CardID,
+		transaction.ExternalID,
+		transaction.Description,
+		transaction.MerchantName,
+		transaction.MerchantCategoryCode,
+		transaction.Currency,
+		transaction.Metadata,
+	).Scan(&id)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &domain.Transaction{
+		ID:                 id,
+		AccountID:          transaction.AccountID,
+		OperationTypeID:    transaction.OperationTypeID,
+		Amount:             transaction.Amount,
+		EventDate:          transaction.EventDate,
+		Balance:            transaction.Balance,
+		OriginalAmount:     transaction.OriginalAmount,
+		SettlementCurrency: transaction.SettlementCurrency,
+		FXRate:             transaction.FXRate,
+		TransactionDetails: transaction.TransactionDetails,
+	}, nil
+}
+
+func (r *TransactionRepository) ListByAccountID(ctx context.Context, accountID int64) (_ []*domain.Transaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "TransactionRepository.ListByAccountID")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE account_id = $1
+		ORDER BY event_date ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []*domain.Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	// pgx reports errors met while reading the rows here.
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// UpdateBalances sends every update in one batch, a single round trip. Outside
+// a transaction, Postgres runs the batch in an implicit one, so that a failed
+// update rolls back the others.
+func (r *TransactionRepository) UpdateBalances(ctx context.Context, transactions []*domain.Transaction) (err error) {
+	ctx, span := tracing.StartQuery(ctx, "TransactionRepository.UpdateBalances")
+	defer func() { tracing.End(span, err) }()
+
+	query := `UPDATE transactions SET balance = $1 WHERE transaction_id = $2`
+
+	batch := &pgx.Batch{}
+	for _, transaction := range transactions {
+		batch.Queue(query, transaction.Balance, transaction.ID)
+	}
+
+	return r.pool.SendBatch(ctx, batch).Close()
+}
+
+func (r *TransactionRepository) FindByExternalID(ctx context.Context, accountID int64, externalID string) (_ *domain.Transaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "TransactionRepository.FindByExternalID")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE account_id = $1 AND external_id = $2
+	`
+
+	transaction, err := scanTransaction(r.pool.QueryRow(ctx, query, accountID, externalID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTransactionNotFound
+		}
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+func (r *TransactionRepository) FindByID(ctx context.Context, ID int64) (_ *domain.Transaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "TransactionRepository.FindByID")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE transaction_id = $1
+	`
+
+	transaction, err := scanTransaction(r.pool.QueryRow(ctx, query, ID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTransactionNotFound
+		}
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// scanTransaction reads the metadata straight into its map, pgx decoding
+// JSONB and leaving the map nil for NULL.
+func scanTransaction(row pgx.Row) (*domain.Transaction, error) {
+	transaction := &domain.Transaction{}
+
+	err := row.Scan(
+		&transaction.ID,
+		&transaction.AccountID,
+		&transaction.OperationTypeID,
+		&transaction.Amount,
+		&transaction.EventDate,
+		&transaction.Balance,
+		&transaction.OriginalAmount,
+		&transaction.SettlementCurrency,
+		&transaction.FXRate,
+		&transaction.CardID,
+		&transaction.ExternalID,
+		&transaction.Description,
+		&transaction.MerchantName,
+		&transaction.MerchantCategoryCode,
+		&transaction.Currency,
+		&transaction.Metadata,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
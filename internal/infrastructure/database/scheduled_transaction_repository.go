@@ -0,0 +1,230 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+const scheduledTransactionColumns = `
+	scheduled_transaction_id, account_id, operation_type_id, amount, amount_type, recurrence,
+	day_of_month, starts_at, COALESCE(description, ''), status, next_run_at, last_run_at,
+	COALESCE(last_transaction_id, 0), COALESCE(last_error, ''), created_at, updated_at
+`
+
+type ScheduledTransactionRepository struct {
+	db *sql.DB
+}
+
+func NewScheduledTransactionRepository(db *sql.DB) *ScheduledTransactionRepository {
+	return &ScheduledTransactionRepository{db: db}
+}
+
+func (r *ScheduledTransactionRepository) Create(ctx context.Context, schedule *domain.ScheduledTransaction) (_ *domain.ScheduledTransaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "ScheduledTransactionRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		INSERT INTO scheduled_transactions (
+			account_id, operation_type_id, amount, amount_type, recurrence, day_of_month,
+			starts_at, description, status, next_run_at, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10, $11, $12)
+		RETURNING scheduled_transaction_id
+	`
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		schedule.AccountID,
+		schedule.OperationTypeID,
+		schedule.Amount,
+		schedule.AmountType,
+		schedule.Recurrence,
+		schedule.DayOfMonth,
+		schedule.StartsAt,
+		schedule.Description,
+		schedule.Status,
+		schedule.NextRunAt,
+		schedule.CreatedAt,
+		schedule.UpdatedAt,
+	).Scan(&schedule.ID)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == foreignKeyViolationCode {
+			if strings.Contains(pgErr.Constraint, "operation_type") {
+				return nil, domain.ErrInvalidOperationType
+			}
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (r *ScheduledTransactionRepository) FindByID(ctx context.Context, ID int64) (_ *domain.ScheduledTransaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "ScheduledTransactionRepository.FindByID")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT ` + scheduledTransactionColumns + `
+		FROM scheduled_transactions
+		WHERE scheduled_transaction_id = $1
+	`
+
+	schedule, err := scanScheduledTransaction(r.db.QueryRowContext(ctx, query, ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrScheduledTransactionNotFound
+		}
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (r *ScheduledTransactionRepository) ListByAccountID(ctx context.Context, accountID int64) (_ []*domain.ScheduledTransaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "ScheduledTransactionRepository.ListByAccountID")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT ` + scheduledTransactionColumns + `
+		FROM scheduled_transactions
+		WHERE account_id = $1
+		ORDER BY scheduled_transaction_id ASC
+	`
+
+	return r.list(ctx, query, accountID)
+}
+
+func (r *ScheduledTransactionRepository) ListDue(ctx context.Context, at time.Time, limit int) (_ []*domain.ScheduledTransaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "ScheduledTransactionRepository.ListDue")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT ` + scheduledTransactionColumns + `
+		FROM scheduled_transactions
+		WHERE status = 'active' AND next_run_at <= $1
+		ORDER BY next_run_at ASC, scheduled_transaction_id ASC
+		LIMIT $2
+	`
+
+	return r.list(ctx, query, at, limit)
+}
+
+func (r *ScheduledTransactionRepository) Update(ctx context.Context, schedule *domain.ScheduledTransaction) (_ *domain.ScheduledTransaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "ScheduledTransactionRepository.Update")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		UPDATE scheduled_transactions
+		SET operation_type_id = $1,
+			amount = $2,
+			amount_type = $3,
+			recurrence = $4,
+			day_of_month = $5,
+			starts_at = $6,
+			description = NULLIF($7, ''),
+			status = $8,
+			next_run_at = $9,
+			last_run_at = $10,
+			last_transaction_id = NULLIF($11, 0),
+			last_error = NULLIF($12, ''),
+			updated_at = $13
+		WHERE scheduled_transaction_id = $14
+		RETURNING scheduled_transaction_id
+	`
+
+	var lastRunAt sql.NullTime
+	if !schedule.LastRunAt.IsZero() {
+		lastRunAt = sql.NullTime{Time: schedule.LastRunAt, Valid: true}
+	}
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		schedule.OperationTypeID,
+		schedule.Amount,
+		schedule.AmountType,
+		schedule.Recurrence,
+		schedule.DayOfMonth,
+		schedule.StartsAt,
+		schedule.Description,
+		schedule.Status,
+		schedule.NextRunAt,
+		lastRunAt,
+		schedule.LastTransactionID,
+		schedule.LastError,
+		schedule.UpdatedAt,
+		schedule.ID,
+	).Scan(&schedule.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrScheduledTransactionNotFound
+		}
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == foreignKeyViolationCode {
+			return nil, domain.ErrInvalidOperationType
+		}
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (r *ScheduledTransactionRepository) list(ctx context.Context, query string, args ...any) (_ []*domain.ScheduledTransaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "ScheduledTransactionRepository.list")
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []*domain.ScheduledTransaction
+	for rows.Next() {
+		schedule, err := scanScheduledTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, rows.Err()
+}
+
+func scanScheduledTransaction(row rowScanner) (*domain.ScheduledTransaction, error) {
+	schedule := &domain.ScheduledTransaction{}
+
+	var lastRunAt sql.NullTime
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.AccountID,
+		&schedule.OperationTypeID,
+		&schedule.Amount,
+		&schedule.AmountType,
+		&schedule.Recurrence,
+		&schedule.DayOfMonth,
+		&schedule.StartsAt,
+		&schedule.Description,
+		&schedule.Status,
+		&schedule.NextRunAt,
+		&lastRunAt,
+		&schedule.LastTransactionID,
+		&schedule.LastError,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	schedule.LastRunAt = lastRunAt.Time
+
+	return schedule, nil
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type timeWindowRecord struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type SpendingControlsRepository struct {
+	db *sql.DB
+}
+
+func NewSpendingControlsRepository(db *sql.DB) *SpendingControlsRepository {
+	return &SpendingControlsRepository{db: db}
+}
+
+func (r *SpendingControlsRepository) FindByAccountID(ctx context.Context, accountID int64) (_ *domain.SpendingControls, err error) {
+	ctx, span := tracing.StartQuery(ctx, "SpendingControlsRepository.FindByAccountID")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT account_id, blocked_mccs, max_transaction_amount, allowed_operation_types, time_windows, timezone
+		FROM spending_controls
+		WHERE account_id = $1
+	`
+
+	controls := &domain.SpendingControls{}
+	var blockedMCCs []string
+	var operationTypes []int64
+	var timeWindows []byte
+
+	err = r.db.QueryRowContext(ctx, query, accountID).Scan(
+		&controls.AccountID,
+		pq.Array(&blockedMCCs),
+		&controls.MaxTransactionAmount,
+		pq.Array(&operationTypes),
+		&timeWindows,
+		&controls.Timezone,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSpendingControlsNotFound
+		}
+		return nil, err
+	}
+
+	controls.BlockedMCCs = blockedMCCs
+	for _, operationType := range operationTypes {
+		controls.AllowedOperationTypes = append(controls.AllowedOperationTypes, domain.OperationType(operationType))
+	}
+
+	var windows []timeWindowRecord
+	if err := json.Unmarshal(timeWindows, &windows); err != nil {
+		return nil, err
+	}
+	for _, window := range windows {
+		controls.TimeWindows = append(controls.TimeWindows, domain.TimeWindow{Start: window.Start, End: window.End})
+	}
+
+	return controls, nil
+}
+
+func (r *SpendingControlsRepository) Save(ctx context.Context, controls *domain.SpendingControls) (_ *domain.SpendingControls, err error) {
+	ctx, span := tracing.StartQuery(ctx, "SpendingControlsRepository.Save")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		INSERT INTO spending_controls (
+			account_id, blocked_mccs, max_transaction_amount, allowed_operation_types, time_windows, timezone, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (account_id) DO UPDATE SET
+			blocked_mccs = EXCLUDED.blocked_mccs,
+			max_transaction_amount = EXCLUDED.max_transaction_amount,
+			allowed_operation_types = EXCLUDED.allowed_operation_types,
+			time_windows = EXCLUDED.time_windows,
+			timezone = EXCLUDED.timezone,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	blockedMCCs := controls.BlockedMCCs
+	if blockedMCCs == nil {
+		blockedMCCs = []string{}
+	}
+
+	operationTypes := make([]int64, 0, len(controls.AllowedOperationTypes))
+	for _, operationType := range controls.AllowedOperationTypes {
+		operationTypes = append(operationTypes, int64(operationType))
+	}
+
+	windows := make([]timeWindowRecord, 0, len(controls.TimeWindows))
+	for _, window := range controls.TimeWindows {
+		windows = append(windows, timeWindowRecord{Start: window.Start, End: window.End})
+	}
+	timeWindows, err := json.Marshal(windows)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		controls.AccountID,
+		pq.Array(blockedMCCs),
+		controls.MaxTransactionAmount,
+		pq.Array(operationTypes),
+		string(timeWindows),
+		controls.Timezone,
+	)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == foreignKeyViolationCode {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	return controls, nil
+}
@@ -3,14 +3,28 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"strings"
 
 	"github.com/lib/pq"
 	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
 )
 
 const foreignKeyViolationCode = "23503"
 
+const transactionColumns = `
+	transaction_id, account_id, operation_type_id, amount, event_date, balance,
+	original_amount, settlement_currency, fx_rate, COALESCE(card_id, 0),
+	COALESCE(external_id, ''), COALESCE(description, ''), COALESCE(merchant_name, ''),
+	COALESCE(merchant_category_code, ''), COALESCE(currency, ''), metadata
+`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
 type TransactionRepository struct {
 	db *sql.DB
 }
@@ -19,15 +33,31 @@ func NewTransactionRepository(db *sql.DB) *TransactionRepository {
 	return &TransactionRepository{db: db}
 }
 
-func (r *TransactionRepository) Create(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
+func (r *TransactionRepository) Create(ctx context.Context, transaction *domain.Transaction) (_ *domain.Transaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "TransactionRepository.Create")
+	defer func() { tracing.End(span, err) }()
+
 	query := `
-		INSERT INTO transactions (account_id, operation_type_id, amount, event_date, balance) 
-		VALUES ($1, $2, $3, $4, $5) 
+		INSERT INTO transactions (
+			account_id, operation_type_id, amount, event_date, balance,
+			original_amount, settlement_currency, fx_rate, card_id,
+			external_id, description, merchant_name, merchant_category_code, currency, metadata
+		)
+		VALUES (
+			$1, $2, $3, $4, $5,
+			$6, $7, $8, NULLIF($9, 0),
+			NULLIF($10, ''), NULLIF($11, ''), NULLIF($12, ''), NULLIF($13, ''), NULLIF($14, ''), $15
+		)
 		RETURNING transaction_id
 	`
 
+	metadata, err := marshalMetadata(transaction.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
 	var id int64
-	err := r.db.QueryRowContext(
+	err = r.db.QueryRowContext(
 		ctx,
 		query,
 		transaction.AccountID,
@@ -35,6 +65,16 @@ func (r *TransactionRepository) Create(ctx context.Context, transaction *domain.
 		transaction.Amount,
 		transaction.EventDate,
 		transaction.Balance,
+		transaction.OriginalAmount,
+		transaction.SettlementCurrency,
+		transaction.FXRate,
+		transaction.CardID,
+		transaction.ExternalID,
+		transaction.Description,
+		transaction.MerchantName,
+		transaction.MerchantCategoryCode,
+		transaction.Currency,
+		metadata,
 	).Scan(&id)
 	if err != nil {
 		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == foreignKeyViolationCode {
@@ -44,29 +84,44 @@ func (r *TransactionRepository) Create(ctx context.Context, transaction *domain.
 			if strings.Contains(pgErr.Constraint, "operation_type") {
 				return nil, domain.ErrInvalidOperationType
 			}
+			if strings.Contains(pgErr.Constraint, "card") {
+				return nil, domain.ErrCardNotFound
+			}
+		}
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == uniqueViolationCode {
+			if strings.Contains(pgErr.Constraint, "external_id") {
+				return nil, domain.ErrTransactionAlreadyExists
+			}
 		}
 		return nil, err
 	}
 
 	return &domain.Transaction{
-		ID:              id,
-		AccountID:       transaction.AccountID,
-		OperationTypeID: transaction.OperationTypeID,
-		Amount:          transaction.Amount,
-		EventDate:       transaction.EventDate,
-		Balance:         transaction.Balance,
+		ID:                 id,
+		AccountID:          transaction.AccountID,
+		OperationTypeID:    transaction.OperationTypeID,
+		Amount:             transaction.Amount,
+		EventDate:          transaction.EventDate,
+		Balance:            transaction.Balance,
+		OriginalAmount:     transaction.OriginalAmount,
+		SettlementCurrency: transaction.SettlementCurrency,
+		FXRate:             transaction.FXRate,
+		TransactionDetails: transaction.TransactionDetails,
 	}, nil
 }
 
-func (r *TransactionRepository) ListByAccountID(ctx context.Context, accountID int64) ([]*domain.Transaction, error) {
+func (r *TransactionRepository) ListByAccountID(ctx context.Context, accountID int64) (_ []*domain.Transaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "TransactionRepository.ListByAccountID")
+	defer func() { tracing.End(span, err) }()
+
 	query := `
-		SELECT transaction_id, account_id, operation_type_id, amount, event_date, balance
-		FROM transactions 
+		SELECT ` + transactionColumns + `
+		FROM transactions
 		WHERE account_id = $1
 		ORDER BY event_date ASC
 	`
 
-	rows, err := r.db.Query(query, accountID)
+	rows, err := r.db.QueryContext(ctx, query, accountID)
 	if err != nil {
 		return nil, err
 	}
@@ -74,8 +129,7 @@ func (r *TransactionRepository) ListByAccountID(ctx context.Context, accountID i
 
 	var transactions []*domain.Transaction
 	for rows.Next() {
-		transaction := &domain.Transaction{}
-		err := rows.Scan(&transaction.ID, &transaction.AccountID, &transaction.OperationTypeID, &transaction.Amount, &transaction.EventDate, &transaction.Balance)
+		transaction, err := scanTransaction(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -85,18 +139,118 @@ func (r *TransactionRepository) ListByAccountID(ctx context.Context, accountID i
 	return transactions, nil
 }
 
-func (r *TransactionRepository) UpdateBalance(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
+// UpdateBalances updates every balance in a single statement, which makes it
+// atomic without a transaction.
+func (r *TransactionRepository) UpdateBalances(ctx context.Context, transactions []*domain.Transaction) (err error) {
+	ctx, span := tracing.StartQuery(ctx, "TransactionRepository.UpdateBalances")
+	defer func() { tracing.End(span, err) }()
+
+	ids := make([]int64, len(transactions))
+	balances := make([]float64, len(transactions))
+	for i, transaction := range transactions {
+		ids[i] = transaction.ID
+		balances[i] = transaction.Balance
+	}
+
 	query := `
-		UPDATE transactions 
-		SET balance = $1 
-		WHERE transaction_id = $2
-		RETURNING transaction_id
+		UPDATE transactions
+		SET balance = updated.balance
+		FROM unnest($1::bigint[], $2::numeric[]) AS updated(transaction_id, balance)
+		WHERE transactions.transaction_id = updated.transaction_id
+	`
+
+	_, err = r.db.ExecContext(ctx, query, pq.Array(ids), pq.Array(balances))
+	return err
+}
+
+func (r *TransactionRepository) FindByExternalID(ctx context.Context, accountID int64, externalID string) (_ *domain.Transaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "TransactionRepository.FindByExternalID")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE account_id = $1 AND external_id = $2
+	`
+
+	transaction, err := scanTransaction(r.db.QueryRowContext(ctx, query, accountID, externalID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTransactionNotFound
+		}
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+func (r *TransactionRepository) FindByID(ctx context.Context, ID int64) (_ *domain.Transaction, err error) {
+	ctx, span := tracing.StartQuery(ctx, "TransactionRepository.FindByID")
+	defer func() { tracing.End(span, err) }()
+
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE transaction_id = $1
 	`
 
-	err := r.db.QueryRowContext(ctx, query, transaction.Balance, transaction.ID).Scan(&transaction.ID)
+	transaction, err := scanTransaction(r.db.QueryRowContext(ctx, query, ID))
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTransactionNotFound
+		}
 		return nil, err
 	}
 
 	return transaction, nil
 }
+
+func scanTransaction(row rowScanner) (*domain.Transaction, error) {
+	transaction := &domain.Transaction{}
+
+	var metadata []byte
+	err := row.Scan(
+		&transaction.ID,
+		&transaction.AccountID,
+		&transaction.OperationTypeID,
+		&transaction.Amount,
+		&transaction.EventDate,
+		&transaction.Balance,
+		&transaction.OriginalAmount,
+		&transaction.SettlementCurrency,
+		&transaction.FXRate,
+		&transaction.CardID,
+		&transaction.ExternalID,
+		&transaction.Description,
+		&transaction.MerchantName,
+		&transaction.MerchantCategoryCode,
+		&transaction.Currency,
+		&metadata,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata != nil {
+		if err := json.Unmarshal(metadata, &transaction.Metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	return transaction, nil
+}
+
+// marshalMetadata encodes metadata as a JSON string, lib/pq sends []byte
+// values as bytea which Postgres rejects for JSONB columns.
+func marshalMetadata(metadata map[string]any) (sql.NullString, error) {
+	if metadata == nil {
+		return sql.NullString{}, nil
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(encoded), Valid: true}, nil
+}
@@ -0,0 +1,64 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+// StaticRateProvider serves rates from a fixed table keyed by "FROM/TO". The
+// inverse of a quoted pair is derived when only one direction is present.
+type StaticRateProvider struct {
+	rates map[string]float64
+}
+
+func NewStaticRateProvider(rates map[string]float64) *StaticRateProvider {
+	return &StaticRateProvider{rates: rates}
+}
+
+func (p *StaticRateProvider) Rate(ctx context.Context, from, to string, at time.Time) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	if rate, ok := p.rates[from+"/"+to]; ok {
+		return rate, nil
+	}
+
+	if rate, ok := p.rates[to+"/"+from]; ok && rate != 0 {
+		return 1 / rate, nil
+	}
+
+	return 0, domain.ErrFXRateUnavailable
+}
+
+// ParseRates parses a comma separated list of "FROM/TO=rate" entries, such as
+// "USD/BRL=5.42,EUR/BRL=5.87".
+func ParseRates(spec string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		pair, value, ok := strings.Cut(entry, "=")
+		if !ok || len(pair) != 7 || pair[3] != '/' {
+			return nil, fmt.Errorf("invalid fx rate entry %q", entry)
+		}
+
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid fx rate for %s: %q", pair, value)
+		}
+
+		rates[strings.ToUpper(pair)] = rate
+	}
+
+	return rates, nil
+}
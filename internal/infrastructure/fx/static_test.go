@@ -0,0 +1,65 @@
+package fx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticRateProvider_Rate(t *testing.T) {
+	provider := NewStaticRateProvider(map[string]float64{"USD/BRL": 5.0})
+
+	t.Run("returns one for the same currency", func(t *testing.T) {
+		rate, err := provider.Rate(context.Background(), "EUR", "EUR", time.Now())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, rate)
+	})
+
+	t.Run("returns quoted rate", func(t *testing.T) {
+		rate, err := provider.Rate(context.Background(), "USD", "BRL", time.Now())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5.0, rate)
+	})
+
+	t.Run("derives inverse rate", func(t *testing.T) {
+		rate, err := provider.Rate(context.Background(), "BRL", "USD", time.Now())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.2, rate)
+	})
+
+	t.Run("returns error when pair is not quoted", func(t *testing.T) {
+		_, err := provider.Rate(context.Background(), "EUR", "BRL", time.Now())
+
+		assert.ErrorIs(t, err, domain.ErrFXRateUnavailable)
+	})
+}
+
+func TestParseRates(t *testing.T) {
+	t.Run("parses rate entries", func(t *testing.T) {
+		rates, err := ParseRates("USD/BRL=5.42, EUR/BRL=5.87")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]float64{"USD/BRL": 5.42, "EUR/BRL": 5.87}, rates)
+	})
+
+	t.Run("returns empty table for empty spec", func(t *testing.T) {
+		rates, err := ParseRates("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, rates)
+	})
+
+	t.Run("returns error for malformed entries", func(t *testing.T) {
+		for _, spec := range []string{"USDBRL=5", "USD/BRL", "USD/BRL=abc", "USD/BRL=-1"} {
+			_, err := ParseRates(spec)
+
+			assert.Error(t, err)
+		}
+	})
+}
@@ -0,0 +1,180 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/metrics"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+// RequestIDKey is the metadata key carrying the request ID, the gRPC
+// counterpart of the X-Request-ID header.
+const RequestIDKey = "x-request-id"
+
+var kindToCode = map[domain.ErrorKind]codes.Code{
+	domain.KindValidation:  codes.InvalidArgument,
+	domain.KindNotFound:    codes.NotFound,
+	domain.KindConflict:    codes.AlreadyExists,
+	domain.KindForbidden:   codes.PermissionDenied,
+	domain.KindUnavailable: codes.Unavailable,
+}
+
+// errorDomain is the ErrorInfo domain of the codes attached to domain errors.
+const errorDomain = "pismo"
+
+// authenticator resolves the credentials sent in the x-api-key or
+// authorization metadata, as the HTTP API does for its headers.
+type authenticator interface {
+	Resolve(ctx context.Context, apiKey, authorization string) (*domain.Principal, error)
+}
+
+// accountRequest is implemented by the requests addressed by account.
+type accountRequest interface {
+	GetAccountId() int64
+}
+
+// RequestID takes the request ID from the incoming metadata, or generates one,
+// stores it in the context for the logger and sends it back in the header.
+func RequestID(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestID := first(md, RequestIDKey)
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+
+	ctx = logger.WithRequestID(ctx, requestID)
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, requestID)); err != nil {
+		logger.Error(ctx, "failed to set request id header", slog.String("error", err.Error()))
+	}
+
+	return handler(ctx, req)
+}
+
+// Logger logs information about each call.
+func Logger(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	logger.Info(ctx, "request completed",
+		slog.String("method", info.FullMethod),
+		slog.String("code", status.Code(err).String()),
+		slog.Duration("duration", time.Since(start)),
+	)
+
+	return resp, err
+}
+
+// Recoverer recovers from panics and returns an Internal status.
+func Recoverer(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(ctx, "panic recovered",
+				slog.Any("error", r),
+				slog.String("stack", string(debug.Stack())),
+			)
+			err = status.Error(codes.Internal, "internal server error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// Errors converts domain errors into statuses according to their kind and
+// hides any other error behind an Internal status.
+func Errors(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+
+	return nil, toStatus(err)
+}
+
+// Auth authenticates calls with the keys and tokens of the HTTP API and only
+// lets through principals holding the scope scopes maps the method to.
+// Methods missing from scopes are refused. Principals restricted to an
+// account can only address their own, and are left in the context for use
+// cases to check the accounts they reach. An authenticator resolving no
+// principal disables authentication.
+func Auth(authenticator authenticator, scopes map[string]domain.Scope) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		principal, err := authenticator.Resolve(ctx, first(md, "x-api-key"), first(md, "authorization"))
+		var authErr *domain.Error
+		if errors.As(err, &authErr) && (authErr == domain.ErrInvalidAPIKey || authErr == domain.ErrInvalidToken) {
+			return nil, withReason(status.New(codes.Unauthenticated, authErr.Message), authErr.Code).Err()
+		}
+		if err != nil {
+			logger.Error(ctx, "failed to authenticate call", slog.String("error", err.Error()))
+			return nil, err
+		}
+		if principal == nil {
+			return handler(ctx, req)
+		}
+
+		scope, ok := scopes[info.FullMethod]
+		if !ok {
+			return nil, withReason(status.New(codes.PermissionDenied, "method requires no known scope"), "insufficient_scope").Err()
+		}
+		if !principal.HasScope(scope) {
+			return nil, withReason(status.New(codes.PermissionDenied, "missing required scope "+string(scope)), "insufficient_scope").Err()
+		}
+
+		ctx = domain.ContextWithPrincipal(ctx, principal)
+		ctx = logger.WithClientID(ctx, principal.ClientID)
+		if req, ok := req.(accountRequest); ok {
+			if err := domain.AuthorizeAccount(ctx, req.GetAccountId()); err != nil {
+				return nil, err
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		metrics.DomainErrors.WithLabelValues(domainErr.Kind.String()).Inc()
+		code, ok := kindToCode[domainErr.Kind]
+		if !ok {
+			code = codes.Internal
+		}
+		return withReason(status.New(code, domainErr.Message), domainErr.Code).Err()
+	}
+
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// withReason attaches the stable error code as ErrorInfo, the same code HTTP
+// clients get in problem responses.
+func withReason(st *status.Status, reason string) *status.Status {
+	if withInfo, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}); err == nil {
+		return withInfo
+	}
+	return st
+}
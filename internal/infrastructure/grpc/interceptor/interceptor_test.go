@@ -0,0 +1,191 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/grpc/pismov1"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+var info = &grpc.UnaryServerInfo{FullMethod: "/pismo.v1.PismoService/GetAccount"}
+
+func TestRequestID(t *testing.T) {
+	var requestID any
+	handler := func(ctx context.Context, req any) (any, error) {
+		requestID = ctx.Value(logger.RequestIDKey)
+		return nil, nil
+	}
+
+	t.Run("generates request ID when not provided", func(t *testing.T) {
+		_, err := RequestID(context.Background(), nil, info, handler)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, requestID)
+	})
+
+	t.Run("uses existing request ID from metadata", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "existing-id-123"))
+
+		_, err := RequestID(ctx, nil, info, handler)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "existing-id-123", requestID)
+	})
+}
+
+func TestRecoverer(t *testing.T) {
+	handler := func(ctx context.Context, req any) (any, error) {
+		panic("test panic")
+	}
+
+	_, err := Recoverer(context.Background(), nil, info, handler)
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestErrors(t *testing.T) {
+	call := func(err error) error {
+		_, err = Errors(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return nil, err
+		})
+		return err
+	}
+
+	t.Run("maps domain error kinds to codes", func(t *testing.T) {
+		err := call(domain.ErrAccountNotFound)
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, domain.ErrAccountNotFound.Message, status.Convert(err).Message())
+		assert.Equal(t, codes.InvalidArgument, status.Code(call(domain.ErrInvalidAmount)))
+		assert.Equal(t, codes.AlreadyExists, status.Code(call(domain.ErrAccountAlreadyExists)))
+		assert.Equal(t, codes.PermissionDenied, status.Code(call(domain.ErrAccountAccessDenied)))
+		assert.Equal(t, codes.Unavailable, status.Code(call(domain.ErrServiceUnavailable)))
+	})
+
+	t.Run("attaches the error code as error info", func(t *testing.T) {
+		err := call(domain.ErrAccountNotFound)
+
+		details := status.Convert(err).Details()
+		require.Len(t, details, 1)
+		errorInfo, ok := details[0].(*errdetails.ErrorInfo)
+		require.True(t, ok)
+		assert.Equal(t, "account_not_found", errorInfo.Reason)
+		assert.Equal(t, "pismo", errorInfo.Domain)
+	})
+
+	t.Run("keeps statuses returned by the service", func(t *testing.T) {
+		err := call(status.Error(codes.InvalidArgument, "account_id is required"))
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "account_id is required", status.Convert(err).Message())
+	})
+
+	t.Run("hides other errors", func(t *testing.T) {
+		err := call(errors.New("connection refused"))
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, "internal server error", status.Convert(err).Message())
+	})
+
+	t.Run("passes successful responses through", func(t *testing.T) {
+		resp, err := Errors(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return "ok", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+	})
+}
+
+type authenticatorFunc func(ctx context.Context, apiKey, authorization string) (*domain.Principal, error)
+
+func (f authenticatorFunc) Resolve(ctx context.Context, apiKey, authorization string) (*domain.Principal, error) {
+	return f(ctx, apiKey, authorization)
+}
+
+func TestAuth(t *testing.T) {
+	principals := map[string]*domain.Principal{
+		"Bearer reader":   {ClientID: "reports", Scopes: []domain.Scope{domain.ScopeAccountsRead}},
+		"Bearer customer": {ClientID: "mobile", Scopes: []domain.Scope{domain.ScopeAccountsRead}, AccountID: 1},
+	}
+	auth := Auth(authenticatorFunc(func(ctx context.Context, apiKey, authorization string) (*domain.Principal, error) {
+		if principal, ok := principals[authorization]; ok {
+			return principal, nil
+		}
+		if authorization == "Bearer broken" {
+			return nil, domain.ErrServiceUnavailable
+		}
+		return nil, domain.ErrInvalidAPIKey
+	}), map[string]domain.Scope{info.FullMethod: domain.ScopeAccountsRead})
+
+	var principal *domain.Principal
+	handler := func(ctx context.Context, req any) (any, error) {
+		principal, _ = domain.PrincipalFromContext(ctx)
+		return "ok", nil
+	}
+	call := func(authorization string, req any, info *grpc.UnaryServerInfo) (any, error) {
+		principal = nil
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", authorization))
+		return auth(ctx, req, info, handler)
+	}
+
+	t.Run("lets through principals with the required scope", func(t *testing.T) {
+		resp, err := call("Bearer reader", &pismov1.GetAccountRequest{AccountId: 2}, info)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+		assert.Equal(t, "reports", principal.ClientID)
+	})
+
+	t.Run("rejects calls without valid credentials", func(t *testing.T) {
+		_, err := call("Bearer unknown", &pismov1.GetAccountRequest{AccountId: 1}, info)
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		details := status.Convert(err).Details()
+		require.Len(t, details, 1)
+		assert.Equal(t, "invalid_api_key", details[0].(*errdetails.ErrorInfo).Reason)
+	})
+
+	t.Run("rejects principals without the required scope", func(t *testing.T) {
+		_, err := call("Bearer reader", &pismov1.CreateTransactionRequest{AccountId: 1}, &grpc.UnaryServerInfo{FullMethod: "/pismo.v1.PismoService/CreateTransaction"})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Nil(t, principal)
+	})
+
+	t.Run("restricts principals to their own account", func(t *testing.T) {
+		_, own := call("Bearer customer", &pismov1.GetAccountRequest{AccountId: 1}, info)
+		_, other := call("Bearer customer", &pismov1.GetAccountRequest{AccountId: 2}, info)
+
+		assert.NoError(t, own)
+		assert.ErrorIs(t, other, domain.ErrAccountAccessDenied)
+	})
+
+	t.Run("returns errors resolving credentials", func(t *testing.T) {
+		_, err := call("Bearer broken", &pismov1.GetAccountRequest{AccountId: 1}, info)
+
+		assert.ErrorIs(t, err, domain.ErrServiceUnavailable)
+	})
+
+	t.Run("lets every call through when no principal is resolved", func(t *testing.T) {
+		disabled := Auth(authenticatorFunc(func(ctx context.Context, apiKey, authorization string) (*domain.Principal, error) {
+			return nil, nil
+		}), nil)
+
+		resp, err := disabled(context.Background(), &pismov1.GetAccountRequest{AccountId: 1}, info, handler)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+	})
+}
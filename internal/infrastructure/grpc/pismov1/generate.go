@@ -0,0 +1,4 @@
+// Package pismov1 holds the code generated from proto/pismo/v1/pismo.proto.
+package pismov1
+
+//go:generate protoc -I ../../../../proto --go_out=../../../.. --go_opt=module=github.com/nubank/pismo-code-assessment --go-grpc_out=../../../.. --go-grpc_opt=module=github.com/nubank/pismo-code-assessment pismo/v1/pismo.proto
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/grpc/interceptor"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/grpc/pismov1"
+)
+
+// Authenticator resolves the credentials of a call into its principal, like
+// the HTTP API's middleware.Auth.
+type Authenticator interface {
+	Resolve(ctx context.Context, apiKey, authorization string) (*domain.Principal, error)
+}
+
+// scopes are the scopes each method requires, matching the HTTP routes.
+var scopes = map[string]domain.Scope{
+	pismov1.PismoService_CreateAccount_FullMethodName:     domain.ScopeAccountsWrite,
+	pismov1.PismoService_GetAccount_FullMethodName:        domain.ScopeAccountsRead,
+	pismov1.PismoService_CreateTransaction_FullMethodName: domain.ScopeTransactionsWrite,
+	pismov1.PismoService_ListTransactions_FullMethodName:  domain.ScopeAccountsRead,
+}
+
+type Server struct {
+	addr       string
+	grpcServer *grpc.Server
+}
+
+// New serves service on port. A nil authenticator disables authentication.
+func New(port string, service pismov1.PismoServiceServer, authenticator Authenticator) *Server {
+	interceptors := []grpc.UnaryServerInterceptor{
+		interceptor.RequestID,
+		interceptor.Logger,
+		interceptor.Recoverer,
+		interceptor.Errors,
+	}
+	if authenticator != nil {
+		interceptors = append(interceptors, interceptor.Auth(authenticator, scopes))
+	}
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+	pismov1.RegisterPismoServiceServer(grpcServer, service)
+	// Reflection is a streaming service the interceptors do not guard, so it
+	// is only offered when calls need no credentials.
+	if authenticator == nil {
+		reflection.Register(grpcServer)
+	}
+
+	return &Server{
+		addr:       ":" + port,
+		grpcServer: grpcServer,
+	}
+}
+
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(listener)
+}
+
+// Serve accepts connections on listener until the server is shut down.
+func (s *Server) Serve(listener net.Listener) error {
+	log.Printf("gRPC server starting on %s", listener.Addr())
+	return s.grpcServer.Serve(listener)
+}
+
+// Shutdown stops accepting calls and waits for the running ones to finish,
+// cancelling them when ctx is done first.
+func (s *Server) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		return ctx.Err()
+	}
+}
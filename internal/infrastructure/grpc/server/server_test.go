@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/grpc/interceptor"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/grpc/pismov1"
+)
+
+type stubService struct {
+	pismov1.UnimplementedPismoServiceServer
+}
+
+func (stubService) GetAccount(ctx context.Context, req *pismov1.GetAccountRequest) (*pismov1.Account, error) {
+	if req.GetAccountId() != 1 {
+		return nil, domain.ErrAccountNotFound
+	}
+	return &pismov1.Account{AccountId: 1, DocumentNumber: "12345678900", Currency: "BRL"}, nil
+}
+
+func TestServer(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	srv := New("0", stubService{}, nil)
+	go srv.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := pismov1.NewPismoServiceClient(conn)
+
+	t.Run("serves calls and echoes the request ID", func(t *testing.T) {
+		// given
+		ctx := metadata.AppendToOutgoingContext(context.Background(), interceptor.RequestIDKey, "request-123")
+		var header metadata.MD
+
+		// when
+		account, err := client.GetAccount(ctx, &pismov1.GetAccountRequest{AccountId: 1}, grpc.Header(&header))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), account.GetAccountId())
+		assert.Equal(t, []string{"request-123"}, header.Get(interceptor.RequestIDKey))
+	})
+
+	t.Run("maps domain errors to status codes", func(t *testing.T) {
+		// when
+		_, err := client.GetAccount(context.Background(), &pismov1.GetAccountRequest{AccountId: 999})
+
+		// then
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, "account was not found", status.Convert(err).Message())
+	})
+
+	t.Run("shuts down gracefully", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		assert.NoError(t, srv.Shutdown(ctx))
+	})
+}
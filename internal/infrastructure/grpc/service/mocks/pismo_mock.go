@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pismo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pismo.go -destination=mocks/pismo_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/nubank/pismo-code-assessment/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockaccountCreator is a mock of accountCreator interface.
+type MockaccountCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccountCreatorMockRecorder
+	isgomock struct{}
+}
+
+// MockaccountCreatorMockRecorder is the mock recorder for MockaccountCreator.
+type MockaccountCreatorMockRecorder struct {
+	mock *MockaccountCreator
+}
+
+// NewMockaccountCreator creates a new mock instance.
+func NewMockaccountCreator(ctrl *gomock.Controller) *MockaccountCreator {
+	mock := &MockaccountCreator{ctrl: ctrl}
+	mock.recorder = &MockaccountCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccountCreator) EXPECT() *MockaccountCreatorMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockaccountCreator) Execute(ctx context.Context, documentNumber, currency string) (*domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, documentNumber, currency)
+	ret0, _ := ret[0].(*domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockaccountCreatorMockRecorder) Execute(ctx, documentNumber, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockaccountCreator)(nil).Execute), ctx, documentNumber, currency)
+}
+
+// MockaccountGetter is a mock of accountGetter interface.
+type MockaccountGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccountGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockaccountGetterMockRecorder is the mock recorder for MockaccountGetter.
+type MockaccountGetterMockRecorder struct {
+	mock *MockaccountGetter
+}
+
+// NewMockaccountGetter creates a new mock instance.
+func NewMockaccountGetter(ctrl *gomock.Controller) *MockaccountGetter {
+	mock := &MockaccountGetter{ctrl: ctrl}
+	mock.recorder = &MockaccountGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccountGetter) EXPECT() *MockaccountGetterMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockaccountGetter) Execute(ctx context.Context, accountID int64) (*domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, accountID)
+	ret0, _ := ret[0].(*domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockaccountGetterMockRecorder) Execute(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockaccountGetter)(nil).Execute), ctx, accountID)
+}
+
+// MocktransactionCreator is a mock of transactionCreator interface.
+type MocktransactionCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionCreatorMockRecorder
+	isgomock struct{}
+}
+
+// MocktransactionCreatorMockRecorder is the mock recorder for MocktransactionCreator.
+type MocktransactionCreatorMockRecorder struct {
+	mock *MocktransactionCreator
+}
+
+// NewMocktransactionCreator creates a new mock instance.
+func NewMocktransactionCreator(ctrl *gomock.Controller) *MocktransactionCreator {
+	mock := &MocktransactionCreator{ctrl: ctrl}
+	mock.recorder = &MocktransactionCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionCreator) EXPECT() *MocktransactionCreatorMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MocktransactionCreator) Execute(ctx context.Context, accountID int64, operationTypeID int, amount float64, details domain.TransactionDetails) (*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, accountID, operationTypeID, amount, details)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MocktransactionCreatorMockRecorder) Execute(ctx, accountID, operationTypeID, amount, details any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MocktransactionCreator)(nil).Execute), ctx, accountID, operationTypeID, amount, details)
+}
+
+// MocktransactionLister is a mock of transactionLister interface.
+type MocktransactionLister struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionListerMockRecorder
+	isgomock struct{}
+}
+
+// MocktransactionListerMockRecorder is the mock recorder for MocktransactionLister.
+type MocktransactionListerMockRecorder struct {
+	mock *MocktransactionLister
+}
+
+// NewMocktransactionLister creates a new mock instance.
+func NewMocktransactionLister(ctrl *gomock.Controller) *MocktransactionLister {
+	mock := &MocktransactionLister{ctrl: ctrl}
+	mock.recorder = &MocktransactionListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionLister) EXPECT() *MocktransactionListerMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MocktransactionLister) Execute(ctx context.Context, accountID int64) ([]*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, accountID)
+	ret0, _ := ret[0].([]*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MocktransactionListerMockRecorder) Execute(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MocktransactionLister)(nil).Execute), ctx, accountID)
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/grpc/pismov1"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+//go:generate mockgen -source=pismo.go -destination=mocks/pismo_mock.go -package=mocks
+type accountCreator interface {
+	Execute(ctx context.Context, documentNumber string, currency string) (*domain.Account, error)
+}
+
+type accountGetter interface {
+	Execute(ctx context.Context, accountID int64) (*domain.Account, error)
+}
+
+type transactionCreator interface {
+	Execute(ctx context.Context, accountID int64, operationTypeID int, amount float64, details domain.TransactionDetails) (*domain.Transaction, error)
+}
+
+type transactionLister interface {
+	Execute(ctx context.Context, accountID int64) ([]*domain.Transaction, error)
+}
+
+// PismoService implements the gRPC API. Domain errors are returned as they
+// are and turned into status codes by interceptor.Errors.
+type PismoService struct {
+	pismov1.UnimplementedPismoServiceServer
+
+	createAccount     accountCreator
+	getAccount        accountGetter
+	createTransaction transactionCreator
+	listTransactions  transactionLister
+}
+
+func NewPismoService(
+	createAccount accountCreator,
+	getAccount accountGetter,
+	createTransaction transactionCreator,
+	listTransactions transactionLister,
+) *PismoService {
+	return &PismoService{
+		createAccount:     createAccount,
+		getAccount:        getAccount,
+		createTransaction: createTransaction,
+		listTransactions:  listTransactions,
+	}
+}
+
+func (s *PismoService) CreateAccount(ctx context.Context, req *pismov1.CreateAccountRequest) (*pismov1.Account, error) {
+	if req.GetDocumentNumber() == "" {
+		return nil, status.Error(codes.InvalidArgument, "document_number is required")
+	}
+
+	account, err := s.createAccount.Execute(ctx, req.GetDocumentNumber(), req.GetCurrency())
+	if err != nil {
+		logger.Error(ctx, "failed to create account",
+			slog.Any("document_number", logger.Document(req.GetDocumentNumber())),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return toAccount(account), nil
+}
+
+func (s *PismoService) GetAccount(ctx context.Context, req *pismov1.GetAccountRequest) (*pismov1.Account, error) {
+	if req.GetAccountId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+
+	account, err := s.getAccount.Execute(ctx, req.GetAccountId())
+	if err != nil {
+		logger.Error(ctx, "failed to get account",
+			slog.Int64("account_id", req.GetAccountId()),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return toAccount(account), nil
+}
+
+func (s *PismoService) CreateTransaction(ctx context.Context, req *pismov1.CreateTransactionRequest) (*pismov1.Transaction, error) {
+	if req.GetAccountId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+	if req.GetOperationTypeId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "operation_type_id is required")
+	}
+	if req.GetAmount() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount is required")
+	}
+	if domain.OperationType(req.GetOperationTypeId()).IsAdjustment() {
+		return nil, domain.ErrInvalidOperationType
+	}
+
+	details := domain.TransactionDetails{
+		CardID:               req.GetCardId(),
+		ExternalID:           req.GetExternalId(),
+		Description:          req.GetDescription(),
+		MerchantName:         req.GetMerchantName(),
+		MerchantCategoryCode: req.GetMerchantCategoryCode(),
+		Currency:             req.GetCurrency(),
+	}
+	if req.GetMetadata() != nil {
+		details.Metadata = req.GetMetadata().AsMap()
+	}
+
+	transaction, err := s.createTransaction.Execute(ctx, req.GetAccountId(), int(req.GetOperationTypeId()), req.GetAmount(), details)
+	if err != nil {
+		logger.Error(ctx, "failed to create transaction",
+			slog.Int64("account_id", req.GetAccountId()),
+			slog.Int("operation_type_id", int(req.GetOperationTypeId())),
+			slog.Float64("amount", req.GetAmount()),
+			slog.String("external_id", req.GetExternalId()),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return toTransaction(transaction)
+}
+
+func (s *PismoService) ListTransactions(ctx context.Context, req *pismov1.ListTransactionsRequest) (*pismov1.ListTransactionsResponse, error) {
+	if req.GetAccountId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+
+	transactions, err := s.listTransactions.Execute(ctx, req.GetAccountId())
+	if err != nil {
+		logger.Error(ctx, "failed to list transactions",
+			slog.Int64("account_id", req.GetAccountId()),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	resp := &pismov1.ListTransactionsResponse{
+		Transactions: make([]*pismov1.Transaction, 0, len(transactions)),
+	}
+	for _, transaction := range transactions {
+		converted, err := toTransaction(transaction)
+		if err != nil {
+			return nil, err
+		}
+		resp.Transactions = append(resp.Transactions, converted)
+	}
+
+	return resp, nil
+}
+
+func toAccount(account *domain.Account) *pismov1.Account {
+	return &pismov1.Account{
+		AccountId:      account.ID,
+		DocumentNumber: account.DocumentNumber,
+		Currency:       account.Currency,
+	}
+}
+
+func toTransaction(transaction *domain.Transaction) (*pismov1.Transaction, error) {
+	var metadata *structpb.Struct
+	if transaction.Metadata != nil {
+		var err error
+		metadata, err = structpb.NewStruct(transaction.Metadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &pismov1.Transaction{
+		TransactionId:        transaction.ID,
+		AccountId:            transaction.AccountID,
+		OperationTypeId:      int32(transaction.OperationTypeID),
+		Amount:               transaction.Amount,
+		Balance:              transaction.Balance,
+		OriginalAmount:       transaction.OriginalAmount,
+		SettlementCurrency:   transaction.SettlementCurrency,
+		FxRate:               transaction.FXRate,
+		CardId:               transaction.CardID,
+		ExternalId:           transaction.ExternalID,
+		Description:          transaction.Description,
+		MerchantName:         transaction.MerchantName,
+		MerchantCategoryCode: transaction.MerchantCategoryCode,
+		Currency:             transaction.Currency,
+		Metadata:             metadata,
+		EventDate:            timestamppb.New(transaction.EventDate),
+	}, nil
+}
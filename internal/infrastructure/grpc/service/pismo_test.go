@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/grpc/pismov1"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/grpc/service/mocks"
+)
+
+func newTestService(ctrl *gomock.Controller) (*PismoService, *mocks.MockaccountCreator, *mocks.MockaccountGetter, *mocks.MocktransactionCreator, *mocks.MocktransactionLister) {
+	mockAccountCreator := mocks.NewMockaccountCreator(ctrl)
+	mockAccountGetter := mocks.NewMockaccountGetter(ctrl)
+	mockTransactionCreator := mocks.NewMocktransactionCreator(ctrl)
+	mockTransactionLister := mocks.NewMocktransactionLister(ctrl)
+	service := NewPismoService(mockAccountCreator, mockAccountGetter, mockTransactionCreator, mockTransactionLister)
+
+	return service, mockAccountCreator, mockAccountGetter, mockTransactionCreator, mockTransactionLister
+}
+
+func TestPismoService_CreateAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mockCreator, _, _, _ := newTestService(ctrl)
+
+	t.Run("creates account successfully", func(t *testing.T) {
+		mockCreator.EXPECT().
+			Execute(gomock.Any(), "12345678900", "USD").
+			Return(&domain.Account{ID: 1, DocumentNumber: "12345678900", Currency: "USD"}, nil)
+
+		account, err := service.CreateAccount(context.Background(), &pismov1.CreateAccountRequest{DocumentNumber: "12345678900", Currency: "USD"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), account.GetAccountId())
+		assert.Equal(t, "12345678900", account.GetDocumentNumber())
+		assert.Equal(t, "USD", account.GetCurrency())
+	})
+
+	t.Run("returns invalid argument when document number is missing", func(t *testing.T) {
+		account, err := service.CreateAccount(context.Background(), &pismov1.CreateAccountRequest{})
+
+		assert.Nil(t, account)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("returns use case errors", func(t *testing.T) {
+		mockCreator.EXPECT().
+			Execute(gomock.Any(), "12345678900", "").
+			Return(nil, domain.ErrAccountAlreadyExists)
+
+		account, err := service.CreateAccount(context.Background(), &pismov1.CreateAccountRequest{DocumentNumber: "12345678900"})
+
+		assert.Nil(t, account)
+		assert.ErrorIs(t, err, domain.ErrAccountAlreadyExists)
+	})
+}
+
+func TestPismoService_GetAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, _, mockGetter, _, _ := newTestService(ctrl)
+
+	t.Run("retrieves account successfully", func(t *testing.T) {
+		mockGetter.EXPECT().
+			Execute(gomock.Any(), int64(1)).
+			Return(&domain.Account{ID: 1, DocumentNumber: "12345678900", Currency: "BRL"}, nil)
+
+		account, err := service.GetAccount(context.Background(), &pismov1.GetAccountRequest{AccountId: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), account.GetAccountId())
+	})
+
+	t.Run("returns invalid argument when account ID is missing", func(t *testing.T) {
+		account, err := service.GetAccount(context.Background(), &pismov1.GetAccountRequest{})
+
+		assert.Nil(t, account)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("returns not found error", func(t *testing.T) {
+		mockGetter.EXPECT().
+			Execute(gomock.Any(), int64(999)).
+			Return(nil, domain.ErrAccountNotFound)
+
+		account, err := service.GetAccount(context.Background(), &pismov1.GetAccountRequest{AccountId: 999})
+
+		assert.Nil(t, account)
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+}
+
+func TestPismoService_CreateTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, _, _, mockCreator, _ := newTestService(ctrl)
+
+	t.Run("creates transaction successfully", func(t *testing.T) {
+		metadata, _ := structpb.NewStruct(map[string]any{"channel": "pos"})
+		eventDate := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+
+		mockCreator.EXPECT().
+			Execute(gomock.Any(), int64(1), 1, 50.0, domain.TransactionDetails{
+				ExternalID:           "order-1",
+				MerchantCategoryCode: "5411",
+				Metadata:             map[string]any{"channel": "pos"},
+			}).
+			Return(&domain.Transaction{
+				ID:              10,
+				AccountID:       1,
+				OperationTypeID: domain.OperationTypePurchase,
+				Amount:          -50.0,
+				Balance:         -50.0,
+				EventDate:       eventDate,
+				TransactionDetails: domain.TransactionDetails{
+					ExternalID:           "order-1",
+					MerchantCategoryCode: "5411",
+					Metadata:             map[string]any{"channel": "pos"},
+				},
+			}, nil)
+
+		transaction, err := service.CreateTransaction(context.Background(), &pismov1.CreateTransactionRequest{
+			AccountId:            1,
+			OperationTypeId:      1,
+			Amount:               50.0,
+			ExternalId:           "order-1",
+			MerchantCategoryCode: "5411",
+			Metadata:             metadata,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), transaction.GetTransactionId())
+		assert.Equal(t, -50.0, transaction.GetAmount())
+		assert.Equal(t, "pos", transaction.GetMetadata().AsMap()["channel"])
+		assert.Equal(t, eventDate, transaction.GetEventDate().AsTime())
+	})
+
+	t.Run("returns invalid argument when required fields are missing", func(t *testing.T) {
+		requests := []*pismov1.CreateTransactionRequest{
+			{OperationTypeId: 1, Amount: 50.0},
+			{AccountId: 1, Amount: 50.0},
+			{AccountId: 1, OperationTypeId: 1},
+		}
+
+		for _, req := range requests {
+			transaction, err := service.CreateTransaction(context.Background(), req)
+
+			assert.Nil(t, transaction)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+
+	t.Run("rejects adjustment operation types", func(t *testing.T) {
+		transaction, err := service.CreateTransaction(context.Background(), &pismov1.CreateTransactionRequest{
+			AccountId:       1,
+			OperationTypeId: int32(domain.OperationTypeCreditVoucher),
+			Amount:          50.0,
+		})
+
+		assert.Nil(t, transaction)
+		assert.ErrorIs(t, err, domain.ErrInvalidOperationType)
+	})
+}
+
+func TestPismoService_ListTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, _, _, _, mockLister := newTestService(ctrl)
+
+	t.Run("lists transactions successfully", func(t *testing.T) {
+		mockLister.EXPECT().
+			Execute(gomock.Any(), int64(1)).
+			Return([]*domain.Transaction{
+				{ID: 10, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.0, Balance: 0},
+				{ID: 11, AccountID: 1, OperationTypeID: domain.OperationTypePayment, Amount: 60.0, Balance: 10.0},
+			}, nil)
+
+		resp, err := service.ListTransactions(context.Background(), &pismov1.ListTransactionsRequest{AccountId: 1})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.GetTransactions(), 2)
+		assert.Equal(t, int64(11), resp.GetTransactions()[1].GetTransactionId())
+		assert.Equal(t, 10.0, resp.GetTransactions()[1].GetBalance())
+	})
+
+	t.Run("returns invalid argument when account ID is missing", func(t *testing.T) {
+		resp, err := service.ListTransactions(context.Background(), &pismov1.ListTransactionsRequest{})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("returns use case errors", func(t *testing.T) {
+		mockLister.EXPECT().
+			Execute(gomock.Any(), int64(1)).
+			Return(nil, errors.New("database error"))
+
+		resp, err := service.ListTransactions(context.Background(), &pismov1.ListTransactionsRequest{AccountId: 1})
+
+		assert.Nil(t, resp)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,188 @@
+// Package health runs the checks behind the liveness, readiness and startup
+// probes.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database"
+)
+
+// Probe is what a check answers for.
+type Probe string
+
+const (
+	// Live says whether the process should be restarted. It should not
+	// depend on other services, or an outage of one restarts every instance.
+	Live Probe = "live"
+	// Ready says whether the instance should receive traffic.
+	Ready Probe = "ready"
+	// Startup says whether the instance has finished starting, holding the
+	// other probes back until then.
+	Startup Probe = "startup"
+)
+
+// ErrDraining fails readiness once the instance is shutting down.
+var ErrDraining = errors.New("shutting down")
+
+// Check returns an error when its dependency is unhealthy.
+type Check func(ctx context.Context) error
+
+type registeredCheck struct {
+	name   string
+	check  Check
+	probes []Probe
+}
+
+// Result is the outcome of one check.
+type Result struct {
+	Name    string
+	Latency time.Duration
+	Err     error
+}
+
+// Report is the outcome of the checks of a probe, healthy when all passed.
+type Report struct {
+	Healthy bool
+	Results []Result
+}
+
+// Checker is a registry of checks, each run for the probes it was
+// registered with.
+type Checker struct {
+	timeout  time.Duration
+	mu       sync.RWMutex
+	checks   []registeredCheck
+	draining atomic.Bool
+}
+
+// NewChecker returns a checker failing the checks that take longer than
+// timeout.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Register adds a check to the probes.
+func (c *Checker) Register(name string, check Check, probes ...Probe) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, registeredCheck{name: name, check: check, probes: probes})
+}
+
+// Drain fails readiness from now on, so that load balancers stop sending
+// requests before the servers shut down.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Run runs the checks of the probe concurrently.
+func (c *Checker) Run(ctx context.Context, probe Probe) Report {
+	c.mu.RLock()
+	var checks []registeredCheck
+	for _, check := range c.checks {
+		for _, p := range check.probes {
+			if p == probe {
+				checks = append(checks, check)
+				break
+			}
+		}
+	}
+	c.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	if probe == Ready && c.draining.Load() {
+		results = append(results, Result{Name: "shutdown", Err: ErrDraining})
+	}
+
+	report := Report{Healthy: true, Results: results}
+	for _, result := range results {
+		if result.Err != nil {
+			report.Healthy = false
+		}
+	}
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, check registeredCheck) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.check(ctx)
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return Result{Name: check.name, Latency: time.Since(start), Err: err}
+}
+
+// Database pings the database.
+func Database(db *sql.DB) Check {
+	return db.PingContext
+}
+
+// PoolSaturation fails when at least the given share of the connection pool
+// is in use, so that an instance out of connections stops taking requests
+// it could only queue.
+func PoolSaturation(db *sql.DB, threshold float64) Check {
+	return func(ctx context.Context) error {
+		stats := db.Stats()
+		return saturation(int64(stats.InUse), int64(stats.MaxOpenConnections), threshold)
+	}
+}
+
+// PgxPoolSaturation is PoolSaturation for the pgx pool.
+func PgxPoolSaturation(pool *pgxpool.Pool, threshold float64) Check {
+	return func(ctx context.Context) error {
+		stat := pool.Stat()
+		return saturation(int64(stat.AcquiredConns()), int64(stat.MaxConns()), threshold)
+	}
+}
+
+// saturation fails when inUse reaches the threshold share of limit, 0
+// meaning no limit.
+func saturation(inUse, limit int64, threshold float64) error {
+	if limit == 0 {
+		return nil
+	}
+	if float64(inUse) >= threshold*float64(limit) {
+		return fmt.Errorf("%d of %d connections in use", inUse, limit)
+	}
+	return nil
+}
+
+type pendingMigrations interface {
+	Pending(ctx context.Context) ([]database.Migration, error)
+}
+
+// Migrations fails while the schema is behind the migrations embedded in the
+// binary.
+func Migrations(migrator pendingMigrations) Check {
+	return func(ctx context.Context) error {
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%d pending migrations, first is %d_%s", len(pending), pending[0].Version, pending[0].Name)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,144 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database"
+)
+
+func TestChecker_Run(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+	failing := func(ctx context.Context) error { return errors.New("connection refused") }
+
+	t.Run("runs the checks registered for the probe", func(t *testing.T) {
+		// given
+		checker := NewChecker(time.Second)
+		checker.Register("database", ok, Ready, Startup)
+		checker.Register("migrations", failing, Startup)
+
+		// when
+		ready := checker.Run(context.Background(), Ready)
+		startup := checker.Run(context.Background(), Startup)
+		live := checker.Run(context.Background(), Live)
+
+		// then
+		assert.True(t, ready.Healthy)
+		assert.Len(t, ready.Results, 1)
+		assert.False(t, startup.Healthy)
+		assert.EqualError(t, startup.Results[1].Err, "connection refused")
+		assert.True(t, live.Healthy)
+		assert.Empty(t, live.Results)
+	})
+
+	t.Run("fails checks running past the timeout", func(t *testing.T) {
+		// given
+		checker := NewChecker(10 * time.Millisecond)
+		checker.Register("slow", func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}, Ready)
+
+		// when
+		report := checker.Run(context.Background(), Ready)
+
+		// then
+		assert.False(t, report.Healthy)
+		assert.ErrorIs(t, report.Results[0].Err, context.DeadlineExceeded)
+	})
+
+	t.Run("fails readiness once draining", func(t *testing.T) {
+		// given
+		checker := NewChecker(time.Second)
+		checker.Register("database", ok, Live, Ready)
+
+		// when
+		checker.Drain()
+
+		// then
+		ready := checker.Run(context.Background(), Ready)
+		assert.False(t, ready.Healthy)
+		assert.ErrorIs(t, ready.Results[1].Err, ErrDraining)
+		assert.True(t, checker.Run(context.Background(), Live).Healthy)
+	})
+}
+
+func TestPoolSaturation(t *testing.T) {
+	t.Run("fails when the pool is saturated", func(t *testing.T) {
+		// given
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		require.NoError(t, err)
+		defer db.Close()
+		db.SetMaxOpenConns(1)
+		mock.ExpectPing()
+		conn, err := db.Conn(context.Background())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		// when
+		err = PoolSaturation(db, 0.9)(context.Background())
+
+		// then
+		assert.EqualError(t, err, "1 of 1 connections in use")
+	})
+
+	t.Run("passes with an unlimited pool", func(t *testing.T) {
+		// given
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		// when
+		err = PoolSaturation(db, 0.9)(context.Background())
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+func TestPgxPoolSaturation(t *testing.T) {
+	t.Run("passes while connections are available", func(t *testing.T) {
+		// given
+		// The pool connects only once a connection is acquired.
+		pool, err := pgxpool.New(context.Background(), "postgres://localhost:1/pismo?pool_max_conns=1")
+		require.NoError(t, err)
+		defer pool.Close()
+
+		// when
+		err = PgxPoolSaturation(pool, 0.9)(context.Background())
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+type stubMigrator []database.Migration
+
+func (s stubMigrator) Pending(ctx context.Context) ([]database.Migration, error) {
+	return s, nil
+}
+
+func TestMigrations(t *testing.T) {
+	t.Run("fails while migrations are pending", func(t *testing.T) {
+		// when
+		err := Migrations(stubMigrator{{Version: 12, Name: "add_disputes"}})(context.Background())
+
+		// then
+		assert.EqualError(t, err, "1 pending migrations, first is 12_add_disputes")
+	})
+
+	t.Run("passes once the schema is up to date", func(t *testing.T) {
+		// when
+		err := Migrations(stubMigrator{})(context.Background())
+
+		// then
+		assert.NoError(t, err)
+	})
+}
@@ -2,14 +2,17 @@ package dto
 
 type CreateAccountRequest struct {
 	DocumentNumber string `json:"document_number"`
+	Currency       string `json:"currency,omitempty"`
 }
 
 type CreateAccountResponse struct {
 	AccountID      int64  `json:"account_id"`
 	DocumentNumber string `json:"document_number"`
+	Currency       string `json:"currency"`
 }
 
 type GetAccountResponse struct {
 	AccountID      int64  `json:"account_id"`
 	DocumentNumber string `json:"document_number"`
+	Currency       string `json:"currency"`
 }
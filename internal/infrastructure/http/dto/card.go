@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type IssueCardRequest struct {
+	Type string `json:"type"`
+}
+
+type CardResponse struct {
+	CardID    int64     `json:"card_id"`
+	AccountID int64     `json:"account_id"`
+	MaskedPAN string    `json:"masked_pan"`
+	Last4     string    `json:"last4"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Status    string    `json:"status"`
+	Type      string    `json:"type"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+type OpenDisputeRequest struct {
+	Reason string `json:"reason"`
+	Note   string `json:"note"`
+}
+
+type UpdateDisputeRequest struct {
+	Status string `json:"status"`
+	Note   string `json:"note"`
+}
+
+type DisputeEventResponse struct {
+	Status    string    `json:"status"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type DisputeResponse struct {
+	DisputeID                      int64                  `json:"dispute_id"`
+	TransactionID                  int64                  `json:"transaction_id"`
+	AccountID                      int64                  `json:"account_id"`
+	Amount                         float64                `json:"amount"`
+	Reason                         string                 `json:"reason"`
+	Status                         string                 `json:"status"`
+	ProvisionalCreditTransactionID int64                  `json:"provisional_credit_transaction_id,omitempty"`
+	FinalTransactionID             int64                  `json:"final_transaction_id,omitempty"`
+	Events                         []DisputeEventResponse `json:"events"`
+	OpenedAt                       time.Time              `json:"opened_at"`
+	UpdatedAt                      time.Time              `json:"updated_at"`
+	ResolvedAt                     *time.Time             `json:"resolved_at,omitempty"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+type ScheduledTransactionRequest struct {
+	OperationTypeID int        `json:"operation_type_id"`
+	Amount          float64    `json:"amount"`
+	AmountType      string     `json:"amount_type"`
+	Recurrence      string     `json:"recurrence"`
+	DayOfMonth      int        `json:"day_of_month"`
+	StartsAt        *time.Time `json:"starts_at"`
+	Description     string     `json:"description"`
+}
+
+type ScheduledTransactionResponse struct {
+	ScheduledTransactionID int64      `json:"scheduled_transaction_id"`
+	AccountID              int64      `json:"account_id"`
+	OperationTypeID        int        `json:"operation_type_id"`
+	Amount                 float64    `json:"amount,omitempty"`
+	AmountType             string     `json:"amount_type"`
+	Recurrence             string     `json:"recurrence"`
+	DayOfMonth             int        `json:"day_of_month,omitempty"`
+	StartsAt               time.Time  `json:"starts_at"`
+	Description            string     `json:"description,omitempty"`
+	Status                 string     `json:"status"`
+	NextRunAt              time.Time  `json:"next_run_at"`
+	LastRunAt              *time.Time `json:"last_run_at,omitempty"`
+	LastTransactionID      int64      `json:"last_transaction_id,omitempty"`
+	LastError              string     `json:"last_error,omitempty"`
+}
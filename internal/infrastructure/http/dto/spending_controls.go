@@ -0,0 +1,23 @@
+package dto
+
+type TimeWindow struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type UpdateSpendingControlsRequest struct {
+	BlockedMCCs           []string     `json:"blocked_mccs"`
+	MaxTransactionAmount  float64      `json:"max_transaction_amount"`
+	AllowedOperationTypes []int        `json:"allowed_operation_types"`
+	TimeWindows           []TimeWindow `json:"time_windows"`
+	Timezone              string       `json:"timezone"`
+}
+
+type SpendingControlsResponse struct {
+	AccountID             int64        `json:"account_id"`
+	BlockedMCCs           []string     `json:"blocked_mccs"`
+	MaxTransactionAmount  float64      `json:"max_transaction_amount"`
+	AllowedOperationTypes []int        `json:"allowed_operation_types"`
+	TimeWindows           []TimeWindow `json:"time_windows"`
+	Timezone              string       `json:"timezone"`
+}
@@ -1,15 +1,32 @@
 package dto
 
 type CreateTransactionRequest struct {
-	AccountID       int64   `json:"account_id"`
-	OperationTypeID int     `json:"operation_type_id"`
-	Amount          float64 `json:"amount"`
+	AccountID            int64          `json:"account_id"`
+	OperationTypeID      int            `json:"operation_type_id"`
+	Amount               float64        `json:"amount"`
+	CardID               int64          `json:"card_id,omitempty"`
+	ExternalID           string         `json:"external_id,omitempty"`
+	Description          string         `json:"description,omitempty"`
+	MerchantName         string         `json:"merchant_name,omitempty"`
+	MerchantCategoryCode string         `json:"merchant_category_code,omitempty"`
+	Currency             string         `json:"currency,omitempty"`
+	Metadata             map[string]any `json:"metadata,omitempty"`
 }
 
 type CreateTransactionResponse struct {
-	TransactionID   int64   `json:"transaction_id"`
-	AccountID       int64   `json:"account_id"`
-	OperationTypeID int     `json:"operation_type_id"`
-	Amount          float64 `json:"amount"`
-	Balance         float64 `json:"balance"`
+	TransactionID        int64          `json:"transaction_id"`
+	AccountID            int64          `json:"account_id"`
+	OperationTypeID      int            `json:"operation_type_id"`
+	Amount               float64        `json:"amount"`
+	Balance              float64        `json:"balance"`
+	OriginalAmount       float64        `json:"original_amount"`
+	SettlementCurrency   string         `json:"settlement_currency"`
+	FXRate               float64        `json:"fx_rate"`
+	CardID               int64          `json:"card_id,omitempty"`
+	ExternalID           string         `json:"external_id,omitempty"`
+	Description          string         `json:"description,omitempty"`
+	MerchantName         string         `json:"merchant_name,omitempty"`
+	MerchantCategoryCode string         `json:"merchant_category_code,omitempty"`
+	Currency             string         `json:"currency,omitempty"`
+	Metadata             map[string]any `json:"metadata,omitempty"`
 }
@@ -14,7 +14,7 @@ import (
 )
 
 type accountCreator interface {
-	Execute(ctx context.Context, documentNumber string) (*domain.Account, error)
+	Execute(ctx context.Context, documentNumber string, currency string) (*domain.Account, error)
 }
 
 type accountGetter interface {
@@ -42,19 +42,19 @@ func (h *AccountHandler) Create(w http.ResponseWriter, r *http.Request) {
 		logger.Error(ctx, "failed to decode request body",
 			slog.String("error", err.Error()),
 		)
-		response.Error(w, http.StatusBadRequest, "invalid request body")
+		response.Error(w, http.StatusBadRequest, "invalid_body", "invalid request body")
 		return
 	}
 
 	if req.DocumentNumber == "" {
-		response.Error(w, http.StatusBadRequest, "document_number is required")
+		response.InvalidFields(w, response.Required("document_number"))
 		return
 	}
 
-	account, err := h.createAccount.Execute(ctx, req.DocumentNumber)
+	account, err := h.createAccount.Execute(ctx, req.DocumentNumber, req.Currency)
 	if err != nil {
 		logger.Error(ctx, "failed to create account",
-			slog.String("document_number", req.DocumentNumber),
+			slog.Any("document_number", logger.Document(req.DocumentNumber)),
 			slog.String("error", err.Error()),
 		)
 		response.HandleError(w, err)
@@ -64,6 +64,7 @@ func (h *AccountHandler) Create(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusCreated, dto.CreateAccountResponse{
 		AccountID:      account.ID,
 		DocumentNumber: account.DocumentNumber,
+		Currency:       account.Currency,
 	})
 }
 
@@ -73,7 +74,7 @@ func (h *AccountHandler) Get(w http.ResponseWriter, r *http.Request) {
 	accountIDStr := r.PathValue("accountId")
 	accountID, err := strconv.ParseInt(accountIDStr, 10, 64)
 	if err != nil {
-		response.Error(w, http.StatusBadRequest, "invalid account id")
+		response.InvalidFields(w, response.Invalid("accountId", "invalid account id"))
 		return
 	}
 
@@ -90,5 +91,6 @@ func (h *AccountHandler) Get(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, dto.GetAccountResponse{
 		AccountID:      account.ID,
 		DocumentNumber: account.DocumentNumber,
+		Currency:       account.Currency,
 	})
 }
@@ -30,7 +30,7 @@ func TestAccountHandler_Create(t *testing.T) {
 		}
 
 		mockCreator.EXPECT().
-			Execute(gomock.Any(), "12345678900").
+			Execute(gomock.Any(), "12345678900", "").
 			Return(expectedAccount, nil)
 
 		body := bytes.NewBufferString(`{"document_number": "12345678900"}`)
@@ -68,9 +68,9 @@ func TestAccountHandler_Create(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 	})
 
-	t.Run("returns unprocessable entity when account already exists", func(t *testing.T) {
+	t.Run("returns conflict when account already exists", func(t *testing.T) {
 		mockCreator.EXPECT().
-			Execute(gomock.Any(), "99999999999").
+			Execute(gomock.Any(), "99999999999", "").
 			Return(nil, domain.ErrAccountAlreadyExists)
 
 		body := bytes.NewBufferString(`{"document_number": "99999999999"}`)
@@ -79,7 +79,7 @@ func TestAccountHandler_Create(t *testing.T) {
 
 		handler.Create(rec, req)
 
-		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, http.StatusConflict, rec.Code)
 	})
 }
 
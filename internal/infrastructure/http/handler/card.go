@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/response"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+//go:generate mockgen -source=card.go -destination=mocks/card_mock.go -package=mocks
+type cardIssuer interface {
+	Execute(ctx context.Context, accountID int64, cardType string) (*domain.Card, error)
+}
+
+type cardBlocker interface {
+	Execute(ctx context.Context, cardID int64) (*domain.Card, error)
+}
+
+type CardHandler struct {
+	issueCard cardIssuer
+	blockCard cardBlocker
+}
+
+func NewCardHandler(issueCard cardIssuer, blockCard cardBlocker) *CardHandler {
+	return &CardHandler{
+		issueCard: issueCard,
+		blockCard: blockCard,
+	}
+}
+
+func (h *CardHandler) Issue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := strconv.ParseInt(r.PathValue("accountId"), 10, 64)
+	if err != nil {
+		response.InvalidFields(w, response.Invalid("accountId", "invalid account id"))
+		return
+	}
+
+	var req dto.IssueCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error(ctx, "failed to decode request body",
+			slog.String("error", err.Error()),
+		)
+		response.Error(w, http.StatusBadRequest, "invalid_body", "invalid request body")
+		return
+	}
+
+	if req.Type == "" {
+		response.InvalidFields(w, response.Required("type"))
+		return
+	}
+
+	card, err := h.issueCard.Execute(ctx, accountID, req.Type)
+	if err != nil {
+		logger.Error(ctx, "failed to issue card",
+			slog.Int64("account_id", accountID),
+			slog.String("type", req.Type),
+			slog.String("error", err.Error()),
+		)
+		response.HandleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, toCardResponse(card))
+}
+
+func (h *CardHandler) Block(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	cardID, err := strconv.ParseInt(r.PathValue("cardId"), 10, 64)
+	if err != nil {
+		response.InvalidFields(w, response.Invalid("cardId", "invalid card id"))
+		return
+	}
+
+	card, err := h.blockCard.Execute(ctx, cardID)
+	if err != nil {
+		logger.Error(ctx, "failed to block card",
+			slog.Int64("card_id", cardID),
+			slog.String("error", err.Error()),
+		)
+		response.HandleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toCardResponse(card))
+}
+
+func toCardResponse(card *domain.Card) dto.CardResponse {
+	return dto.CardResponse{
+		CardID:    card.ID,
+		AccountID: card.AccountID,
+		MaskedPAN: card.MaskedPAN,
+		Last4:     card.Last4,
+		Token:     card.Token,
+		ExpiresAt: card.ExpiresAt,
+		Status:    string(card.Status),
+		Type:      string(card.Type),
+	}
+}
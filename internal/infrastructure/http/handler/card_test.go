@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/handler/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCardHandler_Issue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIssuer := mocks.NewMockcardIssuer(ctrl)
+	mockBlocker := mocks.NewMockcardBlocker(ctrl)
+	handler := NewCardHandler(mockIssuer, mockBlocker)
+
+	t.Run("issues card successfully", func(t *testing.T) {
+		mockIssuer.EXPECT().
+			Execute(gomock.Any(), int64(1), "virtual").
+			Return(&domain.Card{ID: 3, AccountID: 1, MaskedPAN: "400000******1234", Last4: "1234", Status: domain.CardStatusActive, Type: domain.CardTypeVirtual}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/accounts/1/cards", bytes.NewBufferString(`{"type": "virtual"}`))
+		req.SetPathValue("accountId", "1")
+		rec := httptest.NewRecorder()
+
+		handler.Issue(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var response dto.CardResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+
+		assert.Equal(t, int64(3), response.CardID)
+		assert.Equal(t, "1234", response.Last4)
+		assert.Equal(t, "active", response.Status)
+		assert.Equal(t, "virtual", response.Type)
+	})
+
+	t.Run("returns bad request when type is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/accounts/1/cards", bytes.NewBufferString(`{}`))
+		req.SetPathValue("accountId", "1")
+		rec := httptest.NewRecorder()
+
+		handler.Issue(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns not found when account does not exist", func(t *testing.T) {
+		mockIssuer.EXPECT().
+			Execute(gomock.Any(), int64(999), "physical").
+			Return(nil, domain.ErrAccountNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/accounts/999/cards", bytes.NewBufferString(`{"type": "physical"}`))
+		req.SetPathValue("accountId", "999")
+		rec := httptest.NewRecorder()
+
+		handler.Issue(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestCardHandler_Block(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIssuer := mocks.NewMockcardIssuer(ctrl)
+	mockBlocker := mocks.NewMockcardBlocker(ctrl)
+	handler := NewCardHandler(mockIssuer, mockBlocker)
+
+	t.Run("blocks card successfully", func(t *testing.T) {
+		mockBlocker.EXPECT().
+			Execute(gomock.Any(), int64(3)).
+			Return(&domain.Card{ID: 3, AccountID: 1, Status: domain.CardStatusBlocked, Type: domain.CardTypeVirtual}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/cards/3/block", nil)
+		req.SetPathValue("cardId", "3")
+		rec := httptest.NewRecorder()
+
+		handler.Block(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response dto.CardResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+
+		assert.Equal(t, "blocked", response.Status)
+	})
+
+	t.Run("returns bad request when card id is invalid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/cards/abc/block", nil)
+		req.SetPathValue("cardId", "abc")
+		rec := httptest.NewRecorder()
+
+		handler.Block(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns not found when card does not exist", func(t *testing.T) {
+		mockBlocker.EXPECT().
+			Execute(gomock.Any(), int64(999)).
+			Return(nil, domain.ErrCardNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/cards/999/block", nil)
+		req.SetPathValue("cardId", "999")
+		rec := httptest.NewRecorder()
+
+		handler.Block(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
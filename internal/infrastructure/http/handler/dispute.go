@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/response"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+//go:generate mockgen -source=dispute.go -destination=mocks/dispute_mock.go -package=mocks
+type disputeOpener interface {
+	Execute(ctx context.Context, transactionID int64, reason, note string) (*domain.Dispute, error)
+}
+
+type disputeLister interface {
+	Execute(ctx context.Context, transactionID int64) ([]*domain.Dispute, error)
+}
+
+type disputeUpdater interface {
+	Execute(ctx context.Context, transactionID, disputeID int64, status, note string) (*domain.Dispute, error)
+}
+
+type DisputeHandler struct {
+	openDispute   disputeOpener
+	listDisputes  disputeLister
+	updateDispute disputeUpdater
+}
+
+func NewDisputeHandler(openDispute disputeOpener, listDisputes disputeLister, updateDispute disputeUpdater) *DisputeHandler {
+	return &DisputeHandler{
+		openDispute:   openDispute,
+		listDisputes:  listDisputes,
+		updateDispute: updateDispute,
+	}
+}
+
+func (h *DisputeHandler) Open(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	transactionID, err := strconv.ParseInt(r.PathValue("transactionId"), 10, 64)
+	if err != nil {
+		response.InvalidFields(w, response.Invalid("transactionId", "invalid transaction id"))
+		return
+	}
+
+	var req dto.OpenDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error(ctx, "failed to decode request body",
+			slog.String("error", err.Error()),
+		)
+		response.Error(w, http.StatusBadRequest, "invalid_body", "invalid request body")
+		return
+	}
+
+	if req.Reason == "" {
+		response.InvalidFields(w, response.Required("reason"))
+		return
+	}
+
+	dispute, err := h.openDispute.Execute(ctx, transactionID, req.Reason, req.Note)
+	if err != nil {
+		logger.Error(ctx, "failed to open dispute",
+			slog.Int64("transaction_id", transactionID),
+			slog.String("error", err.Error()),
+		)
+		response.HandleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, toDisputeResponse(dispute))
+}
+
+func (h *DisputeHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	transactionID, err := strconv.ParseInt(r.PathValue("transactionId"), 10, 64)
+	if err != nil {
+		response.InvalidFields(w, response.Invalid("transactionId", "invalid transaction id"))
+		return
+	}
+
+	disputes, err := h.listDisputes.Execute(ctx, transactionID)
+	if err != nil {
+		logger.Error(ctx, "failed to list disputes",
+			slog.Int64("transaction_id", transactionID),
+			slog.String("error", err.Error()),
+		)
+		response.HandleError(w, err)
+		return
+	}
+
+	resp := make([]dto.DisputeResponse, 0, len(disputes))
+	for _, dispute := range disputes {
+		resp = append(resp, toDisputeResponse(dispute))
+	}
+
+	response.JSON(w, http.StatusOK, resp)
+}
+
+func (h *DisputeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	transactionID, err := strconv.ParseInt(r.PathValue("transactionId"), 10, 64)
+	if err != nil {
+		response.InvalidFields(w, response.Invalid("transactionId", "invalid transaction id"))
+		return
+	}
+
+	disputeID, err := strconv.ParseInt(r.PathValue("disputeId"), 10, 64)
+	if err != nil {
+		response.InvalidFields(w, response.Invalid("disputeId", "invalid dispute id"))
+		return
+	}
+
+	var req dto.UpdateDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error(ctx, "failed to decode request body",
+			slog.String("error", err.Error()),
+		)
+		response.Error(w, http.StatusBadRequest, "invalid_body", "invalid request body")
+		return
+	}
+
+	if req.Status == "" {
+		response.InvalidFields(w, response.Required("status"))
+		return
+	}
+
+	dispute, err := h.updateDispute.Execute(ctx, transactionID, disputeID, req.Status, req.Note)
+	if err != nil {
+		logger.Error(ctx, "failed to update dispute",
+			slog.Int64("transaction_id", transactionID),
+			slog.Int64("dispute_id", disputeID),
+			slog.String("status", req.Status),
+			slog.String("error", err.Error()),
+		)
+		response.HandleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toDisputeResponse(dispute))
+}
+
+func toDisputeResponse(dispute *domain.Dispute) dto.DisputeResponse {
+	events := make([]dto.DisputeEventResponse, 0, len(dispute.Events))
+	for _, event := range dispute.Events {
+		events = append(events, dto.DisputeEventResponse{
+			Status:    string(event.Status),
+			Note:      event.Note,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	resp := dto.DisputeResponse{
+		DisputeID:                      dispute.ID,
+		TransactionID:                  dispute.TransactionID,
+		AccountID:                      dispute.AccountID,
+		Amount:                         dispute.Amount,
+		Reason:                         dispute.Reason,
+		Status:                         string(dispute.Status),
+		ProvisionalCreditTransactionID: dispute.ProvisionalCreditTransactionID,
+		FinalTransactionID:             dispute.FinalTransactionID,
+		Events:                         events,
+		OpenedAt:                       dispute.OpenedAt,
+		UpdatedAt:                      dispute.UpdatedAt,
+	}
+	if !dispute.ResolvedAt.IsZero() {
+		resp.ResolvedAt = &dispute.ResolvedAt
+	}
+
+	return resp
+}
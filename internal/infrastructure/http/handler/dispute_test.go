@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/handler/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDisputeHandler_Open(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOpener := mocks.NewMockdisputeOpener(ctrl)
+	handler := NewDisputeHandler(mockOpener, mocks.NewMockdisputeLister(ctrl), mocks.NewMockdisputeUpdater(ctrl))
+
+	t.Run("opens dispute successfully", func(t *testing.T) {
+		mockOpener.EXPECT().
+			Execute(gomock.Any(), int64(10), "item not received", "customer called").
+			Return(&domain.Dispute{
+				ID:                             1,
+				TransactionID:                  10,
+				AccountID:                      1,
+				Amount:                         80.0,
+				Reason:                         "item not received",
+				Status:                         domain.DisputeStatusOpened,
+				ProvisionalCreditTransactionID: 11,
+				Events:                         []domain.DisputeEvent{{Status: domain.DisputeStatusOpened, Note: "customer called"}},
+			}, nil)
+
+		body := bytes.NewBufferString(`{"reason": "item not received", "note": "customer called"}`)
+		req := httptest.NewRequest(http.MethodPost, "/transactions/10/disputes", body)
+		req.SetPathValue("transactionId", "10")
+		rec := httptest.NewRecorder()
+
+		handler.Open(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var response dto.DisputeResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+
+		assert.Equal(t, int64(1), response.DisputeID)
+		assert.Equal(t, "opened", response.Status)
+		assert.Equal(t, int64(11), response.ProvisionalCreditTransactionID)
+		assert.Len(t, response.Events, 1)
+		assert.Nil(t, response.ResolvedAt)
+	})
+
+	t.Run("returns bad request when reason is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/transactions/10/disputes", bytes.NewBufferString(`{}`))
+		req.SetPathValue("transactionId", "10")
+		rec := httptest.NewRecorder()
+
+		handler.Open(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns bad request when transaction id is invalid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/transactions/abc/disputes", bytes.NewBufferString(`{"reason": "fraud"}`))
+		req.SetPathValue("transactionId", "abc")
+		rec := httptest.NewRecorder()
+
+		handler.Open(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns unprocessable entity when transaction is not disputable", func(t *testing.T) {
+		mockOpener.EXPECT().
+			Execute(gomock.Any(), int64(20), "fraud", "").
+			Return(nil, domain.ErrTransactionNotDisputable)
+
+		req := httptest.NewRequest(http.MethodPost, "/transactions/20/disputes", bytes.NewBufferString(`{"reason": "fraud"}`))
+		req.SetPathValue("transactionId", "20")
+		rec := httptest.NewRecorder()
+
+		handler.Open(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("returns not found when transaction does not exist", func(t *testing.T) {
+		mockOpener.EXPECT().
+			Execute(gomock.Any(), int64(999), "fraud", "").
+			Return(nil, domain.ErrTransactionNotFound)
+
+		req := httptest.NewRequest(http.MethodPost, "/transactions/999/disputes", bytes.NewBufferString(`{"reason": "fraud"}`))
+		req.SetPathValue("transactionId", "999")
+		rec := httptest.NewRecorder()
+
+		handler.Open(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestDisputeHandler_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLister := mocks.NewMockdisputeLister(ctrl)
+	handler := NewDisputeHandler(mocks.NewMockdisputeOpener(ctrl), mockLister, mocks.NewMockdisputeUpdater(ctrl))
+
+	t.Run("lists disputes of the transaction", func(t *testing.T) {
+		mockLister.EXPECT().
+			Execute(gomock.Any(), int64(10)).
+			Return([]*domain.Dispute{{ID: 1, TransactionID: 10, Status: domain.DisputeStatusLost}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/transactions/10/disputes", nil)
+		req.SetPathValue("transactionId", "10")
+		rec := httptest.NewRecorder()
+
+		handler.List(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response []dto.DisputeResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+
+		assert.Len(t, response, 1)
+		assert.Equal(t, "lost", response[0].Status)
+	})
+
+	t.Run("returns empty list when transaction has no disputes", func(t *testing.T) {
+		mockLister.EXPECT().Execute(gomock.Any(), int64(11)).Return(nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/transactions/11/disputes", nil)
+		req.SetPathValue("transactionId", "11")
+		rec := httptest.NewRecorder()
+
+		handler.List(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[]`, rec.Body.String())
+	})
+}
+
+func TestDisputeHandler_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUpdater := mocks.NewMockdisputeUpdater(ctrl)
+	handler := NewDisputeHandler(mocks.NewMockdisputeOpener(ctrl), mocks.NewMockdisputeLister(ctrl), mockUpdater)
+
+	t.Run("resolves dispute successfully", func(t *testing.T) {
+		resolvedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+		mockUpdater.EXPECT().
+			Execute(gomock.Any(), int64(10), int64(1), "lost", "proof of delivery").
+			Return(&domain.Dispute{ID: 1, TransactionID: 10, Status: domain.DisputeStatusLost, FinalTransactionID: 12, ResolvedAt: resolvedAt}, nil)
+
+		body := bytes.NewBufferString(`{"status": "lost", "note": "proof of delivery"}`)
+		req := httptest.NewRequest(http.MethodPatch, "/transactions/10/disputes/1", body)
+		req.SetPathValue("transactionId", "10")
+		req.SetPathValue("disputeId", "1")
+		rec := httptest.NewRecorder()
+
+		handler.Update(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response dto.DisputeResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+
+		assert.Equal(t, "lost", response.Status)
+		assert.Equal(t, int64(12), response.FinalTransactionID)
+		assert.Equal(t, resolvedAt, *response.ResolvedAt)
+	})
+
+	t.Run("returns bad request when status is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/transactions/10/disputes/1", bytes.NewBufferString(`{"note": "x"}`))
+		req.SetPathValue("transactionId", "10")
+		req.SetPathValue("disputeId", "1")
+		rec := httptest.NewRecorder()
+
+		handler.Update(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns bad request when dispute id is invalid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/transactions/10/disputes/abc", bytes.NewBufferString(`{"status": "won"}`))
+		req.SetPathValue("transactionId", "10")
+		req.SetPathValue("disputeId", "abc")
+		rec := httptest.NewRecorder()
+
+		handler.Update(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns conflict when transition is not allowed", func(t *testing.T) {
+		mockUpdater.EXPECT().
+			Execute(gomock.Any(), int64(10), int64(2), "won", "").
+			Return(nil, domain.ErrInvalidDisputeTransition)
+
+		req := httptest.NewRequest(http.MethodPatch, "/transactions/10/disputes/2", bytes.NewBufferString(`{"status": "won"}`))
+		req.SetPathValue("transactionId", "10")
+		req.SetPathValue("disputeId", "2")
+		rec := httptest.NewRecorder()
+
+		handler.Update(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("returns not found when dispute does not exist", func(t *testing.T) {
+		mockUpdater.EXPECT().
+			Execute(gomock.Any(), int64(10), int64(999), "won", "").
+			Return(nil, domain.ErrDisputeNotFound)
+
+		req := httptest.NewRequest(http.MethodPatch, "/transactions/10/disputes/999", bytes.NewBufferString(`{"status": "won"}`))
+		req.SetPathValue("transactionId", "10")
+		req.SetPathValue("disputeId", "999")
+		rec := httptest.NewRecorder()
+
+		handler.Update(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
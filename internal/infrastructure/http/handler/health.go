@@ -2,42 +2,81 @@ package handler
 
 import (
 	"context"
-	"database/sql"
 	"net/http"
-	"time"
 
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/health"
 	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/response"
 )
 
+type healthChecker interface {
+	Run(ctx context.Context, probe health.Probe) health.Report
+}
+
 type HealthHandler struct {
-	db *sql.DB
+	checker healthChecker
 }
 
-func NewHealthHandler(db *sql.DB) *HealthHandler {
-	return &HealthHandler{db: db}
+func NewHealthHandler(checker healthChecker) *HealthHandler {
+	return &HealthHandler{checker: checker}
 }
 
 type HealthResponse struct {
-	Status   string `json:"status"`
-	Database string `json:"database"`
+	Status string `json:"status"`
+	// Checks are only listed with ?verbose, as their errors may reveal
+	// internals.
+	Checks []HealthCheckResponse `json:"checks,omitempty"`
 }
 
-func (h *HealthHandler) Check(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
-	defer cancel()
+type HealthCheckResponse struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
 
-	dbStatus := "connected"
-	status := "healthy"
-	httpStatus := http.StatusOK
+// Live answers the liveness probe.
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	h.probe(w, r, health.Live)
+}
+
+// Ready answers the readiness probe, which fails once the service drains.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	h.probe(w, r, health.Ready)
+}
 
-	if err := h.db.PingContext(ctx); err != nil {
-		dbStatus = "disconnected"
-		status = "unhealthy"
+// Startup answers the startup probe.
+func (h *HealthHandler) Startup(w http.ResponseWriter, r *http.Request) {
+	h.probe(w, r, health.Startup)
+}
+
+func (h *HealthHandler) probe(w http.ResponseWriter, r *http.Request, probe health.Probe) {
+	report := h.checker.Run(r.Context(), probe)
+
+	resp := HealthResponse{Status: status(report.Healthy)}
+	if r.URL.Query().Has("verbose") {
+		for _, result := range report.Results {
+			check := HealthCheckResponse{
+				Name:      result.Name,
+				Status:    status(result.Err == nil),
+				LatencyMS: float64(result.Latency.Microseconds()) / 1000,
+			}
+			if result.Err != nil {
+				check.Error = result.Err.Error()
+			}
+			resp.Checks = append(resp.Checks, check)
+		}
+	}
+
+	httpStatus := http.StatusOK
+	if !report.Healthy {
 		httpStatus = http.StatusServiceUnavailable
 	}
+	response.JSON(w, httpStatus, resp)
+}
 
-	response.JSON(w, httpStatus, HealthResponse{
-		Status:   status,
-		Database: dbStatus,
-	})
+func status(healthy bool) string {
+	if healthy {
+		return "healthy"
+	}
+	return "unhealthy"
 }
@@ -1,62 +1,78 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
-	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-)
-
-func TestHealthHandler_Check(t *testing.T) {
-	t.Run("returns healthy when database is connected", func(t *testing.T) {
-		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
-		require.NoError(t, err)
-		defer db.Close()
 
-		mock.ExpectPing()
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/health"
+)
 
-		handler := NewHealthHandler(db)
+func TestHealthHandler(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Register("database", func(ctx context.Context) error { return nil }, health.Ready, health.Startup)
+	checker.Register("migrations", func(ctx context.Context) error { return errors.New("1 pending migrations") }, health.Startup)
+	handler := NewHealthHandler(checker)
 
-		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	t.Run("returns healthy when every check passes", func(t *testing.T) {
+		// given
 		rec := httptest.NewRecorder()
 
-		handler.Check(rec, req)
+		// when
+		handler.Ready(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
 
+		// then
 		assert.Equal(t, http.StatusOK, rec.Code)
-
-		var response HealthResponse
-		err = json.NewDecoder(rec.Body).Decode(&response)
-		require.NoError(t, err)
-
-		assert.Equal(t, "healthy", response.Status)
-		assert.Equal(t, "connected", response.Database)
+		assert.JSONEq(t, `{"status": "healthy"}`, rec.Body.String())
 	})
 
-	t.Run("returns unhealthy when database is disconnected", func(t *testing.T) {
-		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
-		require.NoError(t, err)
+	t.Run("returns unhealthy when a check fails", func(t *testing.T) {
+		// given
+		rec := httptest.NewRecorder()
 
-		mock.ExpectPing().WillReturnError(assert.AnError)
-		db.Close() // Close the connection to simulate disconnect
+		// when
+		handler.Startup(rec, httptest.NewRequest(http.MethodGet, "/startupz", nil))
 
-		handler := NewHealthHandler(db)
+		// then
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.JSONEq(t, `{"status": "unhealthy"}`, rec.Body.String())
+	})
 
-		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	t.Run("lists each check in verbose mode", func(t *testing.T) {
+		// given
 		rec := httptest.NewRecorder()
 
-		handler.Check(rec, req)
+		// when
+		handler.Startup(rec, httptest.NewRequest(http.MethodGet, "/startupz?verbose", nil))
+
+		// then
+		var resp HealthResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		require.Len(t, resp.Checks, 2)
+		assert.Equal(t, "database", resp.Checks[0].Name)
+		assert.Equal(t, "healthy", resp.Checks[0].Status)
+		assert.Empty(t, resp.Checks[0].Error)
+		assert.Equal(t, "migrations", resp.Checks[1].Name)
+		assert.Equal(t, "unhealthy", resp.Checks[1].Status)
+		assert.Equal(t, "1 pending migrations", resp.Checks[1].Error)
+	})
 
-		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	t.Run("stays live without dependencies", func(t *testing.T) {
+		// given
+		rec := httptest.NewRecorder()
 
-		var response HealthResponse
-		err = json.NewDecoder(rec.Body).Decode(&response)
-		require.NoError(t, err)
+		// when
+		handler.Live(rec, httptest.NewRequest(http.MethodGet, "/livez?verbose", nil))
 
-		assert.Equal(t, "unhealthy", response.Status)
-		assert.Equal(t, "disconnected", response.Database)
+		// then
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"status": "healthy"}`, rec.Body.String())
 	})
 }
@@ -42,18 +42,18 @@ func (m *MockaccountCreator) EXPECT() *MockaccountCreatorMockRecorder {
 }
 
 // Execute mocks base method.
-func (m *MockaccountCreator) Execute(ctx context.Context, documentNumber string) (*domain.Account, error) {
+func (m *MockaccountCreator) Execute(ctx context.Context, documentNumber, currency string) (*domain.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Execute", ctx, documentNumber)
+	ret := m.ctrl.Call(m, "Execute", ctx, documentNumber, currency)
 	ret0, _ := ret[0].(*domain.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Execute indicates an expected call of Execute.
-func (mr *MockaccountCreatorMockRecorder) Execute(ctx, documentNumber any) *gomock.Call {
+func (mr *MockaccountCreatorMockRecorder) Execute(ctx, documentNumber, currency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockaccountCreator)(nil).Execute), ctx, documentNumber)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockaccountCreator)(nil).Execute), ctx, documentNumber, currency)
 }
 
 // MockaccountGetter is a mock of accountGetter interface.
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: card.go
+//
+// Generated by this command:
+//
+//	mockgen -source=card.go -destination=mocks/card_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/nubank/pismo-code-assessment/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcardIssuer is a mock of cardIssuer interface.
+type MockcardIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockcardIssuerMockRecorder
+	isgomock struct{}
+}
+
+// MockcardIssuerMockRecorder is the mock recorder for MockcardIssuer.
+type MockcardIssuerMockRecorder struct {
+	mock *MockcardIssuer
+}
+
+// NewMockcardIssuer creates a new mock instance.
+func NewMockcardIssuer(ctrl *gomock.Controller) *MockcardIssuer {
+	mock := &MockcardIssuer{ctrl: ctrl}
+	mock.recorder = &MockcardIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcardIssuer) EXPECT() *MockcardIssuerMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockcardIssuer) Execute(ctx context.Context, accountID int64, cardType string) (*domain.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, accountID, cardType)
+	ret0, _ := ret[0].(*domain.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockcardIssuerMockRecorder) Execute(ctx, accountID, cardType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockcardIssuer)(nil).Execute), ctx, accountID, cardType)
+}
+
+// MockcardBlocker is a mock of cardBlocker interface.
+type MockcardBlocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockcardBlockerMockRecorder
+	isgomock struct{}
+}
+
+// MockcardBlockerMockRecorder is the mock recorder for MockcardBlocker.
+type MockcardBlockerMockRecorder struct {
+	mock *MockcardBlocker
+}
+
+// NewMockcardBlocker creates a new mock instance.
+func NewMockcardBlocker(ctrl *gomock.Controller) *MockcardBlocker {
+	mock := &MockcardBlocker{ctrl: ctrl}
+	mock.recorder = &MockcardBlockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcardBlocker) EXPECT() *MockcardBlockerMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockcardBlocker) Execute(ctx context.Context, cardID int64) (*domain.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, cardID)
+	ret0, _ := ret[0].(*domain.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockcardBlockerMockRecorder) Execute(ctx, cardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockcardBlocker)(nil).Execute), ctx, cardID)
+}
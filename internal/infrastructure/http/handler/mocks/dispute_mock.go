@@ -0,0 +1,135 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dispute.go
+//
+// Generated by this command:
+//
+//	mockgen -source=dispute.go -destination=mocks/dispute_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/nubank/pismo-code-assessment/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockdisputeOpener is a mock of disputeOpener interface.
+type MockdisputeOpener struct {
+	ctrl     *gomock.Controller
+	recorder *MockdisputeOpenerMockRecorder
+	isgomock struct{}
+}
+
+// MockdisputeOpenerMockRecorder is the mock recorder for MockdisputeOpener.
+type MockdisputeOpenerMockRecorder struct {
+	mock *MockdisputeOpener
+}
+
+// NewMockdisputeOpener creates a new mock instance.
+func NewMockdisputeOpener(ctrl *gomock.Controller) *MockdisputeOpener {
+	mock := &MockdisputeOpener{ctrl: ctrl}
+	mock.recorder = &MockdisputeOpenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdisputeOpener) EXPECT() *MockdisputeOpenerMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockdisputeOpener) Execute(ctx context.Context, transactionID int64, reason, note string) (*domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, transactionID, reason, note)
+	ret0, _ := ret[0].(*domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockdisputeOpenerMockRecorder) Execute(ctx, transactionID, reason, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockdisputeOpener)(nil).Execute), ctx, transactionID, reason, note)
+}
+
+// MockdisputeLister is a mock of disputeLister interface.
+type MockdisputeLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockdisputeListerMockRecorder
+	isgomock struct{}
+}
+
+// MockdisputeListerMockRecorder is the mock recorder for MockdisputeLister.
+type MockdisputeListerMockRecorder struct {
+	mock *MockdisputeLister
+}
+
+// NewMockdisputeLister creates a new mock instance.
+func NewMockdisputeLister(ctrl *gomock.Controller) *MockdisputeLister {
+	mock := &MockdisputeLister{ctrl: ctrl}
+	mock.recorder = &MockdisputeListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdisputeLister) EXPECT() *MockdisputeListerMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockdisputeLister) Execute(ctx context.Context, transactionID int64) ([]*domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, transactionID)
+	ret0, _ := ret[0].([]*domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockdisputeListerMockRecorder) Execute(ctx, transactionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockdisputeLister)(nil).Execute), ctx, transactionID)
+}
+
+// MockdisputeUpdater is a mock of disputeUpdater interface.
+type MockdisputeUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockdisputeUpdaterMockRecorder
+	isgomock struct{}
+}
+
+// MockdisputeUpdaterMockRecorder is the mock recorder for MockdisputeUpdater.
+type MockdisputeUpdaterMockRecorder struct {
+	mock *MockdisputeUpdater
+}
+
+// NewMockdisputeUpdater creates a new mock instance.
+func NewMockdisputeUpdater(ctrl *gomock.Controller) *MockdisputeUpdater {
+	mock := &MockdisputeUpdater{ctrl: ctrl}
+	mock.recorder = &MockdisputeUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdisputeUpdater) EXPECT() *MockdisputeUpdaterMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockdisputeUpdater) Execute(ctx context.Context, transactionID, disputeID int64, status, note string) (*domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, transactionID, disputeID, status, note)
+	ret0, _ := ret[0].(*domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockdisputeUpdaterMockRecorder) Execute(ctx, transactionID, disputeID, status, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockdisputeUpdater)(nil).Execute), ctx, transactionID, disputeID, status, note)
+}
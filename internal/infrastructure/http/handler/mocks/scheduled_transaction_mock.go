@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: scheduled_transaction.go
+//
+// Generated by this command:
+//
+//	mockgen -source=scheduled_transaction.go -destination=mocks/scheduled_transaction_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/nubank/pismo-code-assessment/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockscheduledTransactionCreator is a mock of scheduledTransactionCreator interface.
+type MockscheduledTransactionCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockscheduledTransactionCreatorMockRecorder
+	isgomock struct{}
+}
+
+// MockscheduledTransactionCreatorMockRecorder is the mock recorder for MockscheduledTransactionCreator.
+type MockscheduledTransactionCreatorMockRecorder struct {
+	mock *MockscheduledTransactionCreator
+}
+
+// NewMockscheduledTransactionCreator creates a new mock instance.
+func NewMockscheduledTransactionCreator(ctrl *gomock.Controller) *MockscheduledTransactionCreator {
+	mock := &MockscheduledTransactionCreator{ctrl: ctrl}
+	mock.recorder = &MockscheduledTransactionCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockscheduledTransactionCreator) EXPECT() *MockscheduledTransactionCreatorMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockscheduledTransactionCreator) Execute(ctx context.Context, accountID int64, definition domain.ScheduleDefinition) (*domain.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, accountID, definition)
+	ret0, _ := ret[0].(*domain.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockscheduledTransactionCreatorMockRecorder) Execute(ctx, accountID, definition any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockscheduledTransactionCreator)(nil).Execute), ctx, accountID, definition)
+}
+
+// MockscheduledTransactionLister is a mock of scheduledTransactionLister interface.
+type MockscheduledTransactionLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockscheduledTransactionListerMockRecorder
+	isgomock struct{}
+}
+
+// MockscheduledTransactionListerMockRecorder is the mock recorder for MockscheduledTransactionLister.
+type MockscheduledTransactionListerMockRecorder struct {
+	mock *MockscheduledTransactionLister
+}
+
+// NewMockscheduledTransactionLister creates a new mock instance.
+func NewMockscheduledTransactionLister(ctrl *gomock.Controller) *MockscheduledTransactionLister {
+	mock := &MockscheduledTransactionLister{ctrl: ctrl}
+	mock.recorder = &MockscheduledTransactionListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockscheduledTransactionLister) EXPECT() *MockscheduledTransactionListerMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockscheduledTransactionLister) Execute(ctx context.Context, accountID int64) ([]*domain.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, accountID)
+	ret0, _ := ret[0].([]*domain.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockscheduledTransactionListerMockRecorder) Execute(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockscheduledTransactionLister)(nil).Execute), ctx, accountID)
+}
+
+// MockscheduledTransactionGetter is a mock of scheduledTransactionGetter interface.
+type MockscheduledTransactionGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockscheduledTransactionGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockscheduledTransactionGetterMockRecorder is the mock recorder for MockscheduledTransactionGetter.
+type MockscheduledTransactionGetterMockRecorder struct {
+	mock *MockscheduledTransactionGetter
+}
+
+// NewMockscheduledTransactionGetter creates a new mock instance.
+func NewMockscheduledTransactionGetter(ctrl *gomock.Controller) *MockscheduledTransactionGetter {
+	mock := &MockscheduledTransactionGetter{ctrl: ctrl}
+	mock.recorder = &MockscheduledTransactionGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockscheduledTransactionGetter) EXPECT() *MockscheduledTransactionGetterMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockscheduledTransactionGetter) Execute(ctx context.Context, ID int64) (*domain.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, ID)
+	ret0, _ := ret[0].(*domain.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockscheduledTransactionGetterMockRecorder) Execute(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockscheduledTransactionGetter)(nil).Execute), ctx, ID)
+}
+
+// MockscheduledTransactionUpdater is a mock of scheduledTransactionUpdater interface.
+type MockscheduledTransactionUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockscheduledTransactionUpdaterMockRecorder
+	isgomock struct{}
+}
+
+// MockscheduledTransactionUpdaterMockRecorder is the mock recorder for MockscheduledTransactionUpdater.
+type MockscheduledTransactionUpdaterMockRecorder struct {
+	mock *MockscheduledTransactionUpdater
+}
+
+// NewMockscheduledTransactionUpdater creates a new mock instance.
+func NewMockscheduledTransactionUpdater(ctrl *gomock.Controller) *MockscheduledTransactionUpdater {
+	mock := &MockscheduledTransactionUpdater{ctrl: ctrl}
+	mock.recorder = &MockscheduledTransactionUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockscheduledTransactionUpdater) EXPECT() *MockscheduledTransactionUpdaterMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockscheduledTransactionUpdater) Execute(ctx context.Context, ID int64, definition domain.ScheduleDefinition) (*domain.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, ID, definition)
+	ret0, _ := ret[0].(*domain.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockscheduledTransactionUpdaterMockRecorder) Execute(ctx, ID, definition any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockscheduledTransactionUpdater)(nil).Execute), ctx, ID, definition)
+}
+
+// MockscheduledTransactionCanceller is a mock of scheduledTransactionCanceller interface.
+type MockscheduledTransactionCanceller struct {
+	ctrl     *gomock.Controller
+	recorder *MockscheduledTransactionCancellerMockRecorder
+	isgomock struct{}
+}
+
+// MockscheduledTransactionCancellerMockRecorder is the mock recorder for MockscheduledTransactionCanceller.
+type MockscheduledTransactionCancellerMockRecorder struct {
+	mock *MockscheduledTransactionCanceller
+}
+
+// NewMockscheduledTransactionCanceller creates a new mock instance.
+func NewMockscheduledTransactionCanceller(ctrl *gomock.Controller) *MockscheduledTransactionCanceller {
+	mock := &MockscheduledTransactionCanceller{ctrl: ctrl}
+	mock.recorder = &MockscheduledTransactionCancellerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockscheduledTransactionCanceller) EXPECT() *MockscheduledTransactionCancellerMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockscheduledTransactionCanceller) Execute(ctx context.Context, ID int64) (*domain.ScheduledTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, ID)
+	ret0, _ := ret[0].(*domain.ScheduledTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockscheduledTransactionCancellerMockRecorder) Execute(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockscheduledTransactionCanceller)(nil).Execute), ctx, ID)
+}
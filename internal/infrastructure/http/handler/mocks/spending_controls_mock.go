@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: spending_controls.go
+//
+// Generated by this command:
+//
+//	mockgen -source=spending_controls.go -destination=mocks/spending_controls_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/nubank/pismo-code-assessment/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockspendingControlsGetter is a mock of spendingControlsGetter interface.
+type MockspendingControlsGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockspendingControlsGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockspendingControlsGetterMockRecorder is the mock recorder for MockspendingControlsGetter.
+type MockspendingControlsGetterMockRecorder struct {
+	mock *MockspendingControlsGetter
+}
+
+// NewMockspendingControlsGetter creates a new mock instance.
+func NewMockspendingControlsGetter(ctrl *gomock.Controller) *MockspendingControlsGetter {
+	mock := &MockspendingControlsGetter{ctrl: ctrl}
+	mock.recorder = &MockspendingControlsGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockspendingControlsGetter) EXPECT() *MockspendingControlsGetterMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockspendingControlsGetter) Execute(ctx context.Context, accountID int64) (*domain.SpendingControls, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, accountID)
+	ret0, _ := ret[0].(*domain.SpendingControls)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockspendingControlsGetterMockRecorder) Execute(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockspendingControlsGetter)(nil).Execute), ctx, accountID)
+}
+
+// MockspendingControlsUpdater is a mock of spendingControlsUpdater interface.
+type MockspendingControlsUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockspendingControlsUpdaterMockRecorder
+	isgomock struct{}
+}
+
+// MockspendingControlsUpdaterMockRecorder is the mock recorder for MockspendingControlsUpdater.
+type MockspendingControlsUpdaterMockRecorder struct {
+	mock *MockspendingControlsUpdater
+}
+
+// NewMockspendingControlsUpdater creates a new mock instance.
+func NewMockspendingControlsUpdater(ctrl *gomock.Controller) *MockspendingControlsUpdater {
+	mock := &MockspendingControlsUpdater{ctrl: ctrl}
+	mock.recorder = &MockspendingControlsUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockspendingControlsUpdater) EXPECT() *MockspendingControlsUpdaterMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockspendingControlsUpdater) Execute(ctx context.Context, accountID int64, blockedMCCs []string, maxTransactionAmount float64, allowedOperationTypes []int, timeWindows []domain.TimeWindow, timezone string) (*domain.SpendingControls, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, accountID, blockedMCCs, maxTransactionAmount, allowedOperationTypes, timeWindows, timezone)
+	ret0, _ := ret[0].(*domain.SpendingControls)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockspendingControlsUpdaterMockRecorder) Execute(ctx, accountID, blockedMCCs, maxTransactionAmount, allowedOperationTypes, timeWindows, timezone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockspendingControlsUpdater)(nil).Execute), ctx, accountID, blockedMCCs, maxTransactionAmount, allowedOperationTypes, timeWindows, timezone)
+}
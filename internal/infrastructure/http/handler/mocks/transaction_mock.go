@@ -42,16 +42,16 @@ func (m *MocktransactionCreator) EXPECT() *MocktransactionCreatorMockRecorder {
 }
 
 // Execute mocks base method.
-func (m *MocktransactionCreator) Execute(ctx context.Context, accountID int64, operationTypeID int, amount float64) (*domain.Transaction, error) {
+func (m *MocktransactionCreator) Execute(ctx context.Context, accountID int64, operationTypeID int, amount float64, details domain.TransactionDetails) (*domain.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Execute", ctx, accountID, operationTypeID, amount)
+	ret := m.ctrl.Call(m, "Execute", ctx, accountID, operationTypeID, amount, details)
 	ret0, _ := ret[0].(*domain.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Execute indicates an expected call of Execute.
-func (mr *MocktransactionCreatorMockRecorder) Execute(ctx, accountID, operationTypeID, amount any) *gomock.Call {
+func (mr *MocktransactionCreatorMockRecorder) Execute(ctx, accountID, operationTypeID, amount, details any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MocktransactionCreator)(nil).Execute), ctx, accountID, operationTypeID, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MocktransactionCreator)(nil).Execute), ctx, accountID, operationTypeID, amount, details)
 }
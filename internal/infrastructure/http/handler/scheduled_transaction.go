@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/response"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+//go:generate mockgen -source=scheduled_transaction.go -destination=mocks/scheduled_transaction_mock.go -package=mocks
+type scheduledTransactionCreator interface {
+	Execute(ctx context.Context, accountID int64, definition domain.ScheduleDefinition) (*domain.ScheduledTransaction, error)
+}
+
+type scheduledTransactionLister interface {
+	Execute(ctx context.Context, accountID int64) ([]*domain.ScheduledTransaction, error)
+}
+
+type scheduledTransactionGetter interface {
+	Execute(ctx context.Context, ID int64) (*domain.ScheduledTransaction, error)
+}
+
+type scheduledTransactionUpdater interface {
+	Execute(ctx context.Context, ID int64, definition domain.ScheduleDefinition) (*domain.ScheduledTransaction, error)
+}
+
+type scheduledTransactionCanceller interface {
+	Execute(ctx context.Context, ID int64) (*domain.ScheduledTransaction, error)
+}
+
+type ScheduledTransactionHandler struct {
+	createSchedule scheduledTransactionCreator
+	listSchedules  scheduledTransactionLister
+	getSchedule    scheduledTransactionGetter
+	updateSchedule scheduledTransactionUpdater
+	cancelSchedule scheduledTransactionCanceller
+}
+
+func NewScheduledTransactionHandler(
+	createSchedule scheduledTransactionCreator,
+	listSchedules scheduledTransactionLister,
+	getSchedule scheduledTransactionGetter,
+	updateSchedule scheduledTransactionUpdater,
+	cancelSchedule scheduledTransactionCanceller,
+) *ScheduledTransactionHandler {
+	return &ScheduledTransactionHandler{
+		createSchedule: createSchedule,
+		listSchedules:  listSchedules,
+		getSchedule:    getSchedule,
+		updateSchedule: updateSchedule,
+		cancelSchedule: cancelSchedule,
+	}
+}
+
+func (h *ScheduledTransactionHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := strconv.ParseInt(r.PathValue("accountId"), 10, 64)
+	if err != nil {
+		response.InvalidFields(w, response.Invalid("accountId", "invalid account id"))
+		return
+	}
+
+	definition, ok := decodeScheduleDefinition(w, r)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.createSchedule.Execute(ctx, accountID, definition)
+	if err != nil {
+		logger.Error(ctx, "failed to create scheduled transaction",
+			slog.Int64("account_id", accountID),
+			slog.String("error", err.Error()),
+		)
+		response.HandleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, toScheduledTransactionResponse(schedule))
+}
+
+func (h *ScheduledTransactionHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := strconv.ParseInt(r.PathValue("accountId"), 10, 64)
+	if err != nil {
+		response.InvalidFields(w, response.Invalid("accountId", "invalid account id"))
+		return
+	}
+
+	schedules, err := h.listSchedules.Execute(ctx, accountID)
+	if err != nil {
+		logger.Error(ctx, "failed to list scheduled transactions",
+			slog.Int64("account_id", accountID),
+			slog.String("error", err.Error()),
+		)
+		response.HandleError(w, err)
+		return
+	}
+
+	resp := make([]dto.ScheduledTransactionResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		resp = append(resp, toScheduledTransactionResponse(schedule))
+	}
+
+	response.JSON(w, http.StatusOK, resp)
+}
+
+func (h *ScheduledTransactionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	scheduleID, err := strconv.ParseInt(r.PathValue("scheduledTransactionId"), 10, 64)
+	if err != nil {
+		response.InvalidFields(w, response.Invalid("scheduledTransactionId", "invalid scheduled transaction id"))
+		return
+	}
+
+	schedule, err := h.getSchedule.Execute(ctx, scheduleID)
+	if err != nil {
+		logger.Error(ctx, "failed to get scheduled transaction",
+			slog.Int64("scheduled_transaction_id", scheduleID),
+			slog.String("error", err.Error()),
+		)
+		response.HandleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toScheduledTransactionResponse(schedule))
+}
+
+func (h *ScheduledTransactionHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	scheduleID, err := strconv.ParseInt(r.PathValue("scheduledTransactionId"), 10, 64)
+	if err != nil {
+		response.InvalidFields(w, response.Invalid("scheduledTransactionId", "invalid scheduled transaction id"))
+		return
+	}
+
+	definition, ok := decodeScheduleDefinition(w, r)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.updateSchedule.Execute(ctx, scheduleID, definition)
+	if err != nil {
+		logger.Error(ctx, "failed to update scheduled transaction",
+			slog.Int64("scheduled_transaction_id", scheduleID),
+			slog.String("error", err.Error()),
+		)
+		response.HandleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toScheduledTransactionResponse(schedule))
+}
+
+func (h *ScheduledTransactionHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	scheduleID, err := strconv.ParseInt(r.PathValue("scheduledTransactionId"), 10, 64)
+	if err != nil {
+		response.InvalidFields(w, response.Invalid("scheduledTransactionId", "invalid scheduled transaction id"))
+		return
+	}
+
+	schedule, err := h.cancelSchedule.Execute(ctx, scheduleID)
+	if err != nil {
+		logger.Error(ctx, "failed to cancel scheduled transaction",
+			slog.Int64("scheduled_transaction_id", scheduleID),
+			slog.String("error", err.Error()),
+		)
+		response.HandleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toScheduledTransactionResponse(schedule))
+}
+
+// decodeScheduleDefinition writes a bad request response and returns false when
+// the body is not a usable schedule.
+func decodeScheduleDefinition(w http.ResponseWriter, r *http.Request) (domain.ScheduleDefinition, bool) {
+	var req dto.ScheduledTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error(r.Context(), "failed to decode request body",
+			slog.String("error", err.Error()),
+		)
+		response.Error(w, http.StatusBadRequest, "invalid_body", "invalid request body")
+		return domain.ScheduleDefinition{}, false
+	}
+
+	var fieldErrors []response.FieldError
+	if req.OperationTypeID == 0 {
+		fieldErrors = append(fieldErrors, response.Required("operation_type_id"))
+	}
+	if req.Recurrence == "" {
+		fieldErrors = append(fieldErrors, response.Required("recurrence"))
+	}
+	if len(fieldErrors) > 0 {
+		response.InvalidFields(w, fieldErrors...)
+		return domain.ScheduleDefinition{}, false
+	}
+
+	definition := domain.ScheduleDefinition{
+		OperationTypeID: domain.OperationType(req.OperationTypeID),
+		Amount:          req.Amount,
+		AmountType:      domain.ScheduleAmountType(req.AmountType),
+		Recurrence:      domain.ScheduleRecurrence(req.Recurrence),
+		DayOfMonth:      req.DayOfMonth,
+		Description:     req.Description,
+	}
+	if req.StartsAt != nil {
+		definition.StartsAt = *req.StartsAt
+	}
+
+	return definition, true
+}
+
+func toScheduledTransactionResponse(schedule *domain.ScheduledTransaction) dto.ScheduledTransactionResponse {
+	resp := dto.ScheduledTransactionResponse{
+		ScheduledTransactionID: schedule.ID,
+		AccountID:              schedule.AccountID,
+		OperationTypeID:        int(schedule.OperationTypeID),
+		Amount:                 schedule.Amount,
+		AmountType:             string(schedule.AmountType),
+		Recurrence:             string(schedule.Recurrence),
+		DayOfMonth:             schedule.DayOfMonth,
+		StartsAt:               schedule.StartsAt,
+		Description:            schedule.Description,
+		Status:                 string(schedule.Status),
+		NextRunAt:              schedule.NextRunAt,
+		LastTransactionID:      schedule.LastTransactionID,
+		LastError:              schedule.LastError,
+	}
+	if !schedule.LastRunAt.IsZero() {
+		resp.LastRunAt = &schedule.LastRunAt
+	}
+
+	return resp
+}
@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/handler/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type scheduledTransactionMocks struct {
+	creator   *mocks.MockscheduledTransactionCreator
+	lister    *mocks.MockscheduledTransactionLister
+	getter    *mocks.MockscheduledTransactionGetter
+	updater   *mocks.MockscheduledTransactionUpdater
+	canceller *mocks.MockscheduledTransactionCanceller
+}
+
+func newScheduledTransactionHandler(ctrl *gomock.Controller) (*ScheduledTransactionHandler, scheduledTransactionMocks) {
+	m := scheduledTransactionMocks{
+		creator:   mocks.NewMockscheduledTransactionCreator(ctrl),
+		lister:    mocks.NewMockscheduledTransactionLister(ctrl),
+		getter:    mocks.NewMockscheduledTransactionGetter(ctrl),
+		updater:   mocks.NewMockscheduledTransactionUpdater(ctrl),
+		canceller: mocks.NewMockscheduledTransactionCanceller(ctrl),
+	}
+	return NewScheduledTransactionHandler(m.creator, m.lister, m.getter, m.updater, m.canceller), m
+}
+
+func TestScheduledTransactionHandler_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, m := newScheduledTransactionHandler(ctrl)
+
+	t.Run("creates scheduled transaction successfully", func(t *testing.T) {
+		nextRunAt := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+		m.creator.EXPECT().
+			Execute(gomock.Any(), int64(1), domain.ScheduleDefinition{
+				OperationTypeID: domain.OperationTypePayment,
+				AmountType:      domain.ScheduleAmountFullBalance,
+				Recurrence:      domain.ScheduleRecurrenceMonthly,
+				DayOfMonth:      10,
+			}).
+			Return(&domain.ScheduledTransaction{
+				ID:        5,
+				AccountID: 1,
+				ScheduleDefinition: domain.ScheduleDefinition{
+					OperationTypeID: domain.OperationTypePayment,
+					AmountType:      domain.ScheduleAmountFullBalance,
+					Recurrence:      domain.ScheduleRecurrenceMonthly,
+					DayOfMonth:      10,
+				},
+				Status:    domain.ScheduleStatusActive,
+				NextRunAt: nextRunAt,
+			}, nil)
+
+		body := bytes.NewBufferString(`{"operation_type_id": 4, "amount_type": "full_balance", "recurrence": "monthly", "day_of_month": 10}`)
+		req := httptest.NewRequest(http.MethodPost, "/accounts/1/scheduled-transactions", body)
+		req.SetPathValue("accountId", "1")
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var response dto.ScheduledTransactionResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+
+		assert.Equal(t, int64(5), response.ScheduledTransactionID)
+		assert.Equal(t, "active", response.Status)
+		assert.Equal(t, "full_balance", response.AmountType)
+		assert.Equal(t, nextRunAt, response.NextRunAt)
+		assert.Nil(t, response.LastRunAt)
+	})
+
+	t.Run("returns bad request when recurrence is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/accounts/1/scheduled-transactions", bytes.NewBufferString(`{"operation_type_id": 4, "amount": 10}`))
+		req.SetPathValue("accountId", "1")
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns bad request when operation type is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/accounts/1/scheduled-transactions", bytes.NewBufferString(`{"recurrence": "once", "amount": 10}`))
+		req.SetPathValue("accountId", "1")
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns unprocessable entity when definition is invalid", func(t *testing.T) {
+		m.creator.EXPECT().Execute(gomock.Any(), int64(1), gomock.Any()).Return(nil, domain.ErrInvalidScheduleDayOfMonth)
+
+		body := bytes.NewBufferString(`{"operation_type_id": 4, "amount": 10, "recurrence": "monthly", "day_of_month": 40}`)
+		req := httptest.NewRequest(http.MethodPost, "/accounts/1/scheduled-transactions", body)
+		req.SetPathValue("accountId", "1")
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
+
+func TestScheduledTransactionHandler_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, m := newScheduledTransactionHandler(ctrl)
+
+	t.Run("lists scheduled transactions of the account", func(t *testing.T) {
+		m.lister.EXPECT().Execute(gomock.Any(), int64(1)).Return([]*domain.ScheduledTransaction{{ID: 1}, {ID: 2}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/accounts/1/scheduled-transactions", nil)
+		req.SetPathValue("accountId", "1")
+		rec := httptest.NewRecorder()
+
+		handler.List(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response []dto.ScheduledTransactionResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+
+		assert.Len(t, response, 2)
+	})
+
+	t.Run("returns not found when account does not exist", func(t *testing.T) {
+		m.lister.EXPECT().Execute(gomock.Any(), int64(999)).Return(nil, domain.ErrAccountNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/accounts/999/scheduled-transactions", nil)
+		req.SetPathValue("accountId", "999")
+		rec := httptest.NewRecorder()
+
+		handler.List(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestScheduledTransactionHandler_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, m := newScheduledTransactionHandler(ctrl)
+
+	t.Run("returns scheduled transaction with its last run", func(t *testing.T) {
+		lastRunAt := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+		m.getter.EXPECT().Execute(gomock.Any(), int64(3)).Return(&domain.ScheduledTransaction{ID: 3, LastRunAt: lastRunAt, LastTransactionID: 9}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/scheduled-transactions/3", nil)
+		req.SetPathValue("scheduledTransactionId", "3")
+		rec := httptest.NewRecorder()
+
+		handler.Get(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response dto.ScheduledTransactionResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+
+		assert.Equal(t, lastRunAt, *response.LastRunAt)
+		assert.Equal(t, int64(9), response.LastTransactionID)
+	})
+
+	t.Run("returns bad request when id is invalid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/scheduled-transactions/abc", nil)
+		req.SetPathValue("scheduledTransactionId", "abc")
+		rec := httptest.NewRecorder()
+
+		handler.Get(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns not found when scheduled transaction does not exist", func(t *testing.T) {
+		m.getter.EXPECT().Execute(gomock.Any(), int64(999)).Return(nil, domain.ErrScheduledTransactionNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/scheduled-transactions/999", nil)
+		req.SetPathValue("scheduledTransactionId", "999")
+		rec := httptest.NewRecorder()
+
+		handler.Get(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestScheduledTransactionHandler_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, m := newScheduledTransactionHandler(ctrl)
+
+	t.Run("updates scheduled transaction successfully", func(t *testing.T) {
+		startsAt := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+		m.updater.EXPECT().
+			Execute(gomock.Any(), int64(3), domain.ScheduleDefinition{
+				OperationTypeID: domain.OperationTypePurchase,
+				Amount:          25.0,
+				AmountType:      domain.ScheduleAmountFixed,
+				Recurrence:      domain.ScheduleRecurrenceOnce,
+				StartsAt:        startsAt,
+			}).
+			Return(&domain.ScheduledTransaction{ID: 3, NextRunAt: startsAt}, nil)
+
+		body := bytes.NewBufferString(`{"operation_type_id": 1, "amount": 25, "amount_type": "fixed", "recurrence": "once", "starts_at": "2024-06-01T08:00:00Z"}`)
+		req := httptest.NewRequest(http.MethodPut, "/scheduled-transactions/3", body)
+		req.SetPathValue("scheduledTransactionId", "3")
+		rec := httptest.NewRecorder()
+
+		handler.Update(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("returns conflict when schedule is no longer active", func(t *testing.T) {
+		m.updater.EXPECT().Execute(gomock.Any(), int64(4), gomock.Any()).Return(nil, domain.ErrScheduleNotActive)
+
+		body := bytes.NewBufferString(`{"operation_type_id": 4, "amount": 25, "recurrence": "once"}`)
+		req := httptest.NewRequest(http.MethodPut, "/scheduled-transactions/4", body)
+		req.SetPathValue("scheduledTransactionId", "4")
+		rec := httptest.NewRecorder()
+
+		handler.Update(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func TestScheduledTransactionHandler_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, m := newScheduledTransactionHandler(ctrl)
+
+	t.Run("cancels scheduled transaction successfully", func(t *testing.T) {
+		m.canceller.EXPECT().Execute(gomock.Any(), int64(3)).Return(&domain.ScheduledTransaction{ID: 3, Status: domain.ScheduleStatusCancelled}, nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/scheduled-transactions/3", nil)
+		req.SetPathValue("scheduledTransactionId", "3")
+		rec := httptest.NewRecorder()
+
+		handler.Cancel(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response dto.ScheduledTransactionResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+
+		assert.Equal(t, "cancelled", response.Status)
+	})
+
+	t.Run("returns not found when scheduled transaction does not exist", func(t *testing.T) {
+		m.canceller.EXPECT().Execute(gomock.Any(), int64(999)).Return(nil, domain.ErrScheduledTransactionNotFound)
+
+		req := httptest.NewRequest(http.MethodDelete, "/scheduled-transactions/999", nil)
+		req.SetPathValue("scheduledTransactionId", "999")
+		rec := httptest.NewRecorder()
+
+		handler.Cancel(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/response"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+//go:generate mockgen -source=spending_controls.go -destination=mocks/spending_controls_mock.go -package=mocks
+type spendingControlsGetter interface {
+	Execute(ctx context.Context, accountID int64) (*domain.SpendingControls, error)
+}
+
+type spendingControlsUpdater interface {
+	Execute(
+		ctx context.Context,
+		accountID int64,
+		blockedMCCs []string,
+		maxTransactionAmount float64,
+		allowedOperationTypes []int,
+		timeWindows []domain.TimeWindow,
+		timezone string,
+	) (*domain.SpendingControls, error)
+}
+
+type SpendingControlsHandler struct {
+	getControls    spendingControlsGetter
+	updateControls spendingControlsUpdater
+}
+
+func NewSpendingControlsHandler(getControls spendingControlsGetter, updateControls spendingControlsUpdater) *SpendingControlsHandler {
+	return &SpendingControlsHandler{
+		getControls:    getControls,
+		updateControls: updateControls,
+	}
+}
+
+func (h *SpendingControlsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := strconv.ParseInt(r.PathValue("accountId"), 10, 64)
+	if err != nil {
+		response.InvalidFields(w, response.Invalid("accountId", "invalid account id"))
+		return
+	}
+
+	controls, err := h.getControls.Execute(ctx, accountID)
+	if err != nil {
+		logger.Error(ctx, "failed to get spending controls",
+			slog.Int64("account_id", accountID),
+			slog.String("error", err.Error()),
+		)
+		response.HandleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toSpendingControlsResponse(controls))
+}
+
+func (h *SpendingControlsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := strconv.ParseInt(r.PathValue("accountId"), 10, 64)
+	if err != nil {
+		response.InvalidFields(w, response.Invalid("accountId", "invalid account id"))
+		return
+	}
+
+	var req dto.UpdateSpendingControlsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error(ctx, "failed to decode request body",
+			slog.String("error", err.Error()),
+		)
+		response.Error(w, http.StatusBadRequest, "invalid_body", "invalid request body")
+		return
+	}
+
+	timeWindows := make([]domain.TimeWindow, 0, len(req.TimeWindows))
+	for _, window := range req.TimeWindows {
+		timeWindows = append(timeWindows, domain.TimeWindow{Start: window.Start, End: window.End})
+	}
+
+	controls, err := h.updateControls.Execute(
+		ctx,
+		accountID,
+		req.BlockedMCCs,
+		req.MaxTransactionAmount,
+		req.AllowedOperationTypes,
+		timeWindows,
+		req.Timezone,
+	)
+	if err != nil {
+		logger.Error(ctx, "failed to update spending controls",
+			slog.Int64("account_id", accountID),
+			slog.String("error", err.Error()),
+		)
+		response.HandleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toSpendingControlsResponse(controls))
+}
+
+func toSpendingControlsResponse(controls *domain.SpendingControls) dto.SpendingControlsResponse {
+	blockedMCCs := controls.BlockedMCCs
+	if blockedMCCs == nil {
+		blockedMCCs = []string{}
+	}
+
+	operationTypes := make([]int, 0, len(controls.AllowedOperationTypes))
+	for _, operationType := range controls.AllowedOperationTypes {
+		operationTypes = append(operationTypes, int(operationType))
+	}
+
+	timeWindows := make([]dto.TimeWindow, 0, len(controls.TimeWindows))
+	for _, window := range controls.TimeWindows {
+		timeWindows = append(timeWindows, dto.TimeWindow{Start: window.Start, End: window.End})
+	}
+
+	return dto.SpendingControlsResponse{
+		AccountID:             controls.AccountID,
+		BlockedMCCs:           blockedMCCs,
+		MaxTransactionAmount:  controls.MaxTransactionAmount,
+		AllowedOperationTypes: operationTypes,
+		TimeWindows:           timeWindows,
+		Timezone:              controls.Timezone,
+	}
+}
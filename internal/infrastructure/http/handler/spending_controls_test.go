@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/handler/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSpendingControlsHandler_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGetter := mocks.NewMockspendingControlsGetter(ctrl)
+	mockUpdater := mocks.NewMockspendingControlsUpdater(ctrl)
+	handler := NewSpendingControlsHandler(mockGetter, mockUpdater)
+
+	t.Run("retrieves spending controls successfully", func(t *testing.T) {
+		mockGetter.EXPECT().
+			Execute(gomock.Any(), int64(1)).
+			Return(&domain.SpendingControls{AccountID: 1, BlockedMCCs: []string{"7995"}, Timezone: "UTC"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/accounts/1/controls", nil)
+		req.SetPathValue("accountId", "1")
+		rec := httptest.NewRecorder()
+
+		handler.Get(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response dto.SpendingControlsResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+
+		assert.Equal(t, int64(1), response.AccountID)
+		assert.Equal(t, []string{"7995"}, response.BlockedMCCs)
+		assert.Equal(t, []int{}, response.AllowedOperationTypes)
+	})
+
+	t.Run("returns bad request when account id is invalid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/accounts/invalid/controls", nil)
+		req.SetPathValue("accountId", "invalid")
+		rec := httptest.NewRecorder()
+
+		handler.Get(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns not found when account does not exist", func(t *testing.T) {
+		mockGetter.EXPECT().
+			Execute(gomock.Any(), int64(999)).
+			Return(nil, domain.ErrAccountNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/accounts/999/controls", nil)
+		req.SetPathValue("accountId", "999")
+		rec := httptest.NewRecorder()
+
+		handler.Get(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestSpendingControlsHandler_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGetter := mocks.NewMockspendingControlsGetter(ctrl)
+	mockUpdater := mocks.NewMockspendingControlsUpdater(ctrl)
+	handler := NewSpendingControlsHandler(mockGetter, mockUpdater)
+
+	t.Run("updates spending controls successfully", func(t *testing.T) {
+		windows := []domain.TimeWindow{{Start: "08:00", End: "18:00"}}
+
+		mockUpdater.EXPECT().
+			Execute(gomock.Any(), int64(1), []string{"7995"}, 500.0, []int{1}, windows, "UTC").
+			Return(&domain.SpendingControls{
+				AccountID:             1,
+				BlockedMCCs:           []string{"7995"},
+				MaxTransactionAmount:  500,
+				AllowedOperationTypes: []domain.OperationType{domain.OperationTypePurchase},
+				TimeWindows:           windows,
+				Timezone:              "UTC",
+			}, nil)
+
+		body := bytes.NewBufferString(`{"blocked_mccs": ["7995"], "max_transaction_amount": 500, "allowed_operation_types": [1], "time_windows": [{"start": "08:00", "end": "18:00"}], "timezone": "UTC"}`)
+		req := httptest.NewRequest(http.MethodPut, "/accounts/1/controls", body)
+		req.SetPathValue("accountId", "1")
+		rec := httptest.NewRecorder()
+
+		handler.Update(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response dto.SpendingControlsResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+
+		assert.Equal(t, 500.0, response.MaxTransactionAmount)
+		assert.Equal(t, []int{1}, response.AllowedOperationTypes)
+		assert.Equal(t, []dto.TimeWindow{{Start: "08:00", End: "18:00"}}, response.TimeWindows)
+	})
+
+	t.Run("returns bad request when body is invalid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/accounts/1/controls", bytes.NewBufferString(`invalid json`))
+		req.SetPathValue("accountId", "1")
+		rec := httptest.NewRecorder()
+
+		handler.Update(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("returns unprocessable entity when controls are invalid", func(t *testing.T) {
+		mockUpdater.EXPECT().
+			Execute(gomock.Any(), int64(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "Mars/Olympus").
+			Return(nil, domain.ErrInvalidTimezone)
+
+		req := httptest.NewRequest(http.MethodPut, "/accounts/1/controls", bytes.NewBufferString(`{"timezone": "Mars/Olympus"}`))
+		req.SetPathValue("accountId", "1")
+		rec := httptest.NewRecorder()
+
+		handler.Update(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
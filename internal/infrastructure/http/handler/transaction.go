@@ -14,7 +14,7 @@ import (
 
 //go:generate mockgen -source=transaction.go -destination=mocks/transaction_mock.go -package=mocks
 type transactionCreator interface {
-	Execute(ctx context.Context, accountID int64, operationTypeID int, amount float64) (*domain.Transaction, error)
+	Execute(ctx context.Context, accountID int64, operationTypeID int, amount float64, details domain.TransactionDetails) (*domain.Transaction, error)
 }
 
 type TransactionHandler struct {
@@ -35,29 +35,46 @@ func (h *TransactionHandler) Create(w http.ResponseWriter, r *http.Request) {
 		logger.Error(ctx, "failed to decode request body",
 			slog.String("error", err.Error()),
 		)
-		response.Error(w, http.StatusBadRequest, "invalid request body")
+		response.Error(w, http.StatusBadRequest, "invalid_body", "invalid request body")
 		return
 	}
 
+	var fieldErrors []response.FieldError
 	if req.AccountID == 0 {
-		response.Error(w, http.StatusBadRequest, "account_id is required")
-		return
+		fieldErrors = append(fieldErrors, response.Required("account_id"))
 	}
 	if req.OperationTypeID == 0 {
-		response.Error(w, http.StatusBadRequest, "operation_type_id is required")
-		return
+		fieldErrors = append(fieldErrors, response.Required("operation_type_id"))
 	}
 	if req.Amount == 0 {
-		response.Error(w, http.StatusBadRequest, "amount is required")
+		fieldErrors = append(fieldErrors, response.Required("amount"))
+	}
+	if len(fieldErrors) > 0 {
+		response.InvalidFields(w, fieldErrors...)
 		return
 	}
+	if domain.OperationType(req.OperationTypeID).IsAdjustment() {
+		response.HandleError(w, domain.ErrInvalidOperationType)
+		return
+	}
+
+	details := domain.TransactionDetails{
+		CardID:               req.CardID,
+		ExternalID:           req.ExternalID,
+		Description:          req.Description,
+		MerchantName:         req.MerchantName,
+		MerchantCategoryCode: req.MerchantCategoryCode,
+		Currency:             req.Currency,
+		Metadata:             req.Metadata,
+	}
 
-	transaction, err := h.createTransaction.Execute(ctx, req.AccountID, req.OperationTypeID, req.Amount)
+	transaction, err := h.createTransaction.Execute(ctx, req.AccountID, req.OperationTypeID, req.Amount, details)
 	if err != nil {
 		logger.Error(ctx, "failed to create transaction",
 			slog.Int64("account_id", req.AccountID),
 			slog.Int("operation_type_id", req.OperationTypeID),
 			slog.Float64("amount", req.Amount),
+			slog.String("external_id", req.ExternalID),
 			slog.String("error", err.Error()),
 		)
 		response.HandleError(w, err)
@@ -65,10 +82,20 @@ func (h *TransactionHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response.JSON(w, http.StatusCreated, dto.CreateTransactionResponse{
-		TransactionID:   transaction.ID,
-		AccountID:       transaction.AccountID,
-		OperationTypeID: int(transaction.OperationTypeID),
-		Amount:          transaction.Amount,
-		Balance:         transaction.Balance,
+		TransactionID:        transaction.ID,
+		AccountID:            transaction.AccountID,
+		OperationTypeID:      int(transaction.OperationTypeID),
+		Amount:               transaction.Amount,
+		Balance:              transaction.Balance,
+		OriginalAmount:       transaction.OriginalAmount,
+		SettlementCurrency:   transaction.SettlementCurrency,
+		FXRate:               transaction.FXRate,
+		CardID:               transaction.CardID,
+		ExternalID:           transaction.ExternalID,
+		Description:          transaction.Description,
+		MerchantName:         transaction.MerchantName,
+		MerchantCategoryCode: transaction.MerchantCategoryCode,
+		Currency:             transaction.Currency,
+		Metadata:             transaction.Metadata,
 	})
 }
@@ -33,7 +33,7 @@ func TestTransactionHandler_Create(t *testing.T) {
 		}
 
 		mockCreator.EXPECT().
-			Execute(gomock.Any(), int64(1), 4, 123.45).
+			Execute(gomock.Any(), int64(1), 4, 123.45, domain.TransactionDetails{}).
 			Return(expectedTransaction, nil)
 
 		body := bytes.NewBufferString(`{"account_id": 1, "operation_type_id": 4, "amount": 123.45}`)
@@ -65,7 +65,7 @@ func TestTransactionHandler_Create(t *testing.T) {
 
 	t.Run("returns not found when account does not exist", func(t *testing.T) {
 		mockCreator.EXPECT().
-			Execute(gomock.Any(), int64(999), 1, 50.0).
+			Execute(gomock.Any(), int64(999), 1, 50.0, domain.TransactionDetails{}).
 			Return(nil, domain.ErrAccountNotFound)
 
 		body := bytes.NewBufferString(`{"account_id": 999, "operation_type_id": 1, "amount": 50.0}`)
@@ -79,7 +79,7 @@ func TestTransactionHandler_Create(t *testing.T) {
 
 	t.Run("returns unprocessable entity when operation type is invalid", func(t *testing.T) {
 		mockCreator.EXPECT().
-			Execute(gomock.Any(), int64(1), 99, 50.0).
+			Execute(gomock.Any(), int64(1), 99, 50.0, domain.TransactionDetails{}).
 			Return(nil, domain.ErrInvalidOperationType)
 
 		body := bytes.NewBufferString(`{"account_id": 1, "operation_type_id": 99, "amount": 50.0}`)
@@ -91,6 +91,16 @@ func TestTransactionHandler_Create(t *testing.T) {
 		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
 	})
 
+	t.Run("returns unprocessable entity when operation type is an adjustment", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"account_id": 1, "operation_type_id": 5, "amount": 50.0}`)
+		req := httptest.NewRequest(http.MethodPost, "/transactions", body)
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
 	t.Run("returns bad request when account_id is missing", func(t *testing.T) {
 		body := bytes.NewBufferString(`{"operation_type_id": 1, "amount": 50.0}`)
 		req := httptest.NewRequest(http.MethodPost, "/transactions", body)
@@ -121,9 +131,30 @@ func TestTransactionHandler_Create(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 	})
 
+	t.Run("reports every missing field", func(t *testing.T) {
+		body := bytes.NewBufferString(`{"account_id": 1}`)
+		req := httptest.NewRequest(http.MethodPost, "/transactions", body)
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.JSONEq(t, `{
+			"type": "urn:pismo:problem:invalid_request",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "request has missing or invalid fields",
+			"code": "invalid_request",
+			"errors": [
+				{"field": "operation_type_id", "code": "required", "message": "operation_type_id is required"},
+				{"field": "amount", "code": "required", "message": "amount is required"}
+			]
+		}`, rec.Body.String())
+	})
+
 	t.Run("returns internal server error when error is unknown", func(t *testing.T) {
 		mockCreator.EXPECT().
-			Execute(gomock.Any(), int64(1), 1, 50.0).
+			Execute(gomock.Any(), int64(1), 1, 50.0, domain.TransactionDetails{}).
 			Return(nil, errors.New("database error"))
 
 		body := bytes.NewBufferString(`{"account_id": 1, "operation_type_id": 1, "amount": 50.0}`)
@@ -134,4 +165,40 @@ func TestTransactionHandler_Create(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	})
+
+	t.Run("passes transaction details to use case and returns them", func(t *testing.T) {
+		details := domain.TransactionDetails{
+			ExternalID:           "auth-123",
+			MerchantName:         "Coffee Shop",
+			MerchantCategoryCode: "5814",
+			Currency:             "BRL",
+			Metadata:             map[string]any{"terminal": "T1"},
+		}
+
+		mockCreator.EXPECT().
+			Execute(gomock.Any(), int64(1), 1, 10.0, details).
+			Return(&domain.Transaction{
+				ID:                 5,
+				AccountID:          1,
+				OperationTypeID:    domain.OperationTypePurchase,
+				Amount:             -10.0,
+				TransactionDetails: details,
+			}, nil)
+
+		body := bytes.NewBufferString(`{"account_id": 1, "operation_type_id": 1, "amount": 10.0, "external_id": "auth-123", "merchant_name": "Coffee Shop", "merchant_category_code": "5814", "currency": "BRL", "metadata": {"terminal": "T1"}}`)
+		req := httptest.NewRequest(http.MethodPost, "/transactions", body)
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var response dto.CreateTransactionResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+
+		assert.Equal(t, "auth-123", response.ExternalID)
+		assert.Equal(t, "5814", response.MerchantCategoryCode)
+		assert.Equal(t, "BRL", response.Currency)
+		assert.Equal(t, "T1", response.Metadata["terminal"])
+	})
 }
@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/response"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+//go:generate mockgen -source=auth.go -destination=mocks/auth_mock.go -package=mocks
+type apiKeyAuthenticator interface {
+	Execute(ctx context.Context, key string) (*domain.APIKey, error)
+}
+
+type tokenVerifier interface {
+	Verify(ctx context.Context, token string) (*domain.Principal, error)
+}
+
+// Auth authenticates clients by API key or JWT and checks their scopes per
+// route. A nil *Auth disables authentication, a nil verifier disables JWTs.
+type Auth struct {
+	authenticator apiKeyAuthenticator
+	verifier      tokenVerifier
+}
+
+func NewAuth(authenticator apiKeyAuthenticator, verifier tokenVerifier) *Auth {
+	return &Auth{authenticator: authenticator, verifier: verifier}
+}
+
+// Authenticate resolves the credentials sent in the Authorization header, as a
+// bearer token, or in X-API-Key and stores the principal in the request
+// context. Bearer values shaped like a JWT are verified as tokens, anything
+// else as an API key. Requests without valid credentials go through
+// unauthenticated so that Require can reject them where a scope is needed.
+func (a *Auth) Authenticate(next http.Handler) http.Handler {
+	if a == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		credentials, isToken := credentialsFromRequest(r)
+		if credentials == "" || (isToken && a.verifier == nil) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		principal, err := a.principal(ctx, credentials, isToken)
+		if errors.Is(err, domain.ErrInvalidAPIKey) || errors.Is(err, domain.ErrInvalidToken) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err != nil {
+			logger.Error(ctx, "failed to authenticate request", slog.String("error", err.Error()))
+			response.HandleError(w, err)
+			return
+		}
+
+		ctx = domain.ContextWithPrincipal(ctx, principal)
+		ctx = logger.WithClientID(ctx, principal.ClientID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Resolve authenticates the credentials of an X-API-Key value or an
+// Authorization header for the gRPC API, which reuses the same keys and
+// tokens. Missing credentials are reported like invalid ones. A nil *Auth
+// returns no principal and no error, leaving every call unauthenticated.
+func (a *Auth) Resolve(ctx context.Context, apiKey, authorization string) (*domain.Principal, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	credentials, isToken := credentialsFrom(apiKey, authorization)
+	if isToken && a.verifier == nil {
+		return nil, domain.ErrInvalidToken
+	}
+	if credentials == "" {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	return a.principal(ctx, credentials, isToken)
+}
+
+func (a *Auth) principal(ctx context.Context, credentials string, isToken bool) (*domain.Principal, error) {
+	if isToken {
+		return a.verifier.Verify(ctx, credentials)
+	}
+
+	apiKey, err := a.authenticator.Execute(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+	return apiKey.Principal(), nil
+}
+
+// Require only lets through clients authenticated with the given scope. On
+// routes addressed by account, clients restricted to an account can only
+// reach their own. Use cases check the accounts of the body and of the
+// resources they load against the principal left in the context.
+func (a *Auth) Require(scope domain.Scope, next http.HandlerFunc) http.HandlerFunc {
+	if a == nil {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := domain.PrincipalFromContext(r.Context())
+		if !ok {
+			authErr := domain.ErrInvalidAPIKey
+			if _, isToken := credentialsFromRequest(r); isToken {
+				authErr = domain.ErrInvalidToken
+			}
+			w.Header().Set("WWW-Authenticate", `Bearer realm="pismo"`)
+			response.Error(w, http.StatusUnauthorized, authErr.Code, authErr.Message)
+			return
+		}
+
+		if !principal.HasScope(scope) {
+			response.Error(w, http.StatusForbidden, "insufficient_scope", "missing required scope "+string(scope))
+			return
+		}
+
+		// Malformed ids are left for the handler to reject.
+		accountID, err := strconv.ParseInt(r.PathValue("accountId"), 10, 64)
+		if err == nil && !principal.CanAccessAccount(accountID) {
+			response.HandleError(w, domain.ErrAccountAccessDenied)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// credentialsFromRequest returns the credentials the client sent and whether
+// they are a JWT rather than an API key.
+func credentialsFromRequest(r *http.Request) (string, bool) {
+	return credentialsFrom(r.Header.Get("X-API-Key"), r.Header.Get("Authorization"))
+}
+
+func credentialsFrom(apiKey, authorization string) (string, bool) {
+	if apiKey != "" {
+		return apiKey, false
+	}
+
+	scheme, credentials, ok := strings.Cut(authorization, " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		credentials = strings.TrimSpace(credentials)
+		return credentials, strings.Count(credentials, ".") == 2
+	}
+
+	return "", false
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/metrics"
+)
+
+// Metrics counts the requests to the route and observes their latency,
+// labelled by the route pattern rather than the path to bound cardinality.
+func Metrics(route string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		wrapped := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next(wrapped, r)
+
+		status := strconv.Itoa(wrapped.status)
+		metrics.HTTPRequests.WithLabelValues(route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(route, status).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Run("counts requests by route and status", func(t *testing.T) {
+		// given
+		route := "GET /accounts/{accountId}"
+		before := testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(route, "404"))
+		handler := Metrics(route, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		// when
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/accounts/1", nil))
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/accounts/2", nil))
+
+		// then
+		assert.Equal(t, before+2, testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(route, "404")))
+		assert.Equal(t, 1, testutil.CollectAndCount(metrics.HTTPRequestDuration.MustCurryWith(map[string]string{"route": route})))
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/response"
 	"github.com/nubank/pismo-code-assessment/pkg/logger"
 )
 
@@ -65,9 +66,7 @@ func Recoverer(next http.Handler) http.Handler {
 					slog.String("stack", string(debug.Stack())),
 				)
 
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusInternalServerError)
-				w.Write([]byte(`{"error":"internal server error"}`))
+				response.Error(w, http.StatusInternalServerError, "internal_error", "internal server error")
 			}
 		}()
 
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auth.go
+//
+// Generated by this command:
+//
+//	mockgen -source=auth.go -destination=mocks/auth_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/nubank/pismo-code-assessment/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockapiKeyAuthenticator is a mock of apiKeyAuthenticator interface.
+type MockapiKeyAuthenticator struct {
+	ctrl     *gomock.Controller
+	recorder *MockapiKeyAuthenticatorMockRecorder
+	isgomock struct{}
+}
+
+// MockapiKeyAuthenticatorMockRecorder is the mock recorder for MockapiKeyAuthenticator.
+type MockapiKeyAuthenticatorMockRecorder struct {
+	mock *MockapiKeyAuthenticator
+}
+
+// NewMockapiKeyAuthenticator creates a new mock instance.
+func NewMockapiKeyAuthenticator(ctrl *gomock.Controller) *MockapiKeyAuthenticator {
+	mock := &MockapiKeyAuthenticator{ctrl: ctrl}
+	mock.recorder = &MockapiKeyAuthenticatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockapiKeyAuthenticator) EXPECT() *MockapiKeyAuthenticatorMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockapiKeyAuthenticator) Execute(ctx context.Context, key string) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, key)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockapiKeyAuthenticatorMockRecorder) Execute(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockapiKeyAuthenticator)(nil).Execute), ctx, key)
+}
+
+// MocktokenVerifier is a mock of tokenVerifier interface.
+type MocktokenVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MocktokenVerifierMockRecorder is the mock recorder for MocktokenVerifier.
+type MocktokenVerifierMockRecorder struct {
+	mock *MocktokenVerifier
+}
+
+// NewMocktokenVerifier creates a new mock instance.
+func NewMocktokenVerifier(ctrl *gomock.Controller) *MocktokenVerifier {
+	mock := &MocktokenVerifier{ctrl: ctrl}
+	mock.recorder = &MocktokenVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenVerifier) EXPECT() *MocktokenVerifierMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MocktokenVerifier) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, token)
+	ret0, _ := ret[0].(*domain.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MocktokenVerifierMockRecorder) Verify(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MocktokenVerifier)(nil).Verify), ctx, token)
+}
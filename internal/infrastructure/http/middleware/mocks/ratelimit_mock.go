@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ratelimit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ratelimit.go -destination=mocks/ratelimit_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	ratelimit "github.com/nubank/pismo-code-assessment/internal/infrastructure/ratelimit"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockrateLimitStore is a mock of rateLimitStore interface.
+type MockrateLimitStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockrateLimitStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockrateLimitStoreMockRecorder is the mock recorder for MockrateLimitStore.
+type MockrateLimitStoreMockRecorder struct {
+	mock *MockrateLimitStore
+}
+
+// NewMockrateLimitStore creates a new mock instance.
+func NewMockrateLimitStore(ctrl *gomock.Controller) *MockrateLimitStore {
+	mock := &MockrateLimitStore{ctrl: ctrl}
+	mock.recorder = &MockrateLimitStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrateLimitStore) EXPECT() *MockrateLimitStoreMockRecorder {
+	return m.recorder
+}
+
+// Take mocks base method.
+func (m *MockrateLimitStore) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, key, limit, now)
+	ret0, _ := ret[0].(ratelimit.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockrateLimitStoreMockRecorder) Take(ctx, key, limit, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockrateLimitStore)(nil).Take), ctx, key, limit, now)
+}
@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/response"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/ratelimit"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+// maxPeekedBody bounds how much of a request body is read to find its account.
+const maxPeekedBody = 1 << 20
+
+//go:generate mockgen -source=ratelimit.go -destination=mocks/ratelimit_mock.go -package=mocks
+type rateLimitStore interface {
+	Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error)
+}
+
+// RateLimiter applies the rate limit policies of each route. A nil
+// *RateLimiter disables rate limiting.
+type RateLimiter struct {
+	store    rateLimitStore
+	policies map[string][]ratelimit.Policy
+	now      func() time.Time
+}
+
+func NewRateLimiter(store rateLimitStore, policies []ratelimit.Policy) *RateLimiter {
+	byRoute := make(map[string][]ratelimit.Policy)
+	for _, policy := range policies {
+		byRoute[policy.Route] = append(byRoute[policy.Route], policy)
+	}
+
+	return &RateLimiter{store: store, policies: byRoute, now: time.Now}
+}
+
+// Limit rejects requests to the route once any of its policies is exhausted,
+// with a 429 telling the client when to retry. Policies are checked in order
+// and the first rejection stops the others, so a client over one limit does
+// not use up the rest. Every response reports the
+// most constraining policy in the RateLimit-* headers. Requests are let
+// through when the store fails, an outage should not take the API down.
+func (l *RateLimiter) Limit(route string, next http.HandlerFunc) http.HandlerFunc {
+	if l == nil {
+		return next
+	}
+
+	policies, ok := l.policies[route]
+	if !ok {
+		policies = l.policies[ratelimit.AllRoutes]
+	}
+	if len(policies) == 0 {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		now := l.now()
+
+		var tightest *ratelimit.Result
+		for _, policy := range policies {
+			key, ok := rateLimitKey(r, policy.Key)
+			if !ok {
+				continue
+			}
+
+			result, err := l.store.Take(ctx, route+"|"+string(policy.Key)+":"+key, policy.Limit, now)
+			if err != nil {
+				logger.Error(ctx, "failed to check rate limit", slog.String("error", err.Error()))
+				continue
+			}
+
+			if tightest == nil || tighter(result, *tightest) {
+				tightest = &result
+			}
+			if !result.Allowed {
+				break
+			}
+		}
+
+		if tightest == nil {
+			next(w, r)
+			return
+		}
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(tightest.Limit))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(tightest.Reset)))
+
+		if !tightest.Allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(tightest.RetryAfter)))
+			response.Error(w, http.StatusTooManyRequests, "rate_limit_exceeded", "rate limit exceeded")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// tighter reports whether a constrains the client more than b: a rejection
+// over an allowance, then the longer wait or fewer remaining requests.
+func tighter(a, b ratelimit.Result) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	if !a.Allowed {
+		return a.RetryAfter > b.RetryAfter
+	}
+	return a.Remaining < b.Remaining
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// rateLimitKey returns the value requests are counted by, or false when the
+// request has none, such as an account-keyed policy on a request without one.
+func rateLimitKey(r *http.Request, kind ratelimit.KeyKind) (string, bool) {
+	switch kind {
+	case ratelimit.KeyClient:
+		if clientID := logger.ClientID(r.Context()); clientID != "" {
+			return clientID, true
+		}
+		// Unauthenticated clients are told apart by address.
+		return "ip:" + clientIP(r), true
+	case ratelimit.KeyIP:
+		return clientIP(r), true
+	case ratelimit.KeyAccount:
+		return accountID(r)
+	}
+	return "", false
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// accountID reads the account from the path or, for requests such as
+// POST /transactions, from the account_id field of the body, which is put
+// back for the handler.
+func accountID(r *http.Request) (string, bool) {
+	if id := r.PathValue("accountId"); id != "" {
+		return id, true
+	}
+	if r.Body == nil {
+		return "", false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPeekedBody))
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	if err != nil {
+		return "", false
+	}
+
+	var payload struct {
+		AccountID json.Number `json:"account_id"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.AccountID == "" {
+		return "", false
+	}
+	return payload.AccountID.String(), true
+}
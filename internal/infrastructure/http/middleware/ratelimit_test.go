@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/middleware/mocks"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/ratelimit"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+func TestRateLimiter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	perClient := ratelimit.Limit{Requests: 100, Period: time.Minute}
+	perAccount := ratelimit.Limit{Requests: 10, Period: time.Minute}
+	perIP := ratelimit.Limit{Requests: 1000, Period: time.Minute}
+
+	mockStore := mocks.NewMockrateLimitStore(ctrl)
+	limiter := NewRateLimiter(mockStore, []ratelimit.Policy{
+		{Route: "POST /transactions", Key: ratelimit.KeyClient, Limit: perClient},
+		{Route: "POST /transactions", Key: ratelimit.KeyAccount, Limit: perAccount},
+		{Route: ratelimit.AllRoutes, Key: ratelimit.KeyIP, Limit: perIP},
+	})
+
+	var body string
+	handler := limiter.Limit("POST /transactions", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(`{"account_id": 1, "amount": 10}`))
+		return req.WithContext(logger.WithClientID(req.Context(), "billing"))
+	}
+
+	t.Run("lets through requests within every limit", func(t *testing.T) {
+		mockStore.EXPECT().
+			Take(gomock.Any(), "POST /transactions|client:billing", perClient, gomock.Any()).
+			Return(ratelimit.Result{Allowed: true, Limit: 100, Remaining: 99, Reset: time.Second}, nil)
+		mockStore.EXPECT().
+			Take(gomock.Any(), "POST /transactions|account:1", perAccount, gomock.Any()).
+			Return(ratelimit.Result{Allowed: true, Limit: 10, Remaining: 4, Reset: 36 * time.Second}, nil)
+
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, newRequest())
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, `{"account_id": 1, "amount": 10}`, body)
+		assert.Equal(t, "10", rec.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "4", rec.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "36", rec.Header().Get("RateLimit-Reset"))
+		assert.Empty(t, rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("returns 429 when a limit is exhausted", func(t *testing.T) {
+		mockStore.EXPECT().
+			Take(gomock.Any(), "POST /transactions|client:billing", perClient, gomock.Any()).
+			Return(ratelimit.Result{Allowed: true, Limit: 100, Remaining: 50, Reset: 30 * time.Second}, nil)
+		mockStore.EXPECT().
+			Take(gomock.Any(), "POST /transactions|account:1", perAccount, gomock.Any()).
+			Return(ratelimit.Result{Allowed: false, Limit: 10, Remaining: 0, RetryAfter: 5500 * time.Millisecond, Reset: time.Minute}, nil)
+
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, newRequest())
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"rate_limit_exceeded"`)
+		assert.Equal(t, "6", rec.Header().Get("Retry-After"))
+		assert.Equal(t, "10", rec.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "60", rec.Header().Get("RateLimit-Reset"))
+	})
+
+	t.Run("takes nothing from the other limits once one is exhausted", func(t *testing.T) {
+		mockStore.EXPECT().
+			Take(gomock.Any(), "POST /transactions|client:billing", perClient, gomock.Any()).
+			Return(ratelimit.Result{Allowed: false, Limit: 100, Remaining: 0, RetryAfter: 2 * time.Second, Reset: time.Minute}, nil)
+		// The account limit is not taken from, which the mock would fail on.
+
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, newRequest())
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+		assert.Equal(t, "100", rec.Header().Get("RateLimit-Limit"))
+	})
+
+	t.Run("lets requests through when the store fails", func(t *testing.T) {
+		mockStore.EXPECT().
+			Take(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(ratelimit.Result{}, errors.New("database error")).
+			Times(2)
+
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, newRequest())
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+	})
+
+	t.Run("keys unauthenticated clients by address and skips requests without account", func(t *testing.T) {
+		mockStore.EXPECT().
+			Take(gomock.Any(), "POST /transactions|client:ip:192.0.2.1", perClient, gomock.Any()).
+			Return(ratelimit.Result{Allowed: true, Limit: 100, Remaining: 99}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(`{}`))
+		req.RemoteAddr = "192.0.2.1:1234"
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("applies the default policies to other routes", func(t *testing.T) {
+		mockStore.EXPECT().
+			Take(gomock.Any(), "GET /accounts/{accountId}|ip:192.0.2.1", perIP, gomock.Any()).
+			Return(ratelimit.Result{Allowed: true, Limit: 1000, Remaining: 999}, nil)
+
+		handler := limiter.Limit("GET /accounts/{accountId}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		req := httptest.NewRequest(http.MethodGet, "/accounts/1", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("does nothing when disabled", func(t *testing.T) {
+		var disabled *RateLimiter
+		handler := disabled.Limit("POST /transactions", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, newRequest())
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+}
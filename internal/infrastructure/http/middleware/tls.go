@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+)
+
+type clientCertificateContextKey struct{}
+
+// ClientCertificate stores the client certificate of mutual TLS connections
+// in the request context, once verified against the CA bundle, so that
+// handlers can authorize on its subject.
+func ClientCertificate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), clientCertificateContextKey{}, r.TLS.VerifiedChains[0][0])
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ClientCertificateFromContext returns the verified client certificate, nil
+// when the client presented none.
+func ClientCertificateFromContext(ctx context.Context) *x509.Certificate {
+	cert, _ := ctx.Value(clientCertificateContextKey{}).(*x509.Certificate)
+	return cert
+}
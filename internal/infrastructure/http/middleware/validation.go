@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/response"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+var pathParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// Validator checks requests, and optionally responses, against the OpenAPI
+// document. A nil *Validator disables validation.
+type Validator struct {
+	spec              *openapi3.T
+	validateResponses bool
+}
+
+// NewValidator loads the OpenAPI document. With validateResponses, responses
+// that do not match the document are replaced by a 500, which is meant for
+// tests, so that handlers cannot drift from the documentation.
+func NewValidator(spec []byte, validateResponses bool) (*Validator, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load openapi document: %w", err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid openapi document: %w", err)
+	}
+
+	return &Validator{spec: doc, validateResponses: validateResponses}, nil
+}
+
+// Validate rejects requests to the route whose parameters or body do not
+// match its operation, listing every violation at once. It panics when the
+// route is not documented, like http.ServeMux does for invalid patterns.
+func (v *Validator) Validate(route string, next http.HandlerFunc) http.HandlerFunc {
+	if v == nil {
+		return next
+	}
+
+	method, path, _ := strings.Cut(route, " ")
+	pathItem := v.spec.Paths.Find(path)
+	if pathItem == nil || pathItem.GetOperation(method) == nil {
+		panic("openapi document has no operation for " + route)
+	}
+	operation := &routers.Route{
+		Spec:      v.spec,
+		Path:      path,
+		PathItem:  pathItem,
+		Method:    method,
+		Operation: pathItem.GetOperation(method),
+	}
+
+	var pathParams []string
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		pathParams = append(pathParams, match[1])
+	}
+
+	options := &openapi3filter.Options{
+		MultiError:          true,
+		SkipSettingDefaults: true,
+		// Credentials are checked by the Auth middleware.
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := make(map[string]string, len(pathParams))
+		for _, name := range pathParams {
+			params[name] = r.PathValue(name)
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: params,
+			Route:      operation,
+			Options:    options,
+		}
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			writeRequestError(w, err)
+			return
+		}
+
+		if !v.validateResponses {
+			next(w, r)
+			return
+		}
+
+		buffered := &bufferedResponse{ResponseWriter: w, status: http.StatusOK}
+		next(buffered, r)
+
+		err := openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 buffered.status,
+			Header:                 w.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(buffered.body.Bytes())),
+			Options:                options,
+		})
+		if err != nil {
+			logger.Error(r.Context(), "response does not match the openapi document",
+				slog.String("route", route),
+				slog.Int("status", buffered.status),
+				slog.String("error", err.Error()),
+			)
+			response.Error(w, http.StatusInternalServerError, "invalid_response", "response does not match the openapi document: "+err.Error())
+			return
+		}
+
+		w.WriteHeader(buffered.status)
+		w.Write(buffered.body.Bytes())
+	}
+}
+
+// writeRequestError turns validation errors into a problem, a 400 listing
+// the offending fields, or a 415 for bodies of another media type.
+func writeRequestError(w http.ResponseWriter, err error) {
+	var fieldErrors []response.FieldError
+	for _, err := range flatten(err) {
+		var requestErr *openapi3filter.RequestError
+		if !errors.As(err, &requestErr) {
+			fieldErrors = append(fieldErrors, response.Invalid("", err.Error()))
+			continue
+		}
+
+		if requestErr.Parameter != nil {
+			fieldErrors = append(fieldErrors, response.Invalid(requestErr.Parameter.Name, requestErr.Parameter.Name+" is invalid"))
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(requestErr.Reason, "header Content-Type"):
+			response.Error(w, http.StatusUnsupportedMediaType, "unsupported_media_type", "request body must be application/json")
+			return
+		case errors.Is(requestErr.Err, openapi3filter.ErrInvalidRequired):
+			response.Error(w, http.StatusBadRequest, "invalid_body", "request body is required")
+			return
+		case requestErr.Err == nil || !isSchemaError(requestErr.Err):
+			response.Error(w, http.StatusBadRequest, "invalid_body", "invalid request body")
+			return
+		}
+
+		for _, err := range flatten(requestErr.Err) {
+			var schemaErr *openapi3.SchemaError
+			if errors.As(err, &schemaErr) {
+				fieldErrors = append(fieldErrors, fieldError(schemaErr))
+			}
+		}
+	}
+
+	response.InvalidFields(w, fieldErrors...)
+}
+
+func fieldError(err *openapi3.SchemaError) response.FieldError {
+	field := strings.Join(err.JSONPointer(), ".")
+
+	switch err.SchemaField {
+	case "required":
+		return response.Required(field)
+	case "properties":
+		// Unknown properties are reported on the object holding them.
+		if _, quoted, ok := strings.Cut(err.Reason, " "); ok {
+			if fields := strings.Fields(quoted); len(fields) > 0 {
+				if name, err := strconv.Unquote(fields[0]); err == nil {
+					field = strings.TrimPrefix(field+"."+name, ".")
+				}
+			}
+		}
+		return response.FieldError{Field: field, Code: "unknown", Message: field + " is not allowed"}
+	}
+	return response.Invalid(field, err.Reason)
+}
+
+// flatten lists the errors of nested multi errors. It does not unwrap, so
+// that request errors keep their parameter or body.
+func flatten(err error) []error {
+	multi, ok := err.(openapi3.MultiError)
+	if !ok {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, err := range multi {
+		errs = append(errs, flatten(err)...)
+	}
+	return errs
+}
+
+func isSchemaError(err error) bool {
+	var schemaErr *openapi3.SchemaError
+	return errors.As(err, &schemaErr)
+}
+
+// bufferedResponse holds a response back until it has been validated.
+type bufferedResponse struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	b.status = status
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
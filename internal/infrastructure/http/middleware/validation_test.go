@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/docs"
+)
+
+func TestValidator(t *testing.T) {
+	validator, err := NewValidator(docs.OpenAPI, false)
+	require.NoError(t, err)
+
+	var body string
+	handler := validator.Validate("POST /transactions", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("lets through requests matching the document", func(t *testing.T) {
+		// given
+		rec := httptest.NewRecorder()
+
+		// when
+		handler(rec, newRequest(`{"account_id": 1, "operation_type_id": 4, "amount": 10}`))
+
+		// then
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"account_id": 1, "operation_type_id": 4, "amount": 10}`, body)
+	})
+
+	t.Run("reports every violation of the body at once", func(t *testing.T) {
+		// given
+		rec := httptest.NewRecorder()
+
+		// when
+		handler(rec, newRequest(`{"account_id": "1", "amount": 10, "tip": 2}`))
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), `"code":"invalid_request"`)
+		assert.Contains(t, rec.Body.String(), `{"field":"account_id","code":"invalid"`)
+		assert.Contains(t, rec.Body.String(), `{"field":"operation_type_id","code":"required"`)
+		assert.Contains(t, rec.Body.String(), `{"field":"tip","code":"unknown"`)
+	})
+
+	t.Run("rejects malformed bodies", func(t *testing.T) {
+		// given
+		rec := httptest.NewRecorder()
+
+		// when
+		handler(rec, newRequest(`{"account_id": `))
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"invalid_body"`)
+	})
+
+	t.Run("rejects bodies of another media type", func(t *testing.T) {
+		// given
+		req := newRequest(`account_id=1`)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		// when
+		handler(rec, req)
+
+		// then
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("rejects invalid path parameters", func(t *testing.T) {
+		// given
+		handler := validator.Validate("GET /accounts/{accountId}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		req := httptest.NewRequest(http.MethodGet, "/accounts/0", nil)
+		req.SetPathValue("accountId", "0")
+		rec := httptest.NewRecorder()
+
+		// when
+		handler(rec, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `{"field":"accountId","code":"invalid"`)
+	})
+
+	t.Run("panics on routes missing from the document", func(t *testing.T) {
+		assert.Panics(t, func() {
+			validator.Validate("GET /unknown", func(w http.ResponseWriter, r *http.Request) {})
+		})
+	})
+
+	t.Run("does nothing when nil", func(t *testing.T) {
+		// given
+		var validator *Validator
+		handler := validator.Validate("GET /unknown", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		rec := httptest.NewRecorder()
+
+		// when
+		handler(rec, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+		// then
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestValidator_Responses(t *testing.T) {
+	validator, err := NewValidator(docs.OpenAPI, true)
+	require.NoError(t, err)
+
+	get := func(respond http.HandlerFunc) *httptest.ResponseRecorder {
+		handler := validator.Validate("GET /accounts/{accountId}", respond)
+		req := httptest.NewRequest(http.MethodGet, "/accounts/1", nil)
+		req.SetPathValue("accountId", "1")
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	t.Run("lets through responses matching the document", func(t *testing.T) {
+		// when
+		rec := get(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"account_id": 1, "document_number": "12345678900", "currency": "BRL"}`))
+		})
+
+		// then
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"account_id": 1, "document_number": "12345678900", "currency": "BRL"}`, rec.Body.String())
+	})
+
+	t.Run("replaces responses drifting from the document", func(t *testing.T) {
+		// when
+		rec := get(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"account_id": "1"}`))
+		})
+
+		// then
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"invalid_response"`)
+	})
+}
+
+func TestFieldError(t *testing.T) {
+	t.Run("reports unknown properties under their name", func(t *testing.T) {
+		// when
+		fieldErr := fieldError(&openapi3.SchemaError{SchemaField: "properties", Reason: `property "nickname" is unsupported`})
+
+		// then
+		assert.Equal(t, "nickname", fieldErr.Field)
+		assert.Equal(t, "unknown", fieldErr.Code)
+	})
+
+	t.Run("keeps the object when the reason names no property", func(t *testing.T) {
+		for _, reason := range []string{"property ", "property   ", "unsupported"} {
+			// when
+			fieldErr := fieldError(&openapi3.SchemaError{SchemaField: "properties", Reason: reason})
+
+			// then
+			assert.Empty(t, fieldErr.Field, reason)
+			assert.Equal(t, "unknown", fieldErr.Code, reason)
+		}
+	})
+}
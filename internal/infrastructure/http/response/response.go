@@ -6,11 +6,45 @@ import (
 	"net/http"
 
 	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/metrics"
 )
 
+// problemTypePrefix prefixes error codes to form the problem type URI.
+const problemTypePrefix = "urn:pismo:problem:"
+
 var kindToStatus = map[domain.ErrorKind]int{
-	domain.KindValidation: http.StatusUnprocessableEntity,
-	domain.KindNotFound:   http.StatusNotFound,
+	domain.KindValidation:  http.StatusUnprocessableEntity,
+	domain.KindNotFound:    http.StatusNotFound,
+	domain.KindConflict:    http.StatusConflict,
+	domain.KindForbidden:   http.StatusForbidden,
+	domain.KindUnavailable: http.StatusServiceUnavailable,
+}
+
+// Problem is an RFC 7807 problem details body. Code is the stable identifier
+// clients should match on, Detail is meant for humans and may change.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes what is wrong with one field of the request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func Required(field string) FieldError {
+	return FieldError{Field: field, Code: "required", Message: field + " is required"}
+}
+
+func Invalid(field, message string) FieldError {
+	return FieldError{Field: field, Code: "invalid", Message: message}
 }
 
 func JSON(w http.ResponseWriter, status int, data any) {
@@ -19,19 +53,43 @@ func JSON(w http.ResponseWriter, status int, data any) {
 	json.NewEncoder(w).Encode(data)
 }
 
-func Error(w http.ResponseWriter, status int, message string) {
-	JSON(w, status, map[string]string{"error": message})
+// Error writes a problem with the given code. The request ID set by the
+// RequestID middleware on the response is reported as the instance.
+func Error(w http.ResponseWriter, status int, code, detail string, fieldErrors ...FieldError) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Problem{
+		Type:     problemTypePrefix + code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: w.Header().Get("X-Request-ID"),
+		Code:     code,
+		Errors:   fieldErrors,
+	})
+}
+
+// InvalidFields rejects a request whose fields are missing or malformed
+// before it reaches a use case.
+func InvalidFields(w http.ResponseWriter, fieldErrors ...FieldError) {
+	Error(w, http.StatusBadRequest, "invalid_request", "request has missing or invalid fields", fieldErrors...)
 }
 
 func HandleError(w http.ResponseWriter, err error) {
 	var domainErr *domain.Error
 	if errors.As(err, &domainErr) {
+		metrics.DomainErrors.WithLabelValues(domainErr.Kind.String()).Inc()
 		status := kindToStatus[domainErr.Kind]
 		if status == 0 {
 			status = http.StatusInternalServerError
 		}
-		Error(w, status, domainErr.Message)
+
+		var fieldErrors []FieldError
+		if domainErr.Field != "" {
+			fieldErrors = append(fieldErrors, FieldError{Field: domainErr.Field, Code: domainErr.Code, Message: domainErr.Message})
+		}
+		Error(w, status, domainErr.Code, domainErr.Message, fieldErrors...)
 		return
 	}
-	Error(w, http.StatusInternalServerError, "internal server error")
+	Error(w, http.StatusInternalServerError, "internal_error", "internal server error")
 }
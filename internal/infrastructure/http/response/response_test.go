@@ -0,0 +1,91 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+func TestError(t *testing.T) {
+	t.Run("writes a problem with the request id as instance", func(t *testing.T) {
+		// given
+		rec := httptest.NewRecorder()
+		rec.Header().Set("X-Request-ID", "req-1")
+
+		// when
+		Error(rec, http.StatusTooManyRequests, "rate_limit_exceeded", "rate limit exceeded")
+
+		// then
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"type": "urn:pismo:problem:rate_limit_exceeded",
+			"title": "Too Many Requests",
+			"status": 429,
+			"detail": "rate limit exceeded",
+			"instance": "req-1",
+			"code": "rate_limit_exceeded"
+		}`, rec.Body.String())
+	})
+}
+
+func TestHandleError(t *testing.T) {
+	statuses := map[*domain.Error]int{
+		domain.ErrInvalidOperationType: http.StatusUnprocessableEntity,
+		domain.ErrAccountNotFound:      http.StatusNotFound,
+		domain.ErrAccountAlreadyExists: http.StatusConflict,
+		domain.ErrAccountAccessDenied:  http.StatusForbidden,
+		domain.ErrServiceUnavailable:   http.StatusServiceUnavailable,
+	}
+	for domainErr, status := range statuses {
+		t.Run("maps "+domainErr.Code+" to its status", func(t *testing.T) {
+			// given
+			rec := httptest.NewRecorder()
+
+			// when
+			HandleError(rec, domainErr)
+
+			// then
+			assert.Equal(t, status, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"code":"`+domainErr.Code+`"`)
+		})
+	}
+
+	t.Run("reports the field of validation errors", func(t *testing.T) {
+		// given
+		rec := httptest.NewRecorder()
+
+		// when
+		HandleError(rec, domain.ErrInvalidCurrency)
+
+		// then
+		assert.JSONEq(t, `{
+			"type": "urn:pismo:problem:invalid_currency",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "currency must be a three-letter ISO 4217 code",
+			"code": "invalid_currency",
+			"errors": [
+				{"field": "currency", "code": "invalid_currency", "message": "currency must be a three-letter ISO 4217 code"}
+			]
+		}`, rec.Body.String())
+	})
+
+	t.Run("hides unknown errors", func(t *testing.T) {
+		// given
+		rec := httptest.NewRecorder()
+
+		// when
+		HandleError(rec, errors.New("connection refused"))
+
+		// then
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"internal_error"`)
+		assert.NotContains(t, rec.Body.String(), "connection refused")
+	})
+}
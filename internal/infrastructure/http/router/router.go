@@ -3,6 +3,7 @@ package router
 import (
 	"net/http"
 
+	"github.com/nubank/pismo-code-assessment/internal/domain"
 	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/handler"
 	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/middleware"
 )
@@ -11,17 +12,58 @@ func New(
 	accountHandler *handler.AccountHandler,
 	transactionHandler *handler.TransactionHandler,
 	healthHandler *handler.HealthHandler,
+	spendingControlsHandler *handler.SpendingControlsHandler,
+	cardHandler *handler.CardHandler,
+	disputeHandler *handler.DisputeHandler,
+	scheduledTransactionHandler *handler.ScheduledTransactionHandler,
+	auth *middleware.Auth,
+	limiter *middleware.RateLimiter,
+	validator *middleware.Validator,
 ) http.Handler {
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("GET /health", healthHandler.Check)
-	mux.HandleFunc("POST /accounts", accountHandler.Create)
-	mux.HandleFunc("GET /accounts/{accountId}", accountHandler.Get)
-	mux.HandleFunc("POST /transactions", transactionHandler.Create)
+	// handle instruments the route and applies its rate limits, ahead of the
+	// scope check so that floods of rejected requests are limited too. Requests
+	// are validated against the OpenAPI document once the client is known to
+	// be allowed in.
+	handle := func(pattern string, scope domain.Scope, h http.HandlerFunc) {
+		h = auth.Require(scope, validator.Validate(pattern, h))
+		mux.HandleFunc(pattern, middleware.Metrics(pattern, middleware.Trace(pattern, limiter.Limit(pattern, h))))
+	}
+
+	read, write, transact, resolve := domain.ScopeAccountsRead, domain.ScopeAccountsWrite, domain.ScopeTransactionsWrite, domain.ScopeDisputesResolve
+
+	// Probes are public, as orchestrators and load balancers hold no
+	// credentials. /health predates them and answers like /readyz.
+	probe := func(pattern string, h http.HandlerFunc) {
+		mux.HandleFunc(pattern, middleware.Metrics(pattern, middleware.Trace(pattern, h)))
+	}
+	probe("GET /livez", healthHandler.Live)
+	probe("GET /readyz", healthHandler.Ready)
+	probe("GET /startupz", healthHandler.Startup)
+	probe("GET /health", healthHandler.Ready)
+	handle("POST /accounts", write, accountHandler.Create)
+	handle("GET /accounts/{accountId}", read, accountHandler.Get)
+	handle("GET /accounts/{accountId}/controls", read, spendingControlsHandler.Get)
+	handle("PUT /accounts/{accountId}/controls", write, spendingControlsHandler.Update)
+	handle("POST /accounts/{accountId}/cards", write, cardHandler.Issue)
+	handle("POST /cards/{cardId}/block", write, cardHandler.Block)
+	handle("POST /accounts/{accountId}/scheduled-transactions", transact, scheduledTransactionHandler.Create)
+	handle("GET /accounts/{accountId}/scheduled-transactions", read, scheduledTransactionHandler.List)
+	handle("GET /scheduled-transactions/{scheduledTransactionId}", read, scheduledTransactionHandler.Get)
+	handle("PUT /scheduled-transactions/{scheduledTransactionId}", transact, scheduledTransactionHandler.Update)
+	handle("DELETE /scheduled-transactions/{scheduledTransactionId}", transact, scheduledTransactionHandler.Cancel)
+	handle("POST /transactions", transact, transactionHandler.Create)
+	handle("POST /transactions/{transactionId}/disputes", transact, disputeHandler.Open)
+	handle("GET /transactions/{transactionId}/disputes", read, disputeHandler.List)
+	handle("PATCH /transactions/{transactionId}/disputes/{disputeId}", resolve, disputeHandler.Update)
 
 	return middleware.Chain(
 		mux,
+		middleware.Tracing,
 		middleware.RequestID,
+		middleware.ClientCertificate,
+		auth.Authenticate,
 		middleware.Recoverer,
 		middleware.Logger,
 	)
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"log"
 	"net/http"
 	"time"
@@ -11,19 +12,36 @@ type Server struct {
 	httpServer *http.Server
 }
 
-func New(port string, handler http.Handler) *Server {
+// Timeouts bound the phases of a connection, zero meaning no limit.
+type Timeouts struct {
+	Read       time.Duration
+	ReadHeader time.Duration
+	Write      time.Duration
+	Idle       time.Duration
+}
+
+// New returns a server, serving HTTPS when tlsConfig is not nil.
+func New(port string, handler http.Handler, timeouts Timeouts, tlsConfig *tls.Config) *Server {
 	return &Server{
 		httpServer: &http.Server{
-			Addr:         ":" + port,
-			Handler:      handler,
-			ReadTimeout:  10 * time.Second,
-			WriteTimeout: 10 * time.Second,
-			IdleTimeout:  30 * time.Second,
+			Addr:              ":" + port,
+			Handler:           handler,
+			ReadTimeout:       timeouts.Read,
+			ReadHeaderTimeout: timeouts.ReadHeader,
+			WriteTimeout:      timeouts.Write,
+			IdleTimeout:       timeouts.Idle,
+			TLSConfig:         tlsConfig,
 		},
 	}
 }
 
 func (s *Server) Start() error {
+	if s.httpServer.TLSConfig != nil {
+		log.Printf("Server starting on port %s with TLS", s.httpServer.Addr)
+		// The certificates come from the TLS configuration.
+		return s.httpServer.ListenAndServeTLS("", "")
+	}
+
 	log.Printf("Server starting on port %s", s.httpServer.Addr)
 	return s.httpServer.ListenAndServe()
 }
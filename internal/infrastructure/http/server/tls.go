@@ -0,0 +1,173 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+// reloadCheckInterval is how often handshakes look for new certificate files.
+var reloadCheckInterval = time.Second
+
+// TLS configures HTTPS.
+type TLS struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is the bundle client certificates are verified against.
+	ClientCAFile string
+	// ClientAuth is none, optional to verify certificates when clients
+	// present one, or require.
+	ClientAuth string
+	// MinVersion is 1.2 or 1.3.
+	MinVersion string
+}
+
+var (
+	clientAuthTypes = map[string]tls.ClientAuthType{
+		"none":     tls.NoClientCert,
+		"optional": tls.VerifyClientCertIfGiven,
+		"require":  tls.RequireAndVerifyClientCert,
+	}
+	tlsVersions = map[string]uint16{
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
+)
+
+// NewTLSConfig loads the certificates, and reloads them when their files
+// change so that they can be rotated without a restart. A reload that fails
+// keeps the previous certificates.
+func NewTLSConfig(cfg TLS) (*tls.Config, error) {
+	clientAuth, ok := clientAuthTypes[cfg.ClientAuth]
+	if !ok {
+		return nil, fmt.Errorf("invalid client auth %q, must be none, optional or require", cfg.ClientAuth)
+	}
+	minVersion, ok := tlsVersions[cfg.MinVersion]
+	if !ok {
+		return nil, fmt.Errorf("invalid minimum tls version %q, must be 1.2 or 1.3", cfg.MinVersion)
+	}
+	if clientAuth != tls.NoClientCert && cfg.ClientCAFile == "" {
+		return nil, errors.New("client certificates require a ca bundle")
+	}
+
+	r := &reloader{cfg: cfg, clientAuth: clientAuth, minVersion: minVersion}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion:         minVersion,
+		GetConfigForClient: r.configForClient,
+	}, nil
+}
+
+// reloader holds the configuration of the current certificate files.
+type reloader struct {
+	cfg        TLS
+	clientAuth tls.ClientAuthType
+	minVersion uint16
+
+	mu        sync.Mutex
+	config    *tls.Config
+	loadedAt  []time.Time
+	checkedAt time.Time
+}
+
+func (r *reloader) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checkedAt) >= reloadCheckInterval {
+		r.checkedAt = time.Now()
+		if modTimes, err := r.modTimes(); err == nil && !equalTimes(modTimes, r.loadedAt) {
+			if err := r.loadLocked(); err != nil {
+				logger.Default().Error("failed to reload tls certificates", "error", err.Error())
+			} else {
+				logger.Default().Info("reloaded tls certificates")
+			}
+		}
+	}
+	return r.config, nil
+}
+
+func (r *reloader) load() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.loadLocked()
+}
+
+func (r *reloader) loadLocked() error {
+	// Times are read first, so that files written while loading are
+	// reloaded on the next check.
+	modTimes, err := r.modTimes()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load tls certificate: %w", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   r.clientAuth,
+		MinVersion:   r.minVersion,
+		// Replacing the server's configuration drops the protocols it
+		// would have offered.
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client ca bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("client ca bundle holds no certificate")
+		}
+		config.ClientCAs = pool
+	}
+
+	r.config = config
+	r.loadedAt = modTimes
+	return nil
+}
+
+func (r *reloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+func (r *reloader) modTimes() ([]time.Time, error) {
+	var modTimes []time.Time
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+	return modTimes, nil
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/middleware"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "pismo test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns the PEM certificate and key of a leaf signed by the CA.
+func (ca *testCA) issue(t *testing.T, serial int64, commonName string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// startServer serves the subject of the client certificate over TLS.
+func startServer(t *testing.T, tlsConfig *tls.Config) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(middleware.ClientCertificate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cert := middleware.ClientCertificateFromContext(r.Context()); cert != nil {
+			w.Write([]byte(cert.Subject.CommonName))
+		}
+	})))
+	srv.TLS = tlsConfig
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func client(ca *testCA, certificates ...tls.Certificate) *http.Client {
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certificates}}}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile, caFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt")
+	certPEM, keyPEM := ca.issue(t, 2, "pismo-api", x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, time.Now().Add(-time.Minute))
+	writeFile(t, keyFile, keyPEM, time.Now().Add(-time.Minute))
+	writeFile(t, caFile, ca.pem, time.Now().Add(-time.Minute))
+
+	clientPEM, clientKeyPEM := ca.issue(t, 3, "billing", x509.ExtKeyUsageClientAuth)
+	clientCert, err := tls.X509KeyPair(clientPEM, clientKeyPEM)
+	require.NoError(t, err)
+
+	t.Run("serves the certificate", func(t *testing.T) {
+		// given
+		tlsConfig, err := NewTLSConfig(TLS{CertFile: certFile, KeyFile: keyFile, ClientAuth: "none", MinVersion: "1.2"})
+		require.NoError(t, err)
+		srv := startServer(t, tlsConfig)
+
+		// when
+		resp, err := client(ca).Get(srv.URL)
+
+		// then
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, int64(2), resp.TLS.PeerCertificates[0].SerialNumber.Int64())
+	})
+
+	t.Run("reloads certificates when their files change", func(t *testing.T) {
+		// given
+		reloadCheckInterval = 0
+		t.Cleanup(func() { reloadCheckInterval = time.Second })
+		dir := t.TempDir()
+		certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+		writeFile(t, certFile, certPEM, time.Now().Add(-time.Minute))
+		writeFile(t, keyFile, keyPEM, time.Now().Add(-time.Minute))
+		tlsConfig, err := NewTLSConfig(TLS{CertFile: certFile, KeyFile: keyFile, ClientAuth: "none", MinVersion: "1.2"})
+		require.NoError(t, err)
+		srv := startServer(t, tlsConfig)
+
+		// when
+		rotatedPEM, rotatedKeyPEM := ca.issue(t, 4, "pismo-api", x509.ExtKeyUsageServerAuth)
+		writeFile(t, certFile, rotatedPEM, time.Now())
+		writeFile(t, keyFile, rotatedKeyPEM, time.Now())
+		resp, err := client(ca).Get(srv.URL)
+
+		// then
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, int64(4), resp.TLS.PeerCertificates[0].SerialNumber.Int64())
+	})
+
+	t.Run("requires client certificates issued by the bundle", func(t *testing.T) {
+		// given
+		tlsConfig, err := NewTLSConfig(TLS{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile, ClientAuth: "require", MinVersion: "1.3"})
+		require.NoError(t, err)
+		srv := startServer(t, tlsConfig)
+
+		// when
+		_, errWithout := client(ca).Get(srv.URL)
+		resp, err := client(ca, clientCert).Get(srv.URL)
+
+		// then
+		assert.Error(t, errWithout)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body := make([]byte, 16)
+		n, _ := resp.Body.Read(body)
+		assert.Equal(t, "billing", string(body[:n]))
+		assert.Equal(t, uint16(tls.VersionTLS13), resp.TLS.Version)
+	})
+
+	t.Run("rejects client auth without a bundle", func(t *testing.T) {
+		// when
+		_, err := NewTLSConfig(TLS{CertFile: certFile, KeyFile: keyFile, ClientAuth: "require", MinVersion: "1.2"})
+
+		// then
+		assert.EqualError(t, err, "client certificates require a ca bundle")
+	})
+}
@@ -0,0 +1,68 @@
+// Package memory implements the repositories in memory, for tests and for
+// running the service without a database. Repositories are safe for concurrent
+// use and enforce the constraints of the database schema, like unique
+// document numbers and the references between tables, with the same domain
+// errors. Data is lost when the process exits.
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+type AccountRepository struct {
+	mu        sync.RWMutex
+	accounts  map[int64]domain.Account
+	documents map[string]int64
+	lastID    int64
+}
+
+func NewAccountRepository() *AccountRepository {
+	return &AccountRepository{
+		accounts:  make(map[int64]domain.Account),
+		documents: make(map[string]int64),
+	}
+}
+
+func (r *AccountRepository) Create(_ context.Context, account *domain.Account) (*domain.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.documents[account.DocumentNumber]; ok {
+		return nil, domain.ErrAccountAlreadyExists
+	}
+
+	r.lastID++
+	created := domain.Account{
+		ID:             r.lastID,
+		DocumentNumber: account.DocumentNumber,
+		Currency:       account.Currency,
+	}
+	r.accounts[created.ID] = created
+	r.documents[created.DocumentNumber] = created.ID
+
+	return &created, nil
+}
+
+func (r *AccountRepository) FindByID(_ context.Context, ID int64) (*domain.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	account, ok := r.accounts[ID]
+	if !ok {
+		return nil, domain.ErrAccountNotFound
+	}
+
+	return &account, nil
+}
+
+// exists backs the references other repositories hold to accounts.
+func (r *AccountRepository) exists(ID int64) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.accounts[ID]
+	return ok
+}
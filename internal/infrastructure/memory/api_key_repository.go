@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+type APIKeyRepository struct {
+	mu     sync.RWMutex
+	keys   map[int64]*domain.APIKey
+	lastID int64
+}
+
+func NewAPIKeyRepository() *APIKeyRepository {
+	return &APIKeyRepository{keys: make(map[int64]*domain.APIKey)}
+}
+
+func (r *APIKeyRepository) Create(_ context.Context, key *domain.APIKey) (*domain.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	key.ID = r.lastID
+	r.keys[key.ID] = cloneAPIKey(key)
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) FindByHash(_ context.Context, hash string) (*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.Hash == hash {
+			return cloneAPIKey(key), nil
+		}
+	}
+
+	return nil, domain.ErrAPIKeyNotFound
+}
+
+func (r *APIKeyRepository) List(_ context.Context) ([]*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var keys []*domain.APIKey
+	for _, key := range r.keys {
+		keys = append(keys, cloneAPIKey(key))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	return keys, nil
+}
+
+// Revoke marks the key as revoked, keeping the first revocation time when the
+// key was already revoked.
+func (r *APIKeyRepository) Revoke(_ context.Context, ID int64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[ID]
+	if !ok {
+		return domain.ErrAPIKeyNotFound
+	}
+	if key.RevokedAt == nil {
+		revokedAt := at.UTC()
+		key.RevokedAt = &revokedAt
+	}
+
+	return nil
+}
+
+func cloneAPIKey(key *domain.APIKey) *domain.APIKey {
+	clone := *key
+	clone.Scopes = slices.Clone(key.Scopes)
+	if key.RevokedAt != nil {
+		revokedAt := *key.RevokedAt
+		clone.RevokedAt = &revokedAt
+	}
+	return &clone
+}
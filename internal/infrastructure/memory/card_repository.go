@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+type CardRepository struct {
+	accounts *AccountRepository
+
+	mu     sync.RWMutex
+	cards  map[int64]domain.Card
+	lastID int64
+}
+
+func NewCardRepository(accounts *AccountRepository) *CardRepository {
+	return &CardRepository{
+		accounts: accounts,
+		cards:    make(map[int64]domain.Card),
+	}
+}
+
+func (r *CardRepository) Create(_ context.Context, card *domain.Card) (*domain.Card, error) {
+	if !r.accounts.exists(card.AccountID) {
+		return nil, domain.ErrAccountNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	card.ID = r.lastID
+	r.cards[card.ID] = *card
+
+	return card, nil
+}
+
+func (r *CardRepository) FindByID(_ context.Context, ID int64) (*domain.Card, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	card, ok := r.cards[ID]
+	if !ok {
+		return nil, domain.ErrCardNotFound
+	}
+
+	return &card, nil
+}
+
+func (r *CardRepository) UpdateStatus(_ context.Context, card *domain.Card) (*domain.Card, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.cards[card.ID]
+	if !ok {
+		return nil, domain.ErrCardNotFound
+	}
+	stored.Status = card.Status
+	r.cards[card.ID] = stored
+
+	return card, nil
+}
+
+// exists backs the references transactions hold to cards.
+func (r *CardRepository) exists(ID int64) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.cards[ID]
+	return ok
+}
@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+type DisputeRepository struct {
+	transactions *TransactionRepository
+
+	mu          sync.RWMutex
+	disputes    map[int64]*domain.Dispute
+	lastID      int64
+	lastEventID int64
+}
+
+func NewDisputeRepository(transactions *TransactionRepository) *DisputeRepository {
+	return &DisputeRepository{
+		transactions: transactions,
+		disputes:     make(map[int64]*domain.Dispute),
+	}
+}
+
+func (r *DisputeRepository) Create(_ context.Context, dispute *domain.Dispute) (*domain.Dispute, error) {
+	if !r.transactions.exists(dispute.TransactionID) {
+		return nil, domain.ErrTransactionNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// A transaction has at most one dispute unresolved or won, which
+	// refunded it for good.
+	if blocksDisputes(dispute.Status) {
+		for _, other := range r.disputes {
+			if other.TransactionID != dispute.TransactionID || !blocksDisputes(other.Status) {
+				continue
+			}
+			if other.Status == domain.DisputeStatusWon {
+				return nil, domain.ErrDisputeAlreadyWon
+			}
+			return nil, domain.ErrDisputeAlreadyOpen
+		}
+	}
+
+	r.lastID++
+	dispute.ID = r.lastID
+	r.assignEventIDs(dispute)
+	r.disputes[dispute.ID] = cloneDispute(dispute)
+
+	return dispute, nil
+}
+
+func (r *DisputeRepository) FindByID(_ context.Context, ID int64) (*domain.Dispute, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dispute, ok := r.disputes[ID]
+	if !ok {
+		return nil, domain.ErrDisputeNotFound
+	}
+
+	return cloneDispute(dispute), nil
+}
+
+func (r *DisputeRepository) ListByTransactionID(_ context.Context, transactionID int64) ([]*domain.Dispute, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var disputes []*domain.Dispute
+	for _, dispute := range r.disputes {
+		if dispute.TransactionID == transactionID {
+			disputes = append(disputes, cloneDispute(dispute))
+		}
+	}
+	sort.Slice(disputes, func(i, j int) bool {
+		if !disputes[i].OpenedAt.Equal(disputes[j].OpenedAt) {
+			return disputes[i].OpenedAt.Before(disputes[j].OpenedAt)
+		}
+		return disputes[i].ID < disputes[j].ID
+	})
+
+	return disputes, nil
+}
+
+// Update saves the dispute's current state and any events not yet persisted,
+// unless its status changed since it was loaded.
+func (r *DisputeRepository) Update(_ context.Context, dispute *domain.Dispute) (*domain.Dispute, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.disputes[dispute.ID]
+	if !ok {
+		return nil, domain.ErrDisputeNotFound
+	}
+	if stored.Status != dispute.LoadedStatus() {
+		return nil, domain.ErrInvalidDisputeTransition
+	}
+
+	r.assignEventIDs(dispute)
+	r.disputes[dispute.ID] = cloneDispute(dispute)
+
+	return dispute, nil
+}
+
+// assignEventIDs numbers the events without an ID, which are the ones
+// appended since the dispute was loaded.
+func (r *DisputeRepository) assignEventIDs(dispute *domain.Dispute) {
+	for i := range dispute.Events {
+		if dispute.Events[i].ID == 0 {
+			r.lastEventID++
+			dispute.Events[i].ID = r.lastEventID
+		}
+	}
+}
+
+func blocksDisputes(status domain.DisputeStatus) bool {
+	return status != domain.DisputeStatusLost
+}
+
+func cloneDispute(dispute *domain.Dispute) *domain.Dispute {
+	clone := *dispute
+	clone.Events = slices.Clone(dispute.Events)
+	return &clone
+}
@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+func TestDisputeRepository(t *testing.T) {
+	ctx := context.Background()
+	accounts := NewAccountRepository()
+	transactions := NewTransactionRepository(accounts, NewCardRepository(accounts))
+	repo := NewDisputeRepository(transactions)
+	account, err := accounts.Create(ctx, &domain.Account{DocumentNumber: "12345678900", Currency: "BRL"})
+	require.NoError(t, err)
+	transaction, err := transactions.Create(ctx, &domain.Transaction{AccountID: account.ID, OperationTypeID: domain.OperationTypePurchase})
+	require.NoError(t, err)
+
+	newDispute := func() *domain.Dispute {
+		return &domain.Dispute{
+			TransactionID: transaction.ID,
+			AccountID:     account.ID,
+			Status:        domain.DisputeStatusOpened,
+			Events:        []domain.DisputeEvent{{Status: domain.DisputeStatusOpened}},
+			OpenedAt:      time.Now(),
+		}
+	}
+
+	t.Run("allows one unresolved dispute per transaction", func(t *testing.T) {
+		// given
+		dispute, err := repo.Create(ctx, newDispute())
+		require.NoError(t, err)
+
+		// when
+		_, errWhileOpen := repo.Create(ctx, newDispute())
+		dispute.Status = domain.DisputeStatusLost
+		dispute.Events = append(dispute.Events, domain.DisputeEvent{Status: domain.DisputeStatusLost})
+		_, err = repo.Update(ctx, dispute)
+		require.NoError(t, err)
+		_, errOnceLost := repo.Create(ctx, newDispute())
+
+		// then
+		assert.ErrorIs(t, errWhileOpen, domain.ErrDisputeAlreadyOpen)
+		assert.NoError(t, errOnceLost)
+	})
+
+	t.Run("numbers new events and lists disputes in opening order", func(t *testing.T) {
+		// when
+		disputes, err := repo.ListByTransactionID(ctx, transaction.ID)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, disputes, 2)
+		assert.Equal(t, domain.DisputeStatusLost, disputes[0].Status)
+		assert.Equal(t, []int64{1, 2}, []int64{disputes[0].Events[0].ID, disputes[0].Events[1].ID})
+		assert.Equal(t, int64(3), disputes[1].Events[0].ID)
+	})
+
+	t.Run("refuses disputes of transactions refunded by a won one", func(t *testing.T) {
+		// given
+		disputes, err := repo.ListByTransactionID(ctx, transaction.ID)
+		require.NoError(t, err)
+		won := disputes[1]
+		won.Status = domain.DisputeStatusWon
+		won.Events = append(won.Events, domain.DisputeEvent{Status: domain.DisputeStatusWon})
+		_, err = repo.Update(ctx, won)
+		require.NoError(t, err)
+
+		// when
+		_, err = repo.Create(ctx, newDispute())
+
+		// then
+		assert.ErrorIs(t, err, domain.ErrDisputeAlreadyWon)
+	})
+
+	t.Run("saves only the first of concurrent transitions", func(t *testing.T) {
+		// given
+		other, err := transactions.Create(ctx, &domain.Transaction{AccountID: account.ID, OperationTypeID: domain.OperationTypePurchase})
+		require.NoError(t, err)
+		dispute := newDispute()
+		dispute.TransactionID = other.ID
+		dispute, err = repo.Create(ctx, dispute)
+		require.NoError(t, err)
+
+		// Both resolutions load the dispute before either is saved.
+		var resolutions []*domain.Dispute
+		for _, status := range []domain.DisputeStatus{domain.DisputeStatusWon, domain.DisputeStatusLost} {
+			loaded, err := repo.FindByID(ctx, dispute.ID)
+			require.NoError(t, err)
+			require.NoError(t, loaded.Transition(status, "", time.Now()))
+			resolutions = append(resolutions, loaded)
+		}
+
+		// when
+		results := make(chan error, len(resolutions))
+		for _, resolution := range resolutions {
+			go func() {
+				_, err := repo.Update(ctx, resolution)
+				results <- err
+			}()
+		}
+		first, second := <-results, <-results
+
+		// then
+		if first == nil {
+			assert.ErrorIs(t, second, domain.ErrInvalidDisputeTransition)
+		} else {
+			assert.ErrorIs(t, first, domain.ErrInvalidDisputeTransition)
+			assert.NoError(t, second)
+		}
+	})
+
+	t.Run("rejects disputes of unknown transactions", func(t *testing.T) {
+		// given
+		dispute := newDispute()
+		dispute.TransactionID = 999
+
+		// when
+		_, err := repo.Create(ctx, dispute)
+
+		// then
+		assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
+	})
+}
@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+// FraudRuleRepository serves a fixed set of rules, as there is no API to
+// manage them.
+type FraudRuleRepository struct {
+	rules []domain.FraudRule
+}
+
+func NewFraudRuleRepository(rules ...domain.FraudRule) *FraudRuleRepository {
+	return &FraudRuleRepository{rules: rules}
+}
+
+func (r *FraudRuleRepository) ListEnabled(_ context.Context) ([]*domain.FraudRule, error) {
+	var rules []*domain.FraudRule
+	for _, rule := range r.rules {
+		if rule.Enabled {
+			rules = append(rules, &rule)
+		}
+	}
+
+	return rules, nil
+}
+
+type FraudAssessmentRepository struct {
+	mu          sync.Mutex
+	assessments []domain.FraudAssessment
+}
+
+func NewFraudAssessmentRepository() *FraudAssessmentRepository {
+	return &FraudAssessmentRepository{}
+}
+
+func (r *FraudAssessmentRepository) Create(_ context.Context, assessment *domain.FraudAssessment) (*domain.FraudAssessment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	assessment.ID = int64(len(r.assessments) + 1)
+	stored := *assessment
+	stored.TriggeredRules = slices.Clone(assessment.TriggeredRules)
+	r.assessments = append(r.assessments, stored)
+
+	return assessment, nil
+}
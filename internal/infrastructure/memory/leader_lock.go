@@ -0,0 +1,15 @@
+package memory
+
+import "context"
+
+// LeaderLock is always acquired, as data kept in memory is only ever served
+// by a single instance.
+type LeaderLock struct{}
+
+func (LeaderLock) TryAcquire(context.Context) (bool, error) {
+	return true, nil
+}
+
+func (LeaderLock) Release(context.Context) error {
+	return nil
+}
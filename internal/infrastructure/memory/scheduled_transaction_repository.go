@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+type ScheduledTransactionRepository struct {
+	accounts *AccountRepository
+
+	mu        sync.RWMutex
+	schedules map[int64]domain.ScheduledTransaction
+	lastID    int64
+}
+
+func NewScheduledTransactionRepository(accounts *AccountRepository) *ScheduledTransactionRepository {
+	return &ScheduledTransactionRepository{
+		accounts:  accounts,
+		schedules: make(map[int64]domain.ScheduledTransaction),
+	}
+}
+
+func (r *ScheduledTransactionRepository) Create(_ context.Context, schedule *domain.ScheduledTransaction) (*domain.ScheduledTransaction, error) {
+	if !schedule.OperationTypeID.IsValid() {
+		return nil, domain.ErrInvalidOperationType
+	}
+	if !r.accounts.exists(schedule.AccountID) {
+		return nil, domain.ErrAccountNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	schedule.ID = r.lastID
+	r.schedules[schedule.ID] = *schedule
+
+	return schedule, nil
+}
+
+func (r *ScheduledTransactionRepository) FindByID(_ context.Context, ID int64) (*domain.ScheduledTransaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedule, ok := r.schedules[ID]
+	if !ok {
+		return nil, domain.ErrScheduledTransactionNotFound
+	}
+
+	return &schedule, nil
+}
+
+func (r *ScheduledTransactionRepository) ListByAccountID(_ context.Context, accountID int64) ([]*domain.ScheduledTransaction, error) {
+	schedules := r.list(func(schedule domain.ScheduledTransaction) bool {
+		return schedule.AccountID == accountID
+	})
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].ID < schedules[j].ID
+	})
+
+	return schedules, nil
+}
+
+func (r *ScheduledTransactionRepository) ListDue(_ context.Context, at time.Time, limit int) ([]*domain.ScheduledTransaction, error) {
+	schedules := r.list(func(schedule domain.ScheduledTransaction) bool {
+		return schedule.Status == domain.ScheduleStatusActive && !schedule.NextRunAt.After(at)
+	})
+	sort.Slice(schedules, func(i, j int) bool {
+		if !schedules[i].NextRunAt.Equal(schedules[j].NextRunAt) {
+			return schedules[i].NextRunAt.Before(schedules[j].NextRunAt)
+		}
+		return schedules[i].ID < schedules[j].ID
+	})
+	if len(schedules) > limit {
+		schedules = schedules[:limit]
+	}
+
+	return schedules, nil
+}
+
+func (r *ScheduledTransactionRepository) Update(_ context.Context, schedule *domain.ScheduledTransaction) (*domain.ScheduledTransaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.schedules[schedule.ID]
+	if !ok {
+		return nil, domain.ErrScheduledTransactionNotFound
+	}
+	if !schedule.OperationTypeID.IsValid() {
+		return nil, domain.ErrInvalidOperationType
+	}
+
+	// The account and creation time never change.
+	updated := *schedule
+	updated.AccountID = stored.AccountID
+	updated.CreatedAt = stored.CreatedAt
+	r.schedules[schedule.ID] = updated
+
+	return schedule, nil
+}
+
+func (r *ScheduledTransactionRepository) list(match func(domain.ScheduledTransaction) bool) []*domain.ScheduledTransaction {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var schedules []*domain.ScheduledTransaction
+	for _, schedule := range r.schedules {
+		if match(schedule) {
+			schedules = append(schedules, &schedule)
+		}
+	}
+	return schedules
+}
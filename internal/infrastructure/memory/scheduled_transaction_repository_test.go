@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+func TestScheduledTransactionRepository(t *testing.T) {
+	ctx := context.Background()
+	accounts := NewAccountRepository()
+	repo := NewScheduledTransactionRepository(accounts)
+	account, err := accounts.Create(ctx, &domain.Account{DocumentNumber: "12345678900", Currency: "BRL"})
+	require.NoError(t, err)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	newSchedule := func(nextRunAt time.Time, status domain.ScheduleStatus) *domain.ScheduledTransaction {
+		return &domain.ScheduledTransaction{
+			AccountID:          account.ID,
+			ScheduleDefinition: domain.ScheduleDefinition{OperationTypeID: domain.OperationTypePayment},
+			Status:             status,
+			NextRunAt:          nextRunAt,
+		}
+	}
+
+	t.Run("lists active schedules due, earliest first, up to the limit", func(t *testing.T) {
+		// given
+		late, err := repo.Create(ctx, newSchedule(now.Add(-time.Minute), domain.ScheduleStatusActive))
+		require.NoError(t, err)
+		early, err := repo.Create(ctx, newSchedule(now.Add(-time.Hour), domain.ScheduleStatusActive))
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, newSchedule(now.Add(-2*time.Hour), domain.ScheduleStatusCancelled))
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, newSchedule(now.Add(time.Minute), domain.ScheduleStatusActive))
+		require.NoError(t, err)
+
+		// when
+		due, err := repo.ListDue(ctx, now, 10)
+		limited, errLimited := repo.ListDue(ctx, now, 1)
+
+		// then
+		require.NoError(t, err)
+		require.NoError(t, errLimited)
+		require.Len(t, due, 2)
+		assert.Equal(t, []int64{early.ID, late.ID}, []int64{due[0].ID, due[1].ID})
+		require.Len(t, limited, 1)
+		assert.Equal(t, early.ID, limited[0].ID)
+	})
+
+	t.Run("rejects schedules breaking references", func(t *testing.T) {
+		// given
+		unknownAccount := newSchedule(now, domain.ScheduleStatusActive)
+		unknownAccount.AccountID = 999
+		unknownOperationType := newSchedule(now, domain.ScheduleStatusActive)
+		unknownOperationType.OperationTypeID = 99
+
+		// when
+		_, unknownAccountErr := repo.Create(ctx, unknownAccount)
+		_, unknownOperationTypeErr := repo.Create(ctx, unknownOperationType)
+		_, notFoundErr := repo.Update(ctx, &domain.ScheduledTransaction{ID: 999})
+
+		// then
+		assert.ErrorIs(t, unknownAccountErr, domain.ErrAccountNotFound)
+		assert.ErrorIs(t, unknownOperationTypeErr, domain.ErrInvalidOperationType)
+		assert.ErrorIs(t, notFoundErr, domain.ErrScheduledTransactionNotFound)
+	})
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+type SpendingControlsRepository struct {
+	accounts *AccountRepository
+
+	mu       sync.RWMutex
+	controls map[int64]*domain.SpendingControls
+}
+
+func NewSpendingControlsRepository(accounts *AccountRepository) *SpendingControlsRepository {
+	return &SpendingControlsRepository{
+		accounts: accounts,
+		controls: make(map[int64]*domain.SpendingControls),
+	}
+}
+
+func (r *SpendingControlsRepository) FindByAccountID(_ context.Context, accountID int64) (*domain.SpendingControls, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	controls, ok := r.controls[accountID]
+	if !ok {
+		return nil, domain.ErrSpendingControlsNotFound
+	}
+
+	return cloneSpendingControls(controls), nil
+}
+
+func (r *SpendingControlsRepository) Save(_ context.Context, controls *domain.SpendingControls) (*domain.SpendingControls, error) {
+	if !r.accounts.exists(controls.AccountID) {
+		return nil, domain.ErrAccountNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.controls[controls.AccountID] = cloneSpendingControls(controls)
+
+	return controls, nil
+}
+
+func cloneSpendingControls(controls *domain.SpendingControls) *domain.SpendingControls {
+	clone := *controls
+	clone.BlockedMCCs = slices.Clone(controls.BlockedMCCs)
+	clone.AllowedOperationTypes = slices.Clone(controls.AllowedOperationTypes)
+	clone.TimeWindows = slices.Clone(controls.TimeWindows)
+	return &clone
+}
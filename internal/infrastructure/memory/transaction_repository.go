@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"sync"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+type externalIDKey struct {
+	accountID  int64
+	externalID string
+}
+
+type TransactionRepository struct {
+	accounts *AccountRepository
+	cards    *CardRepository
+
+	mu           sync.RWMutex
+	transactions map[int64]*domain.Transaction
+	byAccount    map[int64][]*domain.Transaction
+	externalIDs  map[externalIDKey]int64
+	lastID       int64
+}
+
+// NewTransactionRepository checks the accounts and cards of transactions
+// against the given repositories.
+func NewTransactionRepository(accounts *AccountRepository, cards *CardRepository) *TransactionRepository {
+	return &TransactionRepository{
+		accounts:     accounts,
+		cards:        cards,
+		transactions: make(map[int64]*domain.Transaction),
+		byAccount:    make(map[int64][]*domain.Transaction),
+		externalIDs:  make(map[externalIDKey]int64),
+	}
+}
+
+func (r *TransactionRepository) Create(_ context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
+	if !r.accounts.exists(transaction.AccountID) {
+		return nil, domain.ErrAccountNotFound
+	}
+	if !transaction.OperationTypeID.IsValid() {
+		return nil, domain.ErrInvalidOperationType
+	}
+	if transaction.CardID != 0 && !r.cards.exists(transaction.CardID) {
+		return nil, domain.ErrCardNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := externalIDKey{accountID: transaction.AccountID, externalID: transaction.ExternalID}
+	if transaction.ExternalID != "" {
+		if _, ok := r.externalIDs[key]; ok {
+			return nil, domain.ErrTransactionAlreadyExists
+		}
+	}
+
+	r.lastID++
+	stored := cloneTransaction(transaction)
+	stored.ID = r.lastID
+	r.transactions[stored.ID] = stored
+	r.byAccount[stored.AccountID] = append(r.byAccount[stored.AccountID], stored)
+	if transaction.ExternalID != "" {
+		r.externalIDs[key] = stored.ID
+	}
+
+	return cloneTransaction(stored), nil
+}
+
+// ListByAccountID orders transactions by event date, then by creation for
+// those posted at the same time.
+func (r *TransactionRepository) ListByAccountID(_ context.Context, accountID int64) ([]*domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var transactions []*domain.Transaction
+	for _, transaction := range r.byAccount[accountID] {
+		transactions = append(transactions, cloneTransaction(transaction))
+	}
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].EventDate.Before(transactions[j].EventDate)
+	})
+
+	return transactions, nil
+}
+
+// UpdateBalances updates every balance under one lock, so that readers see
+// all of them or none. Like the SQL update, it skips unknown transactions.
+func (r *TransactionRepository) UpdateBalances(_ context.Context, transactions []*domain.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, transaction := range transactions {
+		if stored, ok := r.transactions[transaction.ID]; ok {
+			stored.Balance = transaction.Balance
+		}
+	}
+
+	return nil
+}
+
+func (r *TransactionRepository) FindByExternalID(_ context.Context, accountID int64, externalID string) (*domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ID, ok := r.externalIDs[externalIDKey{accountID: accountID, externalID: externalID}]
+	if !ok {
+		return nil, domain.ErrTransactionNotFound
+	}
+
+	return cloneTransaction(r.transactions[ID]), nil
+}
+
+func (r *TransactionRepository) FindByID(_ context.Context, ID int64) (*domain.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	transaction, ok := r.transactions[ID]
+	if !ok {
+		return nil, domain.ErrTransactionNotFound
+	}
+
+	return cloneTransaction(transaction), nil
+}
+
+// exists backs the references disputes hold to transactions.
+func (r *TransactionRepository) exists(ID int64) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.transactions[ID]
+	return ok
+}
+
+// cloneTransaction keeps callers from changing stored transactions through
+// the values they pass in or get back.
+func cloneTransaction(transaction *domain.Transaction) *domain.Transaction {
+	clone := *transaction
+	clone.Metadata = maps.Clone(transaction.Metadata)
+	return &clone
+}
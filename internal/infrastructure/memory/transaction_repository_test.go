@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/repositorytest"
+)
+
+func TestRepositories(t *testing.T) {
+	// Every subtest gets a fresh store.
+	repositorytest.Run(t, func(*testing.T) repositorytest.Repositories {
+		accounts := NewAccountRepository()
+		return repositorytest.Repositories{
+			Accounts:     accounts,
+			Transactions: NewTransactionRepository(accounts, NewCardRepository(accounts)),
+		}
+	})
+}
+
+func TestTransactionRepository(t *testing.T) {
+	ctx := context.Background()
+	accounts := NewAccountRepository()
+	cards := NewCardRepository(accounts)
+	repo := NewTransactionRepository(accounts, cards)
+	account, err := accounts.Create(ctx, &domain.Account{DocumentNumber: "12345678900", Currency: "BRL"})
+	require.NoError(t, err)
+
+	t.Run("accepts cards of the store", func(t *testing.T) {
+		// given
+		card, err := cards.Create(ctx, &domain.Card{AccountID: account.ID, Status: domain.CardStatusActive})
+		require.NoError(t, err)
+
+		// when
+		created, err := repo.Create(ctx, &domain.Transaction{
+			AccountID:          account.ID,
+			OperationTypeID:    domain.OperationTypePurchase,
+			TransactionDetails: domain.TransactionDetails{CardID: card.ID},
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, card.ID, created.CardID)
+	})
+
+	t.Run("keeps stored transactions apart from callers", func(t *testing.T) {
+		// given
+		transaction := &domain.Transaction{
+			AccountID:          account.ID,
+			OperationTypeID:    domain.OperationTypePurchase,
+			Balance:            -10,
+			TransactionDetails: domain.TransactionDetails{Metadata: map[string]any{"channel": "pos"}},
+		}
+		created, err := repo.Create(ctx, transaction)
+		require.NoError(t, err)
+
+		// when
+		transaction.Metadata["channel"] = "ecommerce"
+		created.Balance = 0
+		found, err := repo.FindByID(ctx, created.ID)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "pos", found.Metadata["channel"])
+		assert.Equal(t, -10.0, found.Balance)
+	})
+
+	t.Run("creates transactions concurrently", func(t *testing.T) {
+		// given
+		var wg sync.WaitGroup
+		before, err := repo.ListByAccountID(ctx, account.ID)
+		require.NoError(t, err)
+
+		// when
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.Create(ctx, &domain.Transaction{AccountID: account.ID, OperationTypeID: domain.OperationTypePayment, EventDate: time.Now()})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		// then
+		after, err := repo.ListByAccountID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Len(t, after, len(before)+50)
+	})
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// pgxPoolCollector exports the stats of a pgx pool, which database/sql
+// stats leave out.
+type pgxPoolCollector struct {
+	pool *pgxpool.Pool
+
+	maxConns         *prometheus.Desc
+	acquiredConns    *prometheus.Desc
+	idleConns        *prometheus.Desc
+	totalConns       *prometheus.Desc
+	acquires         *prometheus.Desc
+	emptyAcquires    *prometheus.Desc
+	emptyAcquireWait *prometheus.Desc
+	canceledAcquires *prometheus.Desc
+}
+
+// RegisterPgxPool exports the connection pool stats of pool.
+func RegisterPgxPool(pool *pgxpool.Pool) {
+	prometheus.MustRegister(newPgxPoolCollector(pool))
+}
+
+func newPgxPoolCollector(pool *pgxpool.Pool) *pgxPoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "pgx_pool", name), help, nil, nil)
+	}
+
+	return &pgxPoolCollector{
+		pool:             pool,
+		maxConns:         desc("max_conns", "Maximum size of the pgx pool."),
+		acquiredConns:    desc("acquired_conns", "Connections of the pgx pool in use."),
+		idleConns:        desc("idle_conns", "Idle connections of the pgx pool."),
+		totalConns:       desc("total_conns", "Connections of the pgx pool, in use, idle or being opened."),
+		acquires:         desc("acquires_total", "Connections acquired from the pgx pool."),
+		emptyAcquires:    desc("empty_acquires_total", "Acquires that waited for a connection, the pool having none idle."),
+		emptyAcquireWait: desc("empty_acquire_wait_seconds_total", "Time spent waiting by the acquires that found no idle connection."),
+		canceledAcquires: desc("canceled_acquires_total", "Acquires canceled before getting a connection."),
+	}
+}
+
+func (c *pgxPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxConns
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.acquires
+	ch <- c.emptyAcquires
+	ch <- c.emptyAcquireWait
+	ch <- c.canceledAcquires
+}
+
+func (c *pgxPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireWait, prometheus.CounterValue, stat.EmptyAcquireWaitTime().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+}
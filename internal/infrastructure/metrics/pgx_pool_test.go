@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPgxPoolCollector(t *testing.T) {
+	t.Run("exports the stats of the pool", func(t *testing.T) {
+		// given
+		// The pool connects only once a connection is acquired.
+		pool, err := pgxpool.New(context.Background(), "postgres://localhost:1/pismo?pool_max_conns=7")
+		require.NoError(t, err)
+		defer pool.Close()
+
+		// when
+		err = testutil.CollectAndCompare(newPgxPoolCollector(pool), strings.NewReader(`
+			# HELP pismo_pgx_pool_max_conns Maximum size of the pgx pool.
+			# TYPE pismo_pgx_pool_max_conns gauge
+			pismo_pgx_pool_max_conns 7
+			# HELP pismo_pgx_pool_acquired_conns Connections of the pgx pool in use.
+			# TYPE pismo_pgx_pool_acquired_conns gauge
+			pismo_pgx_pool_acquired_conns 0
+		`), "pismo_pgx_pool_max_conns", "pismo_pgx_pool_acquired_conns")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 8, testutil.CollectAndCount(newPgxPoolCollector(pool)))
+	})
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"math"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+// TransactionRepository counts the transactions created and the discharges
+// performed through the repository it wraps.
+type TransactionRepository struct {
+	domain.TransactionRepository
+}
+
+func NewTransactionRepository(repo domain.TransactionRepository) *TransactionRepository {
+	return &TransactionRepository{TransactionRepository: repo}
+}
+
+func (r *TransactionRepository) Create(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, error) {
+	created, err := r.TransactionRepository.Create(ctx, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	operationType := transaction.OperationTypeID.String()
+	TransactionsCreated.WithLabelValues(operationType).Inc()
+	TransactionAmount.WithLabelValues(operationType, transaction.SettlementCurrency).Add(math.Abs(transaction.Amount))
+
+	return created, nil
+}
+
+// UpdateBalances is only used to discharge debits.
+func (r *TransactionRepository) UpdateBalances(ctx context.Context, transactions []*domain.Transaction) error {
+	if err := r.TransactionRepository.UpdateBalances(ctx, transactions); err != nil {
+		return err
+	}
+
+	Discharges.Add(float64(len(transactions)))
+	return nil
+}
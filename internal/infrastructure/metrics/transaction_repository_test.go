@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+)
+
+func TestTransactionRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	repo := NewTransactionRepository(mockRepo)
+
+	t.Run("counts created transactions and their amounts", func(t *testing.T) {
+		// given
+		transaction := &domain.Transaction{AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.5, SettlementCurrency: "BRL"}
+		created := testutil.ToFloat64(TransactionsCreated.WithLabelValues("purchase"))
+		amount := testutil.ToFloat64(TransactionAmount.WithLabelValues("purchase", "BRL"))
+
+		// when
+		mockRepo.EXPECT().Create(gomock.Any(), transaction).Return(&domain.Transaction{ID: 1}, nil)
+
+		result, err := repo.Create(context.Background(), transaction)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), result.ID)
+		assert.Equal(t, created+1, testutil.ToFloat64(TransactionsCreated.WithLabelValues("purchase")))
+		assert.Equal(t, amount+50.5, testutil.ToFloat64(TransactionAmount.WithLabelValues("purchase", "BRL")))
+	})
+
+	t.Run("does not count transactions that failed", func(t *testing.T) {
+		// given
+		transaction := &domain.Transaction{AccountID: 1, OperationTypeID: domain.OperationTypePayment, Amount: 10}
+		created := testutil.ToFloat64(TransactionsCreated.WithLabelValues("payment"))
+
+		// when
+		mockRepo.EXPECT().Create(gomock.Any(), transaction).Return(nil, errors.New("database error"))
+
+		_, err := repo.Create(context.Background(), transaction)
+
+		// then
+		assert.Error(t, err)
+		assert.Equal(t, created, testutil.ToFloat64(TransactionsCreated.WithLabelValues("payment")))
+	})
+
+	t.Run("counts discharges", func(t *testing.T) {
+		// given
+		transactions := []*domain.Transaction{{ID: 1, Balance: 0}, {ID: 2, Balance: -5}}
+		discharges := testutil.ToFloat64(Discharges)
+
+		// when
+		mockRepo.EXPECT().UpdateBalances(gomock.Any(), transactions).Return(nil)
+
+		err := repo.UpdateBalances(context.Background(), transactions)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, discharges+2, testutil.ToFloat64(Discharges))
+	})
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+// PostgresStore keeps buckets in the rate_limit_buckets table so every
+// instance shares them. Each take locks the key's row for its transaction.
+type PostgresStore struct {
+	db *sql.DB
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (_ Result, err error) {
+	ctx, span := tracing.StartQuery(ctx, "RateLimitStore.Take")
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.sweep(ctx, now); err != nil {
+		return Result{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Result{}, err
+	}
+	defer tx.Rollback()
+
+	initial := newBucket(limit, now)
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO rate_limit_buckets (key, tokens, updated_at, expires_at)
+		VALUES ($1, $2, $3, $3)
+		ON CONFLICT (key) DO NOTHING
+	`, key, initial.tokens, initial.updatedAt)
+	if err != nil {
+		return Result{}, err
+	}
+
+	b := &bucket{}
+	err = tx.QueryRowContext(ctx, `
+		SELECT tokens, updated_at
+		FROM rate_limit_buckets
+		WHERE key = $1
+		FOR UPDATE
+	`, key).Scan(&b.tokens, &b.updatedAt)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := b.take(limit, now)
+	_, err = tx.ExecContext(ctx, `
+		UPDATE rate_limit_buckets
+		SET tokens = $2, updated_at = $3, expires_at = $4
+		WHERE key = $1
+	`, key, b.tokens, b.updatedAt, now.Add(result.Reset))
+	if err != nil {
+		return Result{}, err
+	}
+
+	return result, tx.Commit()
+}
+
+// sweep deletes the buckets that are full again, at most once per interval
+// on each instance.
+func (s *PostgresStore) sweep(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	if now.Sub(s.lastSweep) < sweepInterval {
+		s.mu.Unlock()
+		return nil
+	}
+	s.lastSweep = now
+	s.mu.Unlock()
+
+	_, err := s.db.ExecContext(ctx, `DELETE FROM rate_limit_buckets WHERE expires_at <= $1`, now)
+	return err
+}
@@ -0,0 +1,141 @@
+// Package ratelimit implements token bucket rate limits whose buckets live in
+// memory, for a single instance, or in Postgres, shared by every instance.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AllRoutes is the route of policies applying to routes without their own.
+const AllRoutes = "*"
+
+// KeyKind is what a policy counts requests by.
+type KeyKind string
+
+const (
+	KeyClient  KeyKind = "client"
+	KeyIP      KeyKind = "ip"
+	KeyAccount KeyKind = "account"
+)
+
+func (k KeyKind) IsValid() bool {
+	switch k {
+	case KeyClient, KeyIP, KeyAccount:
+		return true
+	}
+	return false
+}
+
+// Limit allows Requests per Period, in bursts of up to Requests.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Policy limits the requests to a route, as registered in the router, per key.
+type Policy struct {
+	Route string
+	Key   KeyKind
+	Limit Limit
+}
+
+// Result is the outcome of taking a token from a bucket.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long until a token is available, zero when allowed.
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+}
+
+// bucket holds the tokens left for a key. Tokens are refilled continuously
+// at the limit's rate rather than all at once at the end of each period.
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+func newBucket(limit Limit, now time.Time) *bucket {
+	return &bucket{tokens: float64(limit.Requests), updatedAt: now}
+}
+
+func (b *bucket) take(limit Limit, now time.Time) Result {
+	// Clocks of different instances may disagree, never refill backwards.
+	if elapsed := now.Sub(b.updatedAt); elapsed > 0 {
+		b.tokens = math.Min(float64(limit.Requests), b.tokens+elapsed.Seconds()*limit.rate())
+		b.updatedAt = now
+	}
+
+	result := Result{Limit: limit.Requests}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / limit.rate())
+	}
+
+	result.Remaining = int(b.tokens)
+	result.Reset = b.fullIn(limit)
+	return result
+}
+
+func (b *bucket) fullIn(limit Limit) time.Duration {
+	return seconds((float64(limit.Requests) - b.tokens) / limit.rate())
+}
+
+// seconds converts to a duration rounded to the millisecond, hiding float
+// rounding errors.
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Round(s*1000)) * time.Millisecond
+}
+
+// ParsePolicies reads a comma separated list of "ROUTE=KEY:REQUESTS/PERIOD"
+// entries, such as "POST /transactions=client:100/1m". ROUTE is a route
+// pattern of the router or * for every route without its own policies.
+func ParsePolicies(spec string) ([]Policy, error) {
+	var policies []Policy
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, rule, ok := strings.Cut(entry, "=")
+		key, limit, ok2 := strings.Cut(rule, ":")
+		requests, period, ok3 := strings.Cut(limit, "/")
+		if !ok || !ok2 || !ok3 || strings.TrimSpace(route) == "" {
+			return nil, fmt.Errorf("invalid rate limit entry %q", entry)
+		}
+
+		policy := Policy{Route: strings.TrimSpace(route), Key: KeyKind(key)}
+		if !policy.Key.IsValid() {
+			return nil, fmt.Errorf("invalid rate limit key %q, must be client, ip or account", key)
+		}
+
+		var err error
+		policy.Limit.Requests, err = strconv.Atoi(requests)
+		if err != nil || policy.Limit.Requests <= 0 {
+			return nil, fmt.Errorf("invalid rate limit requests %q", requests)
+		}
+
+		policy.Limit.Period, err = time.ParseDuration(period)
+		if err != nil || policy.Limit.Period <= 0 {
+			return nil, fmt.Errorf("invalid rate limit period %q", period)
+		}
+
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	limit := Limit{Requests: 2, Period: 10 * time.Second}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("allows bursts up to the limit", func(t *testing.T) {
+		// given
+		store := NewMemoryStore()
+
+		// when
+		first, _ := store.Take(context.Background(), "key", limit, start)
+		second, _ := store.Take(context.Background(), "key", limit, start)
+		third, err := store.Take(context.Background(), "key", limit, start)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 1, Reset: 5 * time.Second}, first)
+		assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 10 * time.Second}, second)
+		assert.Equal(t, Result{Allowed: false, Limit: 2, Remaining: 0, RetryAfter: 5 * time.Second, Reset: 10 * time.Second}, third)
+	})
+
+	t.Run("refills tokens over time", func(t *testing.T) {
+		// given
+		store := NewMemoryStore()
+		store.Take(context.Background(), "key", limit, start)
+		store.Take(context.Background(), "key", limit, start)
+
+		// when
+		early, _ := store.Take(context.Background(), "key", limit, start.Add(2*time.Second))
+		refilled, _ := store.Take(context.Background(), "key", limit, start.Add(5*time.Second))
+
+		// then
+		assert.False(t, early.Allowed)
+		assert.Equal(t, 3*time.Second, early.RetryAfter)
+		assert.True(t, refilled.Allowed)
+	})
+
+	t.Run("keeps keys apart", func(t *testing.T) {
+		// given
+		store := NewMemoryStore()
+		store.Take(context.Background(), "a", limit, start)
+		store.Take(context.Background(), "a", limit, start)
+
+		// when
+		result, _ := store.Take(context.Background(), "b", limit, start)
+
+		// then
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("drops buckets once they are full again", func(t *testing.T) {
+		// given
+		store := NewMemoryStore()
+		store.Take(context.Background(), "key", limit, start)
+
+		// when
+		store.Take(context.Background(), "other", limit, start.Add(2*sweepInterval))
+
+		// then
+		assert.NotContains(t, store.buckets, "key")
+		assert.Contains(t, store.buckets, "other")
+	})
+}
+
+func TestParsePolicies(t *testing.T) {
+	t.Run("parses policy entries", func(t *testing.T) {
+		policies, err := ParsePolicies("POST /transactions=client:100/1m, POST /transactions=account:10/1s,*=ip:1000/1h")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Policy{
+			{Route: "POST /transactions", Key: KeyClient, Limit: Limit{Requests: 100, Period: time.Minute}},
+			{Route: "POST /transactions", Key: KeyAccount, Limit: Limit{Requests: 10, Period: time.Second}},
+			{Route: AllRoutes, Key: KeyIP, Limit: Limit{Requests: 1000, Period: time.Hour}},
+		}, policies)
+	})
+
+	t.Run("returns no policies for empty spec", func(t *testing.T) {
+		policies, err := ParsePolicies("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, policies)
+	})
+
+	t.Run("returns error for malformed entries", func(t *testing.T) {
+		for _, spec := range []string{
+			"POST /transactions",
+			"POST /transactions=client",
+			"POST /transactions=client:100",
+			"POST /transactions=user:100/1m",
+			"POST /transactions=client:0/1m",
+			"POST /transactions=client:abc/1m",
+			"POST /transactions=client:100/0s",
+			"POST /transactions=client:100/minute",
+			"=client:100/1m",
+		} {
+			_, err := ParsePolicies(spec)
+
+			assert.Error(t, err, spec)
+		}
+	})
+}
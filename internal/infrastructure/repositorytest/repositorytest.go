@@ -0,0 +1,455 @@
+// Package repositorytest holds the contract every implementation of the
+// account and transaction repositories honors, so that the in-memory and
+// Postgres ones are held to the same behavior.
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+// Repositories are the implementations under test, sharing one store.
+type Repositories struct {
+	Accounts     domain.AccountRepository
+	Transactions domain.TransactionRepository
+}
+
+// Factory returns the repositories a subtest runs against. It may hand out a
+// fresh store each time or the same one, like a database shared by all.
+type Factory func(t *testing.T) Repositories
+
+// concurrency is how many goroutines the concurrent subtests run at once.
+const concurrency = 20
+
+// documentNumbers keeps document numbers unique across runs against the same
+// database.
+var documentNumbers atomic.Int64
+
+func init() {
+	documentNumbers.Store(time.Now().UnixNano() % 1e15)
+}
+
+// Run asserts the contract against the repositories of newRepositories,
+// called once per subtest. Every subtest creates its own accounts, so the
+// store may already hold data.
+func Run(t *testing.T, newRepositories Factory) {
+	ctx := context.Background()
+
+	newAccount := func(t *testing.T, repos Repositories) *domain.Account {
+		t.Helper()
+		account, err := repos.Accounts.Create(ctx, &domain.Account{DocumentNumber: newDocumentNumber(), Currency: "BRL"})
+		require.NoError(t, err)
+		return account
+	}
+
+	t.Run("creates and finds an account", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		documentNumber := newDocumentNumber()
+
+		// when
+		created, err := repos.Accounts.Create(ctx, &domain.Account{DocumentNumber: documentNumber, Currency: "USD"})
+		require.NoError(t, err)
+		found, err := repos.Accounts.FindByID(ctx, created.ID)
+
+		// then
+		require.NoError(t, err)
+		assert.NotZero(t, created.ID)
+		assert.Equal(t, &domain.Account{ID: created.ID, DocumentNumber: documentNumber, Currency: "USD"}, found)
+	})
+
+	t.Run("rejects a document number already in use", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		account := newAccount(t, repos)
+
+		// when
+		_, err := repos.Accounts.Create(ctx, &domain.Account{DocumentNumber: account.DocumentNumber, Currency: "BRL"})
+
+		// then
+		assert.ErrorIs(t, err, domain.ErrAccountAlreadyExists)
+	})
+
+	t.Run("reports missing accounts", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+
+		// when
+		_, err := repos.Accounts.FindByID(ctx, 999999999)
+
+		// then
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+
+	t.Run("creates and finds a transaction", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		account := newAccount(t, repos)
+		transaction := newTransaction(account.ID, time.Now())
+		transaction.ExternalID = "auth-1"
+
+		// when
+		created, err := repos.Transactions.Create(ctx, transaction)
+		require.NoError(t, err)
+		byID, errByID := repos.Transactions.FindByID(ctx, created.ID)
+		byExternalID, errByExternalID := repos.Transactions.FindByExternalID(ctx, account.ID, "auth-1")
+
+		// then
+		require.NoError(t, errByID)
+		require.NoError(t, errByExternalID)
+		assert.NotZero(t, created.ID)
+		for _, found := range []*domain.Transaction{byID, byExternalID} {
+			assert.Equal(t, created.ID, found.ID)
+			assert.Equal(t, account.ID, found.AccountID)
+			assert.Equal(t, domain.OperationTypePurchase, found.OperationTypeID)
+			assert.Equal(t, -50.0, found.Amount)
+			assert.Equal(t, -50.0, found.Balance)
+			assert.Equal(t, 1.0, found.FXRate)
+			assert.Equal(t, "BRL", found.SettlementCurrency)
+			assert.True(t, transaction.EventDate.Equal(found.EventDate))
+			assert.Equal(t, "auth-1", found.ExternalID)
+			assert.Equal(t, map[string]any{"channel": "pos"}, found.Metadata)
+			assert.Zero(t, found.CardID)
+		}
+	})
+
+	t.Run("rejects transactions breaking references", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		account := newAccount(t, repos)
+		unknownAccount := newTransaction(999999999, time.Now())
+		unknownOperationType := newTransaction(account.ID, time.Now())
+		unknownOperationType.OperationTypeID = 99
+		unknownCard := newTransaction(account.ID, time.Now())
+		unknownCard.CardID = 999999999
+
+		// when
+		_, unknownAccountErr := repos.Transactions.Create(ctx, unknownAccount)
+		_, unknownOperationTypeErr := repos.Transactions.Create(ctx, unknownOperationType)
+		_, unknownCardErr := repos.Transactions.Create(ctx, unknownCard)
+
+		// then
+		assert.ErrorIs(t, unknownAccountErr, domain.ErrAccountNotFound)
+		assert.ErrorIs(t, unknownOperationTypeErr, domain.ErrInvalidOperationType)
+		assert.ErrorIs(t, unknownCardErr, domain.ErrCardNotFound)
+	})
+
+	t.Run("keeps external ids unique per account", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		account, other := newAccount(t, repos), newAccount(t, repos)
+		first := newTransaction(account.ID, time.Now())
+		first.ExternalID = "auth-1"
+		_, err := repos.Transactions.Create(ctx, first)
+		require.NoError(t, err)
+		duplicate := newTransaction(account.ID, time.Now())
+		duplicate.ExternalID = "auth-1"
+		otherAccount := newTransaction(other.ID, time.Now())
+		otherAccount.ExternalID = "auth-1"
+
+		// when
+		_, duplicateErr := repos.Transactions.Create(ctx, duplicate)
+		_, otherAccountErr := repos.Transactions.Create(ctx, otherAccount)
+
+		// then
+		assert.ErrorIs(t, duplicateErr, domain.ErrTransactionAlreadyExists)
+		assert.NoError(t, otherAccountErr)
+	})
+
+	t.Run("reports missing transactions", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		account := newAccount(t, repos)
+
+		// when
+		_, byIDErr := repos.Transactions.FindByID(ctx, 999999999)
+		_, byExternalIDErr := repos.Transactions.FindByExternalID(ctx, account.ID, "unknown")
+
+		// then
+		assert.ErrorIs(t, byIDErr, domain.ErrTransactionNotFound)
+		assert.ErrorIs(t, byExternalIDErr, domain.ErrTransactionNotFound)
+	})
+
+	t.Run("lists the transactions of an account by event date", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		account, other := newAccount(t, repos), newAccount(t, repos)
+		now := time.Now()
+		for _, eventDate := range []time.Time{now, now.Add(-2 * time.Hour), now.Add(-time.Hour)} {
+			_, err := repos.Transactions.Create(ctx, newTransaction(account.ID, eventDate))
+			require.NoError(t, err)
+		}
+		_, err := repos.Transactions.Create(ctx, newTransaction(other.ID, now))
+		require.NoError(t, err)
+
+		// when
+		transactions, err := repos.Transactions.ListByAccountID(ctx, account.ID)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, transactions, 3)
+		assert.True(t, transactions[0].EventDate.Equal(now.Add(-2*time.Hour).UTC().Truncate(time.Microsecond)))
+		assert.True(t, transactions[1].EventDate.Equal(now.Add(-time.Hour).UTC().Truncate(time.Microsecond)))
+		assert.True(t, transactions[2].EventDate.Equal(now.UTC().Truncate(time.Microsecond)))
+	})
+
+	t.Run("lists no transactions for an account without any", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		account := newAccount(t, repos)
+
+		// when
+		transactions, err := repos.Transactions.ListByAccountID(ctx, account.ID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, transactions)
+	})
+
+	t.Run("updates balances", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		account := newAccount(t, repos)
+		first, err := repos.Transactions.Create(ctx, newTransaction(account.ID, time.Now()))
+		require.NoError(t, err)
+		second, err := repos.Transactions.Create(ctx, newTransaction(account.ID, time.Now()))
+		require.NoError(t, err)
+		first.Balance = 0
+		second.Balance = -12.5
+
+		// when
+		err = repos.Transactions.UpdateBalances(ctx, []*domain.Transaction{first, second})
+
+		// then
+		require.NoError(t, err)
+		found, err := repos.Transactions.FindByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, found.Balance)
+		found, err = repos.Transactions.FindByID(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Equal(t, -12.5, found.Balance)
+	})
+
+	t.Run("updates nothing but the given balances", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		account := newAccount(t, repos)
+		updated, err := repos.Transactions.Create(ctx, newTransaction(account.ID, time.Now()))
+		require.NoError(t, err)
+		untouched, err := repos.Transactions.Create(ctx, newTransaction(account.ID, time.Now()))
+		require.NoError(t, err)
+		// Only the balance is meant to be stored.
+		changed := *updated
+		changed.Balance = 0
+		changed.Amount = -1
+
+		// when
+		err = repos.Transactions.UpdateBalances(ctx, []*domain.Transaction{&changed})
+
+		// then
+		require.NoError(t, err)
+		found, err := repos.Transactions.FindByID(ctx, updated.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, found.Balance)
+		assert.Equal(t, -50.0, found.Amount)
+		found, err = repos.Transactions.FindByID(ctx, untouched.ID)
+		require.NoError(t, err)
+		assert.Equal(t, -50.0, found.Balance)
+	})
+
+	t.Run("updates no balance when given none", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+
+		// when
+		err := repos.Transactions.UpdateBalances(ctx, nil)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("keeps transactions without metadata", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		account := newAccount(t, repos)
+		transaction := newTransaction(account.ID, time.Now())
+		transaction.Metadata = nil
+		created, err := repos.Transactions.Create(ctx, transaction)
+		require.NoError(t, err)
+
+		// when
+		found, err := repos.Transactions.FindByID(ctx, created.ID)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, found.Metadata)
+	})
+
+	t.Run("accepts one of concurrent accounts with the same document number", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		documentNumber := newDocumentNumber()
+
+		// when
+		errs := concurrently(func() error {
+			_, err := repos.Accounts.Create(ctx, &domain.Account{DocumentNumber: documentNumber, Currency: "BRL"})
+			return err
+		})
+
+		// then
+		assertOneSucceeds(t, errs, domain.ErrAccountAlreadyExists)
+	})
+
+	t.Run("accepts one of concurrent transactions with the same external id", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		account := newAccount(t, repos)
+
+		// when
+		errs := concurrently(func() error {
+			transaction := newTransaction(account.ID, time.Now())
+			transaction.ExternalID = "auth-1"
+			_, err := repos.Transactions.Create(ctx, transaction)
+			return err
+		})
+
+		// then
+		assertOneSucceeds(t, errs, domain.ErrTransactionAlreadyExists)
+	})
+
+	t.Run("creates concurrent transactions with distinct ids", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		account := newAccount(t, repos)
+		var mu sync.Mutex
+		ids := make(map[int64]bool)
+
+		// when
+		errs := concurrently(func() error {
+			created, err := repos.Transactions.Create(ctx, newTransaction(account.ID, time.Now()))
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			ids[created.ID] = true
+			return nil
+		})
+
+		// then
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+		assert.Len(t, ids, concurrency)
+		transactions, err := repos.Transactions.ListByAccountID(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Len(t, transactions, concurrency)
+	})
+
+	t.Run("shows concurrent listings all balances of an update or none", func(t *testing.T) {
+		// given
+		repos := newRepositories(t)
+		account := newAccount(t, repos)
+		var transactions []*domain.Transaction
+		for range 3 {
+			created, err := repos.Transactions.Create(ctx, newTransaction(account.ID, time.Now()))
+			require.NoError(t, err)
+			transactions = append(transactions, created)
+		}
+
+		// when
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for balance := range concurrency {
+				for _, transaction := range transactions {
+					transaction.Balance = float64(balance)
+				}
+				assert.NoError(t, repos.Transactions.UpdateBalances(ctx, transactions))
+			}
+		}()
+		var listings [][]*domain.Transaction
+		for range concurrency {
+			listed, err := repos.Transactions.ListByAccountID(ctx, account.ID)
+			require.NoError(t, err)
+			listings = append(listings, listed)
+		}
+		wg.Wait()
+
+		// then
+		for _, listed := range listings {
+			require.Len(t, listed, 3)
+			for _, transaction := range listed[1:] {
+				assert.Equal(t, listed[0].Balance, transaction.Balance)
+			}
+		}
+	})
+}
+
+// concurrently calls fn from as many goroutines as concurrency, all released
+// at once, and returns their errors.
+func concurrently(fn func() error) []error {
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make([]error, concurrency)
+	for i := range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs[i] = fn()
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	return errs
+}
+
+// assertOneSucceeds asserts that a single call succeeded and the others
+// failed with conflict.
+func assertOneSucceeds(t *testing.T, errs []error, conflict error) {
+	t.Helper()
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, conflict)
+	}
+	assert.Equal(t, 1, succeeded)
+}
+
+func newDocumentNumber() string {
+	return fmt.Sprintf("%d", documentNumbers.Add(1))
+}
+
+// newTransaction returns a purchase of 50 BRL. Event dates are kept to the
+// microsecond precision of Postgres timestamps.
+func newTransaction(accountID int64, eventDate time.Time) *domain.Transaction {
+	return &domain.Transaction{
+		AccountID:          accountID,
+		OperationTypeID:    domain.OperationTypePurchase,
+		Amount:             -50.0,
+		EventDate:          eventDate.UTC().Truncate(time.Microsecond),
+		Balance:            -50.0,
+		OriginalAmount:     -50.0,
+		SettlementCurrency: "BRL",
+		FXRate:             1,
+		TransactionDetails: domain.TransactionDetails{
+			Currency: "BRL",
+			Metadata: map[string]any{"channel": "pos"},
+		},
+	}
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: scheduler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=scheduler.go -destination=mocks/scheduler_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockleaderLock is a mock of leaderLock interface.
+type MockleaderLock struct {
+	ctrl     *gomock.Controller
+	recorder *MockleaderLockMockRecorder
+	isgomock struct{}
+}
+
+// MockleaderLockMockRecorder is the mock recorder for MockleaderLock.
+type MockleaderLockMockRecorder struct {
+	mock *MockleaderLock
+}
+
+// NewMockleaderLock creates a new mock instance.
+func NewMockleaderLock(ctrl *gomock.Controller) *MockleaderLock {
+	mock := &MockleaderLock{ctrl: ctrl}
+	mock.recorder = &MockleaderLockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockleaderLock) EXPECT() *MockleaderLockMockRecorder {
+	return m.recorder
+}
+
+// Release mocks base method.
+func (m *MockleaderLock) Release(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockleaderLockMockRecorder) Release(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockleaderLock)(nil).Release), ctx)
+}
+
+// TryAcquire mocks base method.
+func (m *MockleaderLock) TryAcquire(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryAcquire", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryAcquire indicates an expected call of TryAcquire.
+func (mr *MockleaderLockMockRecorder) TryAcquire(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryAcquire", reflect.TypeOf((*MockleaderLock)(nil).TryAcquire), ctx)
+}
+
+// MockdueRunner is a mock of dueRunner interface.
+type MockdueRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockdueRunnerMockRecorder
+	isgomock struct{}
+}
+
+// MockdueRunnerMockRecorder is the mock recorder for MockdueRunner.
+type MockdueRunnerMockRecorder struct {
+	mock *MockdueRunner
+}
+
+// NewMockdueRunner creates a new mock instance.
+func NewMockdueRunner(ctrl *gomock.Controller) *MockdueRunner {
+	mock := &MockdueRunner{ctrl: ctrl}
+	mock.recorder = &MockdueRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdueRunner) EXPECT() *MockdueRunnerMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockdueRunner) Execute(ctx context.Context, at time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, at)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockdueRunnerMockRecorder) Execute(ctx, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockdueRunner)(nil).Execute), ctx, at)
+}
@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+)
+
+// LeaderLockKey identifies the advisory lock instances compete for so only one
+// of them runs scheduled transactions at a time.
+const LeaderLockKey int64 = 7_311_032
+
+//go:generate mockgen -source=scheduler.go -destination=mocks/scheduler_mock.go -package=mocks
+type leaderLock interface {
+	TryAcquire(ctx context.Context) (bool, error)
+	Release(ctx context.Context) error
+}
+
+type dueRunner interface {
+	Execute(ctx context.Context, at time.Time) (int, error)
+}
+
+// Scheduler periodically runs the scheduled transactions that are due. Every
+// instance runs one, but only the one holding the leader lock does any work.
+type Scheduler struct {
+	lock     leaderLock
+	runner   dueRunner
+	interval time.Duration
+	now      func() time.Time
+}
+
+func New(lock leaderLock, runner dueRunner, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		lock:     lock,
+		runner:   runner,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Run ticks until ctx is cancelled, then gives up leadership.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	defer func() {
+		if err := s.lock.Release(context.Background()); err != nil {
+			logger.Error(ctx, "failed to release scheduler leader lock",
+				slog.String("error", err.Error()),
+			)
+		}
+	}()
+
+	for {
+		s.Tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick runs the due scheduled transactions once if this instance is the leader.
+func (s *Scheduler) Tick(ctx context.Context) {
+	leader, err := s.lock.TryAcquire(ctx)
+	if err != nil {
+		logger.Error(ctx, "failed to acquire scheduler leader lock",
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	if !leader {
+		return
+	}
+
+	advanced, err := s.runner.Execute(ctx, s.now().UTC())
+	if err != nil {
+		logger.Error(ctx, "failed to run due scheduled transactions",
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	if advanced > 0 {
+		logger.Info(ctx, "ran due scheduled transactions",
+			slog.Int("count", advanced),
+		)
+	}
+}
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/scheduler/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScheduler_Tick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLock := mocks.NewMockleaderLock(ctrl)
+	mockRunner := mocks.NewMockdueRunner(ctrl)
+	scheduler := New(mockLock, mockRunner, time.Minute)
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	scheduler.now = func() time.Time { return now }
+
+	t.Run("runs due transactions when leader", func(t *testing.T) {
+		mockLock.EXPECT().TryAcquire(gomock.Any()).Return(true, nil)
+		mockRunner.EXPECT().Execute(gomock.Any(), now).Return(2, nil)
+
+		scheduler.Tick(context.Background())
+	})
+
+	t.Run("does nothing when another instance is leader", func(t *testing.T) {
+		mockLock.EXPECT().TryAcquire(gomock.Any()).Return(false, nil)
+
+		scheduler.Tick(context.Background())
+	})
+
+	t.Run("does nothing when lock cannot be checked", func(t *testing.T) {
+		mockLock.EXPECT().TryAcquire(gomock.Any()).Return(false, errors.New("connection refused"))
+
+		scheduler.Tick(context.Background())
+	})
+}
+
+func TestScheduler_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLock := mocks.NewMockleaderLock(ctrl)
+	mockRunner := mocks.NewMockdueRunner(ctrl)
+	scheduler := New(mockLock, mockRunner, time.Hour)
+
+	t.Run("releases leadership when stopped", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		mockLock.EXPECT().TryAcquire(gomock.Any()).Return(true, nil)
+		mockRunner.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, at time.Time) (int, error) {
+				cancel()
+				return 0, nil
+			},
+		)
+		mockLock.EXPECT().Release(gomock.Any()).Return(nil)
+
+		done := make(chan struct{})
+		go func() {
+			scheduler.Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			assert.Fail(t, "scheduler did not stop")
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestSetup(t *testing.T) {
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	t.Run("installs a tracer provider and trace-context propagation", func(t *testing.T) {
+		for _, exporter := range []string{ExporterNone, ExporterStdout, ExporterOTLP} {
+			// when
+			shutdown, err := Setup(context.Background(), Config{ServiceName: "pismo-api", Exporter: exporter})
+
+			// then
+			assert.NoError(t, err, exporter)
+			assert.Contains(t, otel.GetTextMapPropagator().Fields(), "traceparent")
+
+			_, span := otel.Tracer("test").Start(context.Background(), "span")
+			assert.True(t, span.SpanContext().IsValid(), exporter)
+			span.End()
+
+			assert.NoError(t, shutdown(context.Background()), exporter)
+		}
+	})
+
+	t.Run("returns error for unknown exporters", func(t *testing.T) {
+		_, err := Setup(context.Background(), Config{ServiceName: "pismo-api", Exporter: "zipkin"})
+
+		assert.ErrorIs(t, err, ErrInvalidExporter)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
 )
 
 type CreateAccount struct {
@@ -14,8 +15,11 @@ func NewCreateAccount(repo domain.AccountRepository) *CreateAccount {
 	return &CreateAccount{repo: repo}
 }
 
-func (c *CreateAccount) Execute(ctx context.Context, documentNumber string) (*domain.Account, error) {
-	account, err := domain.NewAccount(documentNumber)
+func (c *CreateAccount) Execute(ctx context.Context, documentNumber string, currency string) (_ *domain.Account, err error) {
+	ctx, span := tracing.Start(ctx, "CreateAccount.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	account, err := domain.NewAccount(documentNumber, currency)
 	if err != nil {
 		return nil, err
 	}
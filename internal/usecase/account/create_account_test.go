@@ -28,7 +28,7 @@ func TestCreateAccount(t *testing.T) {
 		// when
 		mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(expectedAccount, nil)
 
-		account, err := usecase.Execute(context.Background(), "12345678900")
+		account, err := usecase.Execute(context.Background(), "12345678900", "")
 
 		// then
 		assert.NoError(t, err)
@@ -42,7 +42,7 @@ func TestCreateAccount(t *testing.T) {
 		documentNumber := ""
 
 		// when
-		account, err := usecase.Execute(context.Background(), documentNumber)
+		account, err := usecase.Execute(context.Background(), documentNumber, "")
 
 		// then
 		assert.Nil(t, account)
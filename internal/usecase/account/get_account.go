@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
 )
 
 type GetAccount struct {
@@ -14,6 +15,9 @@ func NewGetAccount(repo domain.AccountRepository) *GetAccount {
 	return &GetAccount{repo: repo}
 }
 
-func (g *GetAccount) Execute(ctx context.Context, accountID int64) (*domain.Account, error) {
+func (g *GetAccount) Execute(ctx context.Context, accountID int64) (_ *domain.Account, err error) {
+	ctx, span := tracing.Start(ctx, "GetAccount.Execute")
+	defer func() { tracing.End(span, err) }()
+
 	return g.repo.FindByID(ctx, accountID)
 }
@@ -0,0 +1,42 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type AuthenticateAPIKey struct {
+	repo domain.APIKeyRepository
+}
+
+func NewAuthenticateAPIKey(repo domain.APIKeyRepository) *AuthenticateAPIKey {
+	return &AuthenticateAPIKey{repo: repo}
+}
+
+// Execute returns the active key matching the one presented by a client, or
+// ErrInvalidAPIKey when there is none.
+func (a *AuthenticateAPIKey) Execute(ctx context.Context, key string) (_ *domain.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "AuthenticateAPIKey.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	if key == "" {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	apiKey, err := a.repo.FindByHash(ctx, domain.HashAPIKey(key))
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey.IsRevoked() {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	return apiKey, nil
+}
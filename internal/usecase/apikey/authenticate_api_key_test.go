@@ -0,0 +1,80 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	usecase := NewAuthenticateAPIKey(mockRepo)
+
+	t.Run("returns the active key", func(t *testing.T) {
+		// given
+		expected := &domain.APIKey{ID: 1, ClientID: "billing"}
+
+		// when
+		mockRepo.EXPECT().FindByHash(gomock.Any(), domain.HashAPIKey("pismo_secret")).Return(expected, nil)
+
+		apiKey, err := usecase.Execute(context.Background(), "pismo_secret")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, apiKey)
+	})
+
+	t.Run("rejects empty keys", func(t *testing.T) {
+		// when
+		apiKey, err := usecase.Execute(context.Background(), "")
+
+		// then
+		assert.Nil(t, apiKey)
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	})
+
+	t.Run("rejects unknown keys", func(t *testing.T) {
+		// when
+		mockRepo.EXPECT().FindByHash(gomock.Any(), gomock.Any()).Return(nil, domain.ErrAPIKeyNotFound)
+
+		apiKey, err := usecase.Execute(context.Background(), "pismo_unknown")
+
+		// then
+		assert.Nil(t, apiKey)
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	})
+
+	t.Run("rejects revoked keys", func(t *testing.T) {
+		// given
+		revokedAt := time.Now()
+
+		// when
+		mockRepo.EXPECT().FindByHash(gomock.Any(), gomock.Any()).Return(&domain.APIKey{ID: 1, RevokedAt: &revokedAt}, nil)
+
+		apiKey, err := usecase.Execute(context.Background(), "pismo_revoked")
+
+		// then
+		assert.Nil(t, apiKey)
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+	})
+
+	t.Run("returns repository errors", func(t *testing.T) {
+		// when
+		mockRepo.EXPECT().FindByHash(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+		apiKey, err := usecase.Execute(context.Background(), "pismo_secret")
+
+		// then
+		assert.Nil(t, apiKey)
+		assert.EqualError(t, err, "database error")
+	})
+}
@@ -0,0 +1,35 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type CreateAPIKey struct {
+	repo domain.APIKeyRepository
+}
+
+func NewCreateAPIKey(repo domain.APIKeyRepository) *CreateAPIKey {
+	return &CreateAPIKey{repo: repo}
+}
+
+// Execute stores a new key for the client and returns it with the key in
+// clear, which cannot be recovered afterwards.
+func (c *CreateAPIKey) Execute(ctx context.Context, clientID string, scopes []domain.Scope) (_ *domain.APIKey, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "CreateAPIKey.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	apiKey, key, err := domain.NewAPIKey(clientID, scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	created, err := c.repo.Create(ctx, apiKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return created, key, nil
+}
@@ -0,0 +1,61 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	usecase := NewCreateAPIKey(mockRepo)
+
+	t.Run("stores the hash and returns the key in clear", func(t *testing.T) {
+		// given
+		var stored *domain.APIKey
+
+		// when
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, apiKey *domain.APIKey) (*domain.APIKey, error) {
+			stored = apiKey
+			apiKey.ID = 1
+			return apiKey, nil
+		})
+
+		apiKey, key, err := usecase.Execute(context.Background(), "billing", []domain.Scope{domain.ScopeAccountsRead})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), apiKey.ID)
+		assert.Equal(t, domain.HashAPIKey(key), stored.Hash)
+	})
+
+	t.Run("returns validation error without touching the repository", func(t *testing.T) {
+		// when
+		apiKey, key, err := usecase.Execute(context.Background(), "billing", []domain.Scope{"admin"})
+
+		// then
+		assert.Nil(t, apiKey)
+		assert.Empty(t, key)
+		assert.ErrorIs(t, err, domain.ErrInvalidScope)
+	})
+
+	t.Run("returns error when repository fails", func(t *testing.T) {
+		// when
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+		apiKey, key, err := usecase.Execute(context.Background(), "billing", []domain.Scope{domain.ScopeAccountsRead})
+
+		// then
+		assert.Nil(t, apiKey)
+		assert.Empty(t, key)
+		assert.Error(t, err)
+	})
+}
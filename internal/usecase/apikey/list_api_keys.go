@@ -0,0 +1,23 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type ListAPIKeys struct {
+	repo domain.APIKeyRepository
+}
+
+func NewListAPIKeys(repo domain.APIKeyRepository) *ListAPIKeys {
+	return &ListAPIKeys{repo: repo}
+}
+
+func (l *ListAPIKeys) Execute(ctx context.Context) (_ []*domain.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "ListAPIKeys.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	return l.repo.List(ctx)
+}
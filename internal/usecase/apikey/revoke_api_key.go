@@ -0,0 +1,24 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type RevokeAPIKey struct {
+	repo domain.APIKeyRepository
+}
+
+func NewRevokeAPIKey(repo domain.APIKeyRepository) *RevokeAPIKey {
+	return &RevokeAPIKey{repo: repo}
+}
+
+func (r *RevokeAPIKey) Execute(ctx context.Context, apiKeyID int64) (err error) {
+	ctx, span := tracing.Start(ctx, "RevokeAPIKey.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	return r.repo.Revoke(ctx, apiKeyID, time.Now())
+}
@@ -0,0 +1,39 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRevokeAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	usecase := NewRevokeAPIKey(mockRepo)
+
+	t.Run("revokes the key", func(t *testing.T) {
+		// when
+		mockRepo.EXPECT().Revoke(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+
+		err := usecase.Execute(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns not found error", func(t *testing.T) {
+		// when
+		mockRepo.EXPECT().Revoke(gomock.Any(), int64(999), gomock.Any()).Return(domain.ErrAPIKeyNotFound)
+
+		err := usecase.Execute(context.Background(), 999)
+
+		// then
+		assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+	})
+}
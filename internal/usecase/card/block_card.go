@@ -0,0 +1,37 @@
+package card
+
+import (
+	"context"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type BlockCard struct {
+	repo domain.CardRepository
+}
+
+func NewBlockCard(repo domain.CardRepository) *BlockCard {
+	return &BlockCard{repo: repo}
+}
+
+// Execute blocks the card. Blocking an already blocked card is a no-op.
+func (b *BlockCard) Execute(ctx context.Context, cardID int64) (_ *domain.Card, err error) {
+	ctx, span := tracing.Start(ctx, "BlockCard.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	card, err := b.repo.FindByID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.AuthorizeAccount(ctx, card.AccountID); err != nil {
+		return nil, err
+	}
+
+	if card.Status == domain.CardStatusBlocked {
+		return card, nil
+	}
+
+	card.Block()
+	return b.repo.UpdateStatus(ctx, card)
+}
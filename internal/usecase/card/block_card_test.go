@@ -0,0 +1,79 @@
+package card
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBlockCard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedRepo := mocks.NewMockCardRepository(ctrl)
+	usecase := NewBlockCard(mockedRepo)
+
+	t.Run("blocks card successfully", func(t *testing.T) {
+		// given
+		cardID := int64(1)
+
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), cardID).Return(&domain.Card{ID: cardID, Status: domain.CardStatusActive}, nil)
+		mockedRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, card *domain.Card) (*domain.Card, error) {
+				return card, nil
+			},
+		)
+
+		card, err := usecase.Execute(context.Background(), cardID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CardStatusBlocked, card.Status)
+	})
+
+	t.Run("does not update card already blocked", func(t *testing.T) {
+		// given
+		cardID := int64(2)
+
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), cardID).Return(&domain.Card{ID: cardID, Status: domain.CardStatusBlocked}, nil)
+
+		card, err := usecase.Execute(context.Background(), cardID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CardStatusBlocked, card.Status)
+	})
+
+	t.Run("returns error when card does not exist", func(t *testing.T) {
+		// given
+		cardID := int64(999)
+
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), cardID).Return(nil, domain.ErrCardNotFound)
+
+		card, err := usecase.Execute(context.Background(), cardID)
+
+		// then
+		assert.Nil(t, card)
+		assert.ErrorIs(t, err, domain.ErrCardNotFound)
+	})
+
+	t.Run("denies callers restricted to another account", func(t *testing.T) {
+		// given
+		ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{ClientID: "mobile", AccountID: 2})
+
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(3)).Return(&domain.Card{ID: 3, AccountID: 1, Status: domain.CardStatusActive}, nil)
+
+		card, err := usecase.Execute(ctx, 3)
+
+		// then
+		assert.Nil(t, card)
+		assert.ErrorIs(t, err, domain.ErrAccountAccessDenied)
+	})
+}
@@ -0,0 +1,28 @@
+package card
+
+import (
+	"context"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type IssueCard struct {
+	repo domain.CardRepository
+}
+
+func NewIssueCard(repo domain.CardRepository) *IssueCard {
+	return &IssueCard{repo: repo}
+}
+
+func (i *IssueCard) Execute(ctx context.Context, accountID int64, cardType string) (_ *domain.Card, err error) {
+	ctx, span := tracing.Start(ctx, "IssueCard.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	card, err := domain.NewCard(accountID, domain.CardType(cardType))
+	if err != nil {
+		return nil, err
+	}
+
+	return i.repo.Create(ctx, card)
+}
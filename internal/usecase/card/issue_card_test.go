@@ -0,0 +1,60 @@
+package card
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIssueCard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedRepo := mocks.NewMockCardRepository(ctrl)
+	usecase := NewIssueCard(mockedRepo)
+
+	t.Run("issues card successfully", func(t *testing.T) {
+		// given
+		accountID := int64(1)
+
+		// when
+		mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, card *domain.Card) (*domain.Card, error) {
+				card.ID = 1
+				return card, nil
+			},
+		)
+
+		card, err := usecase.Execute(context.Background(), accountID, "physical")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), card.ID)
+		assert.Equal(t, accountID, card.AccountID)
+		assert.Equal(t, domain.CardTypePhysical, card.Type)
+		assert.Equal(t, domain.CardStatusActive, card.Status)
+	})
+
+	t.Run("returns error when card type is invalid", func(t *testing.T) {
+		// when
+		card, err := usecase.Execute(context.Background(), 1, "plastic")
+
+		// then
+		assert.Nil(t, card)
+		assert.ErrorIs(t, err, domain.ErrInvalidCardType)
+	})
+
+	t.Run("returns error when account does not exist", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, domain.ErrAccountNotFound)
+
+		card, err := usecase.Execute(context.Background(), 999, "virtual")
+
+		// then
+		assert.Nil(t, card)
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+}
@@ -0,0 +1,35 @@
+package dispute
+
+import (
+	"context"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type ListDisputes struct {
+	repo            domain.DisputeRepository
+	transactionRepo domain.TransactionRepository
+}
+
+func NewListDisputes(repo domain.DisputeRepository, transactionRepo domain.TransactionRepository) *ListDisputes {
+	return &ListDisputes{
+		repo:            repo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+func (l *ListDisputes) Execute(ctx context.Context, transactionID int64) (_ []*domain.Dispute, err error) {
+	ctx, span := tracing.Start(ctx, "ListDisputes.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	transaction, err := l.transactionRepo.FindByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.AuthorizeAccount(ctx, transaction.AccountID); err != nil {
+		return nil, err
+	}
+
+	return l.repo.ListByTransactionID(ctx, transactionID)
+}
@@ -0,0 +1,56 @@
+package dispute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	domainmocks "github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListDisputes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedRepo := domainmocks.NewMockDisputeRepository(ctrl)
+	mockedTransactionRepo := domainmocks.NewMockTransactionRepository(ctrl)
+	usecase := NewListDisputes(mockedRepo, mockedTransactionRepo)
+
+	t.Run("lists disputes of the transaction", func(t *testing.T) {
+		// when
+		mockedTransactionRepo.EXPECT().FindByID(gomock.Any(), int64(10)).Return(&domain.Transaction{ID: 10}, nil)
+		mockedRepo.EXPECT().ListByTransactionID(gomock.Any(), int64(10)).Return([]*domain.Dispute{{ID: 1}, {ID: 2}}, nil)
+
+		disputes, err := usecase.Execute(context.Background(), 10)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, disputes, 2)
+	})
+
+	t.Run("returns error when transaction does not exist", func(t *testing.T) {
+		// when
+		mockedTransactionRepo.EXPECT().FindByID(gomock.Any(), int64(999)).Return(nil, domain.ErrTransactionNotFound)
+
+		disputes, err := usecase.Execute(context.Background(), 999)
+
+		// then
+		assert.Nil(t, disputes)
+		assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
+	})
+
+	t.Run("denies callers restricted to another account", func(t *testing.T) {
+		// given
+		ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{ClientID: "mobile", AccountID: 2})
+
+		// when
+		mockedTransactionRepo.EXPECT().FindByID(gomock.Any(), int64(10)).Return(&domain.Transaction{ID: 10, AccountID: 1}, nil)
+
+		disputes, err := usecase.Execute(ctx, 10)
+
+		// then
+		assert.Nil(t, disputes)
+		assert.ErrorIs(t, err, domain.ErrAccountAccessDenied)
+	})
+}
@@ -0,0 +1,104 @@
+package dispute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+// transactionCreator posts the compensating transactions so they go through
+// the same validation, conversion and balance rules as any other.
+//
+//go:generate mockgen -source=open_dispute.go -destination=mocks/open_dispute_mock.go -package=mocks
+type transactionCreator interface {
+	Execute(ctx context.Context, accountID int64, operationTypeID int, amount float64, details domain.TransactionDetails) (*domain.Transaction, error)
+}
+
+type OpenDispute struct {
+	repo            domain.DisputeRepository
+	transactionRepo domain.TransactionRepository
+	transactions    transactionCreator
+}
+
+func NewOpenDispute(repo domain.DisputeRepository, transactionRepo domain.TransactionRepository, transactions transactionCreator) *OpenDispute {
+	return &OpenDispute{
+		repo:            repo,
+		transactionRepo: transactionRepo,
+		transactions:    transactions,
+	}
+}
+
+// Execute opens a dispute on the transaction and provisionally credits the
+// disputed amount back to the account while it is investigated. A
+// transaction can be disputed again after losing, but not once refunded by a
+// won dispute.
+func (o *OpenDispute) Execute(ctx context.Context, transactionID int64, reason, note string) (_ *domain.Dispute, err error) {
+	ctx, span := tracing.Start(ctx, "OpenDispute.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	transaction, err := o.transactionRepo.FindByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.AuthorizeAccount(ctx, transaction.AccountID); err != nil {
+		return nil, err
+	}
+
+	dispute, err := domain.NewDispute(transaction, reason, note)
+	if err != nil {
+		return nil, err
+	}
+
+	disputes, err := o.repo.ListByTransactionID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range disputes {
+		switch existing.Status {
+		case domain.DisputeStatusWon:
+			return nil, domain.ErrDisputeAlreadyWon
+		case domain.DisputeStatusLost:
+			continue
+		}
+		// A previous attempt failed before crediting the dispute, so this one
+		// finishes it rather than leaving it open without a credit.
+		if existing.ProvisionalCreditTransactionID == 0 {
+			return o.credit(ctx, existing)
+		}
+		return nil, domain.ErrDisputeAlreadyOpen
+	}
+
+	dispute, err = o.repo.Create(ctx, dispute)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.credit(ctx, dispute)
+}
+
+// credit posts the provisional credit of the dispute and records it.
+func (o *OpenDispute) credit(ctx context.Context, dispute *domain.Dispute) (*domain.Dispute, error) {
+	credit, err := post(ctx, o.transactions, o.transactionRepo, dispute.AccountID, domain.OperationTypeCreditVoucher, dispute.Amount, domain.TransactionDetails{
+		ExternalID:  fmt.Sprintf("dispute-%d-provisional-credit", dispute.ID),
+		Description: fmt.Sprintf("Provisional credit for dispute %d", dispute.ID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dispute.ProvisionalCreditTransactionID = credit.ID
+	return o.repo.Update(ctx, dispute)
+}
+
+// post creates a compensating transaction, or returns the one a previous
+// attempt posted under the same external ID before failing to record it.
+func post(ctx context.Context, transactions transactionCreator, transactionRepo domain.TransactionRepository, accountID int64, operationType domain.OperationType, amount float64, details domain.TransactionDetails) (*domain.Transaction, error) {
+	transaction, err := transactions.Execute(ctx, accountID, int(operationType), amount, details)
+	if errors.Is(err, domain.ErrTransactionAlreadyExists) {
+		return transactionRepo.FindByExternalID(ctx, accountID, details.ExternalID)
+	}
+	return transaction, err
+}
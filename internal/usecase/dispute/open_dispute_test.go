@@ -0,0 +1,150 @@
+package dispute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	domainmocks "github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/dispute/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOpenDispute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedRepo := domainmocks.NewMockDisputeRepository(ctrl)
+	mockedTransactionRepo := domainmocks.NewMockTransactionRepository(ctrl)
+	mockedTransactions := mocks.NewMocktransactionCreator(ctrl)
+	usecase := NewOpenDispute(mockedRepo, mockedTransactionRepo, mockedTransactions)
+
+	purchase := &domain.Transaction{ID: 10, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -80.0}
+
+	t.Run("opens dispute and posts provisional credit", func(t *testing.T) {
+		// when
+		mockedTransactionRepo.EXPECT().FindByID(gomock.Any(), int64(10)).Return(purchase, nil)
+		mockedRepo.EXPECT().ListByTransactionID(gomock.Any(), int64(10)).Return(
+			[]*domain.Dispute{{ID: 1, Status: domain.DisputeStatusLost}}, nil,
+		)
+		mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, dispute *domain.Dispute) (*domain.Dispute, error) {
+				dispute.ID = 2
+				return dispute, nil
+			},
+		)
+		mockedTransactions.EXPECT().
+			Execute(gomock.Any(), int64(1), int(domain.OperationTypeCreditVoucher), 80.0, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, accountID int64, operationTypeID int, amount float64, details domain.TransactionDetails) (*domain.Transaction, error) {
+				assert.Equal(t, "dispute-2-provisional-credit", details.ExternalID)
+				return &domain.Transaction{ID: 11, AccountID: accountID, OperationTypeID: domain.OperationTypeCreditVoucher, Amount: amount}, nil
+			})
+		mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, dispute *domain.Dispute) (*domain.Dispute, error) {
+				return dispute, nil
+			},
+		)
+
+		dispute, err := usecase.Execute(context.Background(), 10, "item not received", "")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), dispute.ID)
+		assert.Equal(t, domain.DisputeStatusOpened, dispute.Status)
+		assert.Equal(t, 80.0, dispute.Amount)
+		assert.Equal(t, int64(11), dispute.ProvisionalCreditTransactionID)
+	})
+
+	t.Run("credits dispute left without credit by a previous attempt", func(t *testing.T) {
+		// when
+		mockedTransactionRepo.EXPECT().FindByID(gomock.Any(), int64(10)).Return(purchase, nil)
+		mockedRepo.EXPECT().ListByTransactionID(gomock.Any(), int64(10)).Return(
+			[]*domain.Dispute{{ID: 3, AccountID: 1, Amount: 80.0, Status: domain.DisputeStatusOpened}}, nil,
+		)
+		mockedTransactions.EXPECT().
+			Execute(gomock.Any(), int64(1), int(domain.OperationTypeCreditVoucher), 80.0, gomock.Any()).
+			Return(nil, domain.ErrTransactionAlreadyExists)
+		mockedTransactionRepo.EXPECT().FindByExternalID(gomock.Any(), int64(1), "dispute-3-provisional-credit").Return(&domain.Transaction{ID: 12}, nil)
+		mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, dispute *domain.Dispute) (*domain.Dispute, error) {
+				return dispute, nil
+			},
+		)
+
+		dispute, err := usecase.Execute(context.Background(), 10, "item not received", "")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), dispute.ID)
+		assert.Equal(t, int64(12), dispute.ProvisionalCreditTransactionID)
+	})
+
+	t.Run("returns error when transaction already has an open dispute", func(t *testing.T) {
+		// when
+		mockedTransactionRepo.EXPECT().FindByID(gomock.Any(), int64(10)).Return(purchase, nil)
+		mockedRepo.EXPECT().ListByTransactionID(gomock.Any(), int64(10)).Return(
+			[]*domain.Dispute{{ID: 2, Status: domain.DisputeStatusUnderReview, ProvisionalCreditTransactionID: 11}}, nil,
+		)
+
+		dispute, err := usecase.Execute(context.Background(), 10, "item not received", "")
+
+		// then
+		assert.Nil(t, dispute)
+		assert.ErrorIs(t, err, domain.ErrDisputeAlreadyOpen)
+	})
+
+	t.Run("returns error when transaction was refunded by a won dispute", func(t *testing.T) {
+		// when
+		mockedTransactionRepo.EXPECT().FindByID(gomock.Any(), int64(10)).Return(purchase, nil)
+		mockedRepo.EXPECT().ListByTransactionID(gomock.Any(), int64(10)).Return(
+			[]*domain.Dispute{
+				{ID: 1, Status: domain.DisputeStatusLost, ProvisionalCreditTransactionID: 11},
+				{ID: 2, Status: domain.DisputeStatusWon, ProvisionalCreditTransactionID: 13, FinalTransactionID: 13},
+			}, nil,
+		)
+
+		dispute, err := usecase.Execute(context.Background(), 10, "item not received", "")
+
+		// then
+		assert.Nil(t, dispute)
+		assert.ErrorIs(t, err, domain.ErrDisputeAlreadyWon)
+	})
+
+	t.Run("returns error when transaction is a payment", func(t *testing.T) {
+		// when
+		mockedTransactionRepo.EXPECT().FindByID(gomock.Any(), int64(20)).Return(
+			&domain.Transaction{ID: 20, AccountID: 1, OperationTypeID: domain.OperationTypePayment, Amount: 100.0}, nil,
+		)
+
+		dispute, err := usecase.Execute(context.Background(), 20, "not mine", "")
+
+		// then
+		assert.Nil(t, dispute)
+		assert.ErrorIs(t, err, domain.ErrTransactionNotDisputable)
+	})
+
+	t.Run("returns error when transaction does not exist", func(t *testing.T) {
+		// when
+		mockedTransactionRepo.EXPECT().FindByID(gomock.Any(), int64(999)).Return(nil, domain.ErrTransactionNotFound)
+
+		dispute, err := usecase.Execute(context.Background(), 999, "not mine", "")
+
+		// then
+		assert.Nil(t, dispute)
+		assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
+	})
+
+	t.Run("denies callers restricted to another account", func(t *testing.T) {
+		// given
+		ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{ClientID: "mobile", AccountID: 2})
+
+		// when
+		mockedTransactionRepo.EXPECT().FindByID(gomock.Any(), int64(10)).Return(purchase, nil)
+
+		dispute, err := usecase.Execute(ctx, 10, "item not received", "")
+
+		// then
+		assert.Nil(t, dispute)
+		assert.ErrorIs(t, err, domain.ErrAccountAccessDenied)
+	})
+}
@@ -0,0 +1,88 @@
+package dispute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type UpdateDispute struct {
+	repo            domain.DisputeRepository
+	transactionRepo domain.TransactionRepository
+	transactions    transactionCreator
+}
+
+func NewUpdateDispute(repo domain.DisputeRepository, transactionRepo domain.TransactionRepository, transactions transactionCreator) *UpdateDispute {
+	return &UpdateDispute{
+		repo:            repo,
+		transactionRepo: transactionRepo,
+		transactions:    transactions,
+	}
+}
+
+// Execute moves the dispute to status. A won dispute keeps the provisional
+// credit as final, a lost one reverses it with a new debit, posted only once
+// the dispute is saved as lost so that a concurrent resolution cannot win it
+// too. Retrying after a failure finishes the reversal. A dispute whose
+// provisional credit was never posted cannot be resolved until opening it
+// again posts it.
+func (u *UpdateDispute) Execute(ctx context.Context, transactionID, disputeID int64, status, note string) (_ *domain.Dispute, err error) {
+	ctx, span := tracing.Start(ctx, "UpdateDispute.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	dispute, err := u.repo.FindByID(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dispute.TransactionID != transactionID {
+		return nil, domain.ErrDisputeNotFound
+	}
+	if err := domain.AuthorizeAccount(ctx, dispute.AccountID); err != nil {
+		return nil, err
+	}
+
+	if domain.DisputeStatus(status).IsResolved() && dispute.ProvisionalCreditTransactionID == 0 {
+		return nil, domain.ErrDisputeNotCredited
+	}
+
+	// A previous attempt saved the dispute as lost but failed to reverse it.
+	if dispute.Status == domain.DisputeStatusLost && dispute.FinalTransactionID == 0 && status == string(domain.DisputeStatusLost) {
+		return u.reverse(ctx, dispute)
+	}
+
+	if err := dispute.Transition(domain.DisputeStatus(status), note, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	if dispute.Status == domain.DisputeStatusWon {
+		dispute.FinalTransactionID = dispute.ProvisionalCreditTransactionID
+	}
+
+	// The repository only saves the transition if no one else changed the
+	// dispute since it was loaded.
+	dispute, err = u.repo.Update(ctx, dispute)
+	if err != nil || dispute.Status != domain.DisputeStatusLost {
+		return dispute, err
+	}
+
+	return u.reverse(ctx, dispute)
+}
+
+// reverse posts the reversal of the provisional credit of a lost dispute and
+// records it.
+func (u *UpdateDispute) reverse(ctx context.Context, dispute *domain.Dispute) (*domain.Dispute, error) {
+	reversal, err := post(ctx, u.transactions, u.transactionRepo, dispute.AccountID, domain.OperationTypeDisputeReversal, dispute.Amount, domain.TransactionDetails{
+		ExternalID:  fmt.Sprintf("dispute-%d-reversal", dispute.ID),
+		Description: fmt.Sprintf("Reversal of provisional credit for dispute %d", dispute.ID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dispute.FinalTransactionID = reversal.ID
+	return u.repo.Update(ctx, dispute)
+}
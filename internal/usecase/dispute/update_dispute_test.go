@@ -0,0 +1,163 @@
+package dispute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	domainmocks "github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/dispute/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateDispute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedRepo := domainmocks.NewMockDisputeRepository(ctrl)
+	mockedTransactionRepo := domainmocks.NewMockTransactionRepository(ctrl)
+	mockedTransactions := mocks.NewMocktransactionCreator(ctrl)
+	usecase := NewUpdateDispute(mockedRepo, mockedTransactionRepo, mockedTransactions)
+
+	openDispute := func(ID int64) *domain.Dispute {
+		return &domain.Dispute{
+			ID:                             ID,
+			TransactionID:                  10,
+			AccountID:                      1,
+			Amount:                         80.0,
+			Status:                         domain.DisputeStatusUnderReview,
+			ProvisionalCreditTransactionID: 11,
+		}
+	}
+	update := func(ctx context.Context, dispute *domain.Dispute) (*domain.Dispute, error) {
+		return dispute, nil
+	}
+
+	t.Run("keeps provisional credit when dispute is won", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(openDispute(1), nil)
+		mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(update)
+
+		dispute, err := usecase.Execute(context.Background(), 10, 1, "won", "merchant accepted")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DisputeStatusWon, dispute.Status)
+		assert.Equal(t, int64(11), dispute.FinalTransactionID)
+		assert.False(t, dispute.ResolvedAt.IsZero())
+	})
+
+	t.Run("posts reversal when dispute is lost", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(2)).Return(openDispute(2), nil)
+		mockedTransactions.EXPECT().
+			Execute(gomock.Any(), int64(1), int(domain.OperationTypeDisputeReversal), 80.0, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, accountID int64, operationTypeID int, amount float64, details domain.TransactionDetails) (*domain.Transaction, error) {
+				assert.Equal(t, "dispute-2-reversal", details.ExternalID)
+				return &domain.Transaction{ID: 12, AccountID: accountID, OperationTypeID: domain.OperationTypeDisputeReversal, Amount: -amount}, nil
+			})
+		gomock.InOrder(
+			mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, dispute *domain.Dispute) (*domain.Dispute, error) {
+					assert.Zero(t, dispute.FinalTransactionID, "saved as lost before the reversal")
+					return dispute, nil
+				},
+			),
+			mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(update),
+		)
+
+		dispute, err := usecase.Execute(context.Background(), 10, 2, "lost", "merchant provided proof of delivery")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DisputeStatusLost, dispute.Status)
+		assert.Equal(t, int64(12), dispute.FinalTransactionID)
+	})
+
+	t.Run("finishes reversal of a dispute saved as lost by a previous attempt", func(t *testing.T) {
+		// given
+		lost := openDispute(5)
+		lost.Status = domain.DisputeStatusLost
+
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(5)).Return(lost, nil)
+		mockedTransactions.EXPECT().
+			Execute(gomock.Any(), int64(1), int(domain.OperationTypeDisputeReversal), 80.0, gomock.Any()).
+			Return(nil, domain.ErrTransactionAlreadyExists)
+		mockedTransactionRepo.EXPECT().FindByExternalID(gomock.Any(), int64(1), "dispute-5-reversal").Return(&domain.Transaction{ID: 13}, nil)
+		mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(update)
+
+		dispute, err := usecase.Execute(context.Background(), 10, 5, "lost", "")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DisputeStatusLost, dispute.Status)
+		assert.Equal(t, int64(13), dispute.FinalTransactionID)
+	})
+
+	t.Run("posts no reversal when another resolution was saved first", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(7)).Return(openDispute(7), nil)
+		mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, domain.ErrInvalidDisputeTransition)
+
+		dispute, err := usecase.Execute(context.Background(), 10, 7, "lost", "")
+
+		// then
+		assert.Nil(t, dispute)
+		assert.ErrorIs(t, err, domain.ErrInvalidDisputeTransition)
+	})
+
+	t.Run("returns error when transition is not allowed", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(3)).Return(openDispute(3), nil)
+
+		dispute, err := usecase.Execute(context.Background(), 10, 3, "opened", "")
+
+		// then
+		assert.Nil(t, dispute)
+		assert.ErrorIs(t, err, domain.ErrInvalidDisputeTransition)
+	})
+
+	t.Run("returns error resolving a dispute without provisional credit", func(t *testing.T) {
+		for _, status := range []string{"won", "lost"} {
+			// given
+			uncredited := openDispute(5)
+			uncredited.Status = domain.DisputeStatusOpened
+			uncredited.ProvisionalCreditTransactionID = 0
+
+			// when
+			mockedRepo.EXPECT().FindByID(gomock.Any(), int64(5)).Return(uncredited, nil)
+
+			dispute, err := usecase.Execute(context.Background(), 10, 5, status, "")
+
+			// then
+			assert.Nil(t, dispute, status)
+			assert.ErrorIs(t, err, domain.ErrDisputeNotCredited, status)
+		}
+	})
+
+	t.Run("returns not found when dispute belongs to another transaction", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(4)).Return(openDispute(4), nil)
+
+		dispute, err := usecase.Execute(context.Background(), 99, 4, "won", "")
+
+		// then
+		assert.Nil(t, dispute)
+		assert.ErrorIs(t, err, domain.ErrDisputeNotFound)
+	})
+
+	t.Run("denies callers restricted to another account", func(t *testing.T) {
+		// given
+		ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{ClientID: "mobile", AccountID: 2})
+
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(6)).Return(openDispute(6), nil)
+
+		dispute, err := usecase.Execute(ctx, 10, 6, "won", "")
+
+		// then
+		assert.Nil(t, dispute)
+		assert.ErrorIs(t, err, domain.ErrAccountAccessDenied)
+	})
+}
@@ -0,0 +1,118 @@
+package fraud
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+// Engine screens debits against the fraud rules stored in the database. Rules
+// are reloaded every refreshInterval so they can be changed without a deploy.
+type Engine struct {
+	rulesRepo       domain.FraudRuleRepository
+	assessmentsRepo domain.FraudAssessmentRepository
+	transactions    domain.TransactionRepository
+	evaluators      map[domain.FraudRuleType]Evaluator
+	refreshInterval time.Duration
+
+	mu       sync.Mutex
+	rules    []*domain.FraudRule
+	loadedAt time.Time
+}
+
+func NewEngine(
+	rulesRepo domain.FraudRuleRepository,
+	assessmentsRepo domain.FraudAssessmentRepository,
+	transactions domain.TransactionRepository,
+	refreshInterval time.Duration,
+) *Engine {
+	return &Engine{
+		rulesRepo:       rulesRepo,
+		assessmentsRepo: assessmentsRepo,
+		transactions:    transactions,
+		evaluators:      defaultEvaluators(),
+		refreshInterval: refreshInterval,
+	}
+}
+
+// Register plugs an evaluator for a rule type, replacing any existing one.
+func (e *Engine) Register(ruleType domain.FraudRuleType, evaluator Evaluator) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.evaluators[ruleType] = evaluator
+}
+
+func (e *Engine) Assess(ctx context.Context, transaction *domain.Transaction) (*domain.FraudAssessment, error) {
+	assessment := &domain.FraudAssessment{
+		AccountID:       transaction.AccountID,
+		OperationTypeID: transaction.OperationTypeID,
+		Amount:          transaction.Amount,
+		Decision:        domain.FraudDecisionApprove,
+		CreatedAt:       time.Now(),
+	}
+
+	if !transaction.OperationTypeID.IsDebit() {
+		return assessment, nil
+	}
+
+	rules, err := e.loadRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return assessment, nil
+	}
+
+	history, err := e.transactions.ListByAccountID(ctx, transaction.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		evaluator := e.evaluator(rule.Type)
+		if evaluator == nil || !evaluator(rule, transaction, history) {
+			continue
+		}
+
+		assessment.TriggeredRules = append(assessment.TriggeredRules, rule.Name)
+		if rule.Action.Severity() > assessment.Decision.Severity() {
+			assessment.Decision = rule.Action
+		}
+	}
+
+	return assessment, nil
+}
+
+func (e *Engine) Record(ctx context.Context, assessment *domain.FraudAssessment) error {
+	_, err := e.assessmentsRepo.Create(ctx, assessment)
+	return err
+}
+
+func (e *Engine) evaluator(ruleType domain.FraudRuleType) Evaluator {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.evaluators[ruleType]
+}
+
+func (e *Engine) loadRules(ctx context.Context) ([]*domain.FraudRule, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.rules != nil && time.Since(e.loadedAt) < e.refreshInterval {
+		return e.rules, nil
+	}
+
+	rules, err := e.rulesRepo.ListEnabled(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		rules = []*domain.FraudRule{}
+	}
+
+	e.rules = rules
+	e.loadedAt = time.Now()
+	return rules, nil
+}
@@ -0,0 +1,151 @@
+package fraud
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEngine_Assess(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	debit := func(amount float64, at time.Time) *domain.Transaction {
+		return &domain.Transaction{AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -amount, EventDate: at}
+	}
+
+	setup := func(t *testing.T, rules []*domain.FraudRule, history []*domain.Transaction) *Engine {
+		ctrl := gomock.NewController(t)
+		rulesRepo := mocks.NewMockFraudRuleRepository(ctrl)
+		assessmentsRepo := mocks.NewMockFraudAssessmentRepository(ctrl)
+		transactionRepo := mocks.NewMockTransactionRepository(ctrl)
+
+		rulesRepo.EXPECT().ListEnabled(gomock.Any()).Return(rules, nil).AnyTimes()
+		transactionRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return(history, nil).AnyTimes()
+
+		return NewEngine(rulesRepo, assessmentsRepo, transactionRepo, time.Minute)
+	}
+
+	t.Run("approves when no rule is triggered", func(t *testing.T) {
+		// given
+		rules := []*domain.FraudRule{{Name: "count", Type: domain.FraudRuleVelocityCount, Action: domain.FraudDecisionDecline, Window: time.Hour, MaxCount: 3}}
+		engine := setup(t, rules, []*domain.Transaction{debit(10, now.Add(-time.Minute))})
+
+		// when
+		assessment, err := engine.Assess(context.Background(), debit(10, now))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FraudDecisionApprove, assessment.Decision)
+		assert.Empty(t, assessment.TriggeredRules)
+	})
+
+	t.Run("declines when debit count exceeds velocity limit", func(t *testing.T) {
+		// given
+		rules := []*domain.FraudRule{{Name: "count", Type: domain.FraudRuleVelocityCount, Action: domain.FraudDecisionDecline, Window: time.Hour, MaxCount: 2}}
+		history := []*domain.Transaction{
+			debit(10, now.Add(-2*time.Hour)),
+			debit(10, now.Add(-30*time.Minute)),
+			debit(10, now.Add(-10*time.Minute)),
+		}
+		engine := setup(t, rules, history)
+
+		// when
+		assessment, err := engine.Assess(context.Background(), debit(10, now))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FraudDecisionDecline, assessment.Decision)
+		assert.Equal(t, []string{"count"}, assessment.TriggeredRules)
+	})
+
+	t.Run("flags for review when amount exceeds velocity limit", func(t *testing.T) {
+		// given
+		rules := []*domain.FraudRule{{Name: "amount", Type: domain.FraudRuleVelocityAmount, Action: domain.FraudDecisionReview, Window: time.Hour, MaxAmount: 100}}
+		engine := setup(t, rules, []*domain.Transaction{debit(80, now.Add(-time.Minute))})
+
+		// when
+		assessment, err := engine.Assess(context.Background(), debit(30, now))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FraudDecisionReview, assessment.Decision)
+	})
+
+	t.Run("detects spikes against the account history", func(t *testing.T) {
+		// given
+		rules := []*domain.FraudRule{{Name: "spike", Type: domain.FraudRuleSpike, Action: domain.FraudDecisionReview, SpikeMultiplier: 5, MinHistory: 2}}
+		history := []*domain.Transaction{debit(10, now.Add(-48*time.Hour)), debit(20, now.Add(-24*time.Hour))}
+		engine := setup(t, rules, history)
+
+		// when
+		assessment, err := engine.Assess(context.Background(), debit(100, now))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FraudDecisionReview, assessment.Decision)
+	})
+
+	t.Run("ignores spikes without enough history", func(t *testing.T) {
+		// given
+		rules := []*domain.FraudRule{{Name: "spike", Type: domain.FraudRuleSpike, Action: domain.FraudDecisionReview, SpikeMultiplier: 5, MinHistory: 3}}
+		engine := setup(t, rules, []*domain.Transaction{debit(10, now.Add(-time.Hour))})
+
+		// when
+		assessment, err := engine.Assess(context.Background(), debit(100, now))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FraudDecisionApprove, assessment.Decision)
+	})
+
+	t.Run("keeps the strictest decision among triggered rules", func(t *testing.T) {
+		// given
+		rules := []*domain.FraudRule{
+			{Name: "amount", Type: domain.FraudRuleVelocityAmount, Action: domain.FraudDecisionReview, Window: time.Hour, MaxAmount: 10},
+			{Name: "count", Type: domain.FraudRuleVelocityCount, Action: domain.FraudDecisionDecline, Window: time.Hour, MaxCount: 1},
+		}
+		engine := setup(t, rules, []*domain.Transaction{debit(10, now.Add(-time.Minute))})
+
+		// when
+		assessment, err := engine.Assess(context.Background(), debit(10, now))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FraudDecisionDecline, assessment.Decision)
+		assert.Equal(t, []string{"amount", "count"}, assessment.TriggeredRules)
+	})
+
+	t.Run("uses registered evaluators for custom rule types", func(t *testing.T) {
+		// given
+		rules := []*domain.FraudRule{{Name: "always", Type: "custom", Action: domain.FraudDecisionReview}}
+		engine := setup(t, rules, nil)
+		engine.Register("custom", func(*domain.FraudRule, *domain.Transaction, []*domain.Transaction) bool { return true })
+
+		// when
+		assessment, err := engine.Assess(context.Background(), debit(10, now))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FraudDecisionReview, assessment.Decision)
+	})
+
+	t.Run("returns error when rules cannot be loaded", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		rulesRepo := mocks.NewMockFraudRuleRepository(ctrl)
+		rulesRepo.EXPECT().ListEnabled(gomock.Any()).Return(nil, errors.New("database error"))
+		engine := NewEngine(rulesRepo, mocks.NewMockFraudAssessmentRepository(ctrl), mocks.NewMockTransactionRepository(ctrl), time.Minute)
+
+		// when
+		assessment, err := engine.Assess(context.Background(), debit(10, now))
+
+		// then
+		assert.Nil(t, assessment)
+		assert.Error(t, err)
+	})
+}
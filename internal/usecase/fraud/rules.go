@@ -0,0 +1,64 @@
+package fraud
+
+import (
+	"math"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+// Evaluator reports whether rule is triggered by transaction given the
+// account's past transactions.
+type Evaluator func(rule *domain.FraudRule, transaction *domain.Transaction, history []*domain.Transaction) bool
+
+func defaultEvaluators() map[domain.FraudRuleType]Evaluator {
+	return map[domain.FraudRuleType]Evaluator{
+		domain.FraudRuleVelocityCount:  velocityCount,
+		domain.FraudRuleVelocityAmount: velocityAmount,
+		domain.FraudRuleSpike:          spike,
+	}
+}
+
+func velocityCount(rule *domain.FraudRule, transaction *domain.Transaction, history []*domain.Transaction) bool {
+	count := len(debitsWithin(rule, transaction, history)) + 1
+	return count > rule.MaxCount
+}
+
+func velocityAmount(rule *domain.FraudRule, transaction *domain.Transaction, history []*domain.Transaction) bool {
+	total := math.Abs(transaction.Amount)
+	for _, past := range debitsWithin(rule, transaction, history) {
+		total += math.Abs(past.Amount)
+	}
+	return total > rule.MaxAmount
+}
+
+func spike(rule *domain.FraudRule, transaction *domain.Transaction, history []*domain.Transaction) bool {
+	var count int
+	var total float64
+	for _, past := range history {
+		if past.OperationTypeID.IsDebit() {
+			count++
+			total += math.Abs(past.Amount)
+		}
+	}
+
+	if count == 0 || count < rule.MinHistory {
+		return false
+	}
+
+	average := total / float64(count)
+	return math.Abs(transaction.Amount) > average*rule.SpikeMultiplier
+}
+
+// debitsWithin returns the past debits that happened inside the rule's sliding
+// window ending at the transaction's event date.
+func debitsWithin(rule *domain.FraudRule, transaction *domain.Transaction, history []*domain.Transaction) []*domain.Transaction {
+	since := transaction.EventDate.Add(-rule.Window)
+
+	var debits []*domain.Transaction
+	for _, past := range history {
+		if past.OperationTypeID.IsDebit() && !past.EventDate.Before(since) && !past.EventDate.After(transaction.EventDate) {
+			debits = append(debits, past)
+		}
+	}
+	return debits
+}
@@ -0,0 +1,42 @@
+package scheduledtransaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type CancelScheduledTransaction struct {
+	repo domain.ScheduledTransactionRepository
+}
+
+func NewCancelScheduledTransaction(repo domain.ScheduledTransactionRepository) *CancelScheduledTransaction {
+	return &CancelScheduledTransaction{repo: repo}
+}
+
+// Execute stops any further runs. Cancelling a cancelled schedule is a no-op,
+// a completed one cannot be cancelled.
+func (c *CancelScheduledTransaction) Execute(ctx context.Context, ID int64) (_ *domain.ScheduledTransaction, err error) {
+	ctx, span := tracing.Start(ctx, "CancelScheduledTransaction.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	schedule, err := c.repo.FindByID(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.AuthorizeAccount(ctx, schedule.AccountID); err != nil {
+		return nil, err
+	}
+
+	switch schedule.Status {
+	case domain.ScheduleStatusCancelled:
+		return schedule, nil
+	case domain.ScheduleStatusCompleted:
+		return nil, domain.ErrScheduleNotActive
+	}
+
+	schedule.Cancel(time.Now().UTC())
+	return c.repo.Update(ctx, schedule)
+}
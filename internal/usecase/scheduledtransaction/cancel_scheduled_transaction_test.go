@@ -0,0 +1,70 @@
+package scheduledtransaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCancelScheduledTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedRepo := mocks.NewMockScheduledTransactionRepository(ctrl)
+	usecase := NewCancelScheduledTransaction(mockedRepo)
+
+	t.Run("cancels active schedule", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&domain.ScheduledTransaction{ID: 1, Status: domain.ScheduleStatusActive}, nil)
+		mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, schedule *domain.ScheduledTransaction) (*domain.ScheduledTransaction, error) {
+				return schedule, nil
+			},
+		)
+
+		schedule, err := usecase.Execute(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ScheduleStatusCancelled, schedule.Status)
+	})
+
+	t.Run("does not update schedule already cancelled", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(2)).Return(&domain.ScheduledTransaction{ID: 2, Status: domain.ScheduleStatusCancelled}, nil)
+
+		schedule, err := usecase.Execute(context.Background(), 2)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ScheduleStatusCancelled, schedule.Status)
+	})
+
+	t.Run("returns error when schedule is completed", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(3)).Return(&domain.ScheduledTransaction{ID: 3, Status: domain.ScheduleStatusCompleted}, nil)
+
+		schedule, err := usecase.Execute(context.Background(), 3)
+
+		// then
+		assert.Nil(t, schedule)
+		assert.ErrorIs(t, err, domain.ErrScheduleNotActive)
+	})
+
+	t.Run("denies callers restricted to another account", func(t *testing.T) {
+		// given
+		ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{ClientID: "mobile", AccountID: 2})
+
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(4)).Return(&domain.ScheduledTransaction{ID: 4, AccountID: 1, Status: domain.ScheduleStatusActive}, nil)
+
+		schedule, err := usecase.Execute(ctx, 4)
+
+		// then
+		assert.Nil(t, schedule)
+		assert.ErrorIs(t, err, domain.ErrAccountAccessDenied)
+	})
+}
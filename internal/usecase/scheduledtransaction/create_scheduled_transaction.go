@@ -0,0 +1,29 @@
+package scheduledtransaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type CreateScheduledTransaction struct {
+	repo domain.ScheduledTransactionRepository
+}
+
+func NewCreateScheduledTransaction(repo domain.ScheduledTransactionRepository) *CreateScheduledTransaction {
+	return &CreateScheduledTransaction{repo: repo}
+}
+
+func (c *CreateScheduledTransaction) Execute(ctx context.Context, accountID int64, definition domain.ScheduleDefinition) (_ *domain.ScheduledTransaction, err error) {
+	ctx, span := tracing.Start(ctx, "CreateScheduledTransaction.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	schedule, err := domain.NewScheduledTransaction(accountID, definition, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	return c.repo.Create(ctx, schedule)
+}
@@ -0,0 +1,81 @@
+package scheduledtransaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateScheduledTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedRepo := mocks.NewMockScheduledTransactionRepository(ctrl)
+	usecase := NewCreateScheduledTransaction(mockedRepo)
+
+	t.Run("creates monthly autopay successfully", func(t *testing.T) {
+		// given
+		definition := domain.ScheduleDefinition{
+			OperationTypeID: domain.OperationTypePayment,
+			AmountType:      domain.ScheduleAmountFullBalance,
+			Recurrence:      domain.ScheduleRecurrenceMonthly,
+			DayOfMonth:      5,
+		}
+
+		// when
+		mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, schedule *domain.ScheduledTransaction) (*domain.ScheduledTransaction, error) {
+				schedule.ID = 1
+				return schedule, nil
+			},
+		)
+
+		schedule, err := usecase.Execute(context.Background(), 1, definition)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), schedule.ID)
+		assert.Equal(t, domain.ScheduleStatusActive, schedule.Status)
+		assert.Equal(t, 5, schedule.NextRunAt.Day())
+	})
+
+	t.Run("returns error when definition is invalid", func(t *testing.T) {
+		// given
+		definition := domain.ScheduleDefinition{
+			OperationTypeID: domain.OperationTypePayment,
+			AmountType:      domain.ScheduleAmountFixed,
+			Recurrence:      domain.ScheduleRecurrenceMonthly,
+			DayOfMonth:      40,
+			Amount:          10.0,
+		}
+
+		// when
+		schedule, err := usecase.Execute(context.Background(), 1, definition)
+
+		// then
+		assert.Nil(t, schedule)
+		assert.ErrorIs(t, err, domain.ErrInvalidScheduleDayOfMonth)
+	})
+
+	t.Run("returns error when account does not exist", func(t *testing.T) {
+		// given
+		definition := domain.ScheduleDefinition{
+			OperationTypeID: domain.OperationTypePurchase,
+			Amount:          10.0,
+			AmountType:      domain.ScheduleAmountFixed,
+			Recurrence:      domain.ScheduleRecurrenceOnce,
+		}
+
+		// when
+		mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, domain.ErrAccountNotFound)
+
+		schedule, err := usecase.Execute(context.Background(), 999, definition)
+
+		// then
+		assert.Nil(t, schedule)
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+}
@@ -0,0 +1,31 @@
+package scheduledtransaction
+
+import (
+	"context"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type GetScheduledTransaction struct {
+	repo domain.ScheduledTransactionRepository
+}
+
+func NewGetScheduledTransaction(repo domain.ScheduledTransactionRepository) *GetScheduledTransaction {
+	return &GetScheduledTransaction{repo: repo}
+}
+
+func (g *GetScheduledTransaction) Execute(ctx context.Context, ID int64) (_ *domain.ScheduledTransaction, err error) {
+	ctx, span := tracing.Start(ctx, "GetScheduledTransaction.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	schedule, err := g.repo.FindByID(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.AuthorizeAccount(ctx, schedule.AccountID); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
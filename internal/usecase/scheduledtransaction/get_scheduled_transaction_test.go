@@ -0,0 +1,54 @@
+package scheduledtransaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetScheduledTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedRepo := mocks.NewMockScheduledTransactionRepository(ctrl)
+	usecase := NewGetScheduledTransaction(mockedRepo)
+
+	t.Run("returns schedule", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&domain.ScheduledTransaction{ID: 1}, nil)
+
+		schedule, err := usecase.Execute(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), schedule.ID)
+	})
+
+	t.Run("returns error when schedule does not exist", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(999)).Return(nil, domain.ErrScheduledTransactionNotFound)
+
+		schedule, err := usecase.Execute(context.Background(), 999)
+
+		// then
+		assert.Nil(t, schedule)
+		assert.ErrorIs(t, err, domain.ErrScheduledTransactionNotFound)
+	})
+
+	t.Run("denies callers restricted to another account", func(t *testing.T) {
+		// given
+		ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{ClientID: "mobile", AccountID: 2})
+
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(4)).Return(&domain.ScheduledTransaction{ID: 4, AccountID: 1, Status: domain.ScheduleStatusActive}, nil)
+
+		schedule, err := usecase.Execute(ctx, 4)
+
+		// then
+		assert.Nil(t, schedule)
+		assert.ErrorIs(t, err, domain.ErrAccountAccessDenied)
+	})
+}
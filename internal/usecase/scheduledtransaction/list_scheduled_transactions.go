@@ -0,0 +1,31 @@
+package scheduledtransaction
+
+import (
+	"context"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type ListScheduledTransactions struct {
+	repo        domain.ScheduledTransactionRepository
+	accountRepo domain.AccountRepository
+}
+
+func NewListScheduledTransactions(repo domain.ScheduledTransactionRepository, accountRepo domain.AccountRepository) *ListScheduledTransactions {
+	return &ListScheduledTransactions{
+		repo:        repo,
+		accountRepo: accountRepo,
+	}
+}
+
+func (l *ListScheduledTransactions) Execute(ctx context.Context, accountID int64) (_ []*domain.ScheduledTransaction, err error) {
+	ctx, span := tracing.Start(ctx, "ListScheduledTransactions.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := l.accountRepo.FindByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	return l.repo.ListByAccountID(ctx, accountID)
+}
@@ -0,0 +1,42 @@
+package scheduledtransaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListScheduledTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedRepo := mocks.NewMockScheduledTransactionRepository(ctrl)
+	mockedAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	usecase := NewListScheduledTransactions(mockedRepo, mockedAccountRepo)
+
+	t.Run("lists schedules of the account", func(t *testing.T) {
+		// when
+		mockedAccountRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&domain.Account{ID: 1}, nil)
+		mockedRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return([]*domain.ScheduledTransaction{{ID: 1}, {ID: 2}}, nil)
+
+		schedules, err := usecase.Execute(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, schedules, 2)
+	})
+
+	t.Run("returns error when account does not exist", func(t *testing.T) {
+		// when
+		mockedAccountRepo.EXPECT().FindByID(gomock.Any(), int64(999)).Return(nil, domain.ErrAccountNotFound)
+
+		schedules, err := usecase.Execute(context.Background(), 999)
+
+		// then
+		assert.Nil(t, schedules)
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+}
@@ -0,0 +1,121 @@
+package scheduledtransaction
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+//go:generate mockgen -source=run_due_scheduled_transactions.go -destination=mocks/run_due_scheduled_transactions_mock.go -package=mocks
+type transactionCreator interface {
+	Execute(ctx context.Context, accountID int64, operationTypeID int, amount float64, details domain.TransactionDetails) (*domain.Transaction, error)
+}
+
+type RunDueScheduledTransactions struct {
+	repo            domain.ScheduledTransactionRepository
+	transactionRepo domain.TransactionRepository
+	transactions    transactionCreator
+	batchSize       int
+}
+
+func NewRunDueScheduledTransactions(
+	repo domain.ScheduledTransactionRepository,
+	transactionRepo domain.TransactionRepository,
+	transactions transactionCreator,
+	batchSize int,
+) *RunDueScheduledTransactions {
+	return &RunDueScheduledTransactions{
+		repo:            repo,
+		transactionRepo: transactionRepo,
+		transactions:    transactions,
+		batchSize:       batchSize,
+	}
+}
+
+// Execute posts every run due at or before at and returns how many schedules
+// it advanced. Runs rejected by the transaction rules are recorded on the
+// schedule and skipped; any other failure leaves the run due so it is retried.
+func (r *RunDueScheduledTransactions) Execute(ctx context.Context, at time.Time) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "RunDueScheduledTransactions.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	schedules, err := r.repo.ListDue(ctx, at, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	advanced := 0
+	for _, schedule := range schedules {
+		if err := r.run(ctx, schedule, at); err != nil {
+			logger.Error(ctx, "failed to run scheduled transaction",
+				slog.Int64("scheduled_transaction_id", schedule.ID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		advanced++
+	}
+
+	return advanced, nil
+}
+
+func (r *RunDueScheduledTransactions) run(ctx context.Context, schedule *domain.ScheduledTransaction, at time.Time) error {
+	externalID := schedule.RunExternalID()
+
+	amount, err := r.amount(ctx, schedule)
+	if err != nil {
+		return err
+	}
+
+	// Nothing to pay, the run is done without posting anything.
+	if amount == 0 {
+		schedule.Advance(0, "", at)
+		_, err := r.repo.Update(ctx, schedule)
+		return err
+	}
+
+	transaction, err := r.transactions.Execute(ctx, schedule.AccountID, int(schedule.OperationTypeID), amount, domain.TransactionDetails{
+		ExternalID:  externalID,
+		Description: schedule.Description,
+	})
+
+	var domainErr *domain.Error
+	switch {
+	case err == nil:
+		schedule.Advance(transaction.ID, "", at)
+	case errors.Is(err, domain.ErrTransactionAlreadyExists):
+		// A previous attempt posted the run but failed before advancing.
+		posted, err := r.transactionRepo.FindByExternalID(ctx, schedule.AccountID, externalID)
+		if err != nil {
+			return err
+		}
+		schedule.Advance(posted.ID, "", at)
+	case errors.As(err, &domainErr):
+		schedule.Advance(0, domainErr.Message, at)
+	default:
+		return err
+	}
+
+	_, err = r.repo.Update(ctx, schedule)
+	return err
+}
+
+// amount returns what the run should post, which for full balance payments is
+// the account's outstanding debt at the time of the run.
+func (r *RunDueScheduledTransactions) amount(ctx context.Context, schedule *domain.ScheduledTransaction) (float64, error) {
+	if schedule.AmountType != domain.ScheduleAmountFullBalance {
+		return schedule.Amount, nil
+	}
+
+	transactions, err := r.transactionRepo.ListByAccountID(ctx, schedule.AccountID)
+	if err != nil {
+		return 0, err
+	}
+
+	return domain.NewAccountBalance(schedule.AccountID, transactions).Outstanding, nil
+}
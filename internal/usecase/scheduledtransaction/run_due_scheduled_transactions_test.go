@@ -0,0 +1,175 @@
+package scheduledtransaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	domainmocks "github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/scheduledtransaction/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunDueScheduledTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedRepo := domainmocks.NewMockScheduledTransactionRepository(ctrl)
+	mockedTransactionRepo := domainmocks.NewMockTransactionRepository(ctrl)
+	mockedTransactions := mocks.NewMocktransactionCreator(ctrl)
+	usecase := NewRunDueScheduledTransactions(mockedRepo, mockedTransactionRepo, mockedTransactions, 50)
+
+	at := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	dueAt := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	monthly := func(ID int64, amountType domain.ScheduleAmountType) *domain.ScheduledTransaction {
+		return &domain.ScheduledTransaction{
+			ID:        ID,
+			AccountID: 1,
+			ScheduleDefinition: domain.ScheduleDefinition{
+				OperationTypeID: domain.OperationTypePayment,
+				Amount:          100.0,
+				AmountType:      amountType,
+				Recurrence:      domain.ScheduleRecurrenceMonthly,
+				DayOfMonth:      10,
+				StartsAt:        dueAt,
+			},
+			Status:    domain.ScheduleStatusActive,
+			NextRunAt: dueAt,
+		}
+	}
+	update := func(ctx context.Context, schedule *domain.ScheduledTransaction) (*domain.ScheduledTransaction, error) {
+		return schedule, nil
+	}
+
+	t.Run("posts fixed amount and advances to next month", func(t *testing.T) {
+		// given
+		schedule := monthly(1, domain.ScheduleAmountFixed)
+
+		// when
+		mockedRepo.EXPECT().ListDue(gomock.Any(), at, 50).Return([]*domain.ScheduledTransaction{schedule}, nil)
+		mockedTransactions.EXPECT().
+			Execute(gomock.Any(), int64(1), int(domain.OperationTypePayment), 100.0, domain.TransactionDetails{ExternalID: schedule.RunExternalID()}).
+			Return(&domain.Transaction{ID: 20}, nil)
+		mockedRepo.EXPECT().Update(gomock.Any(), schedule).DoAndReturn(update)
+
+		advanced, err := usecase.Execute(context.Background(), at)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 1, advanced)
+		assert.Equal(t, int64(20), schedule.LastTransactionID)
+		assert.Equal(t, time.Date(2024, 4, 10, 9, 0, 0, 0, time.UTC), schedule.NextRunAt)
+	})
+
+	t.Run("pays full outstanding balance", func(t *testing.T) {
+		// given
+		schedule := monthly(2, domain.ScheduleAmountFullBalance)
+
+		// when
+		mockedRepo.EXPECT().ListDue(gomock.Any(), at, 50).Return([]*domain.ScheduledTransaction{schedule}, nil)
+		mockedTransactionRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return([]*domain.Transaction{
+			{Balance: -50.10},
+			{Balance: -23.5},
+			{Balance: 0},
+		}, nil)
+		mockedTransactions.EXPECT().
+			Execute(gomock.Any(), int64(1), int(domain.OperationTypePayment), 73.6, gomock.Any()).
+			Return(&domain.Transaction{ID: 21}, nil)
+		mockedRepo.EXPECT().Update(gomock.Any(), schedule).DoAndReturn(update)
+
+		advanced, err := usecase.Execute(context.Background(), at)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 1, advanced)
+		assert.Equal(t, int64(21), schedule.LastTransactionID)
+	})
+
+	t.Run("skips full balance payment when nothing is owed", func(t *testing.T) {
+		// given
+		schedule := monthly(3, domain.ScheduleAmountFullBalance)
+
+		// when
+		mockedRepo.EXPECT().ListDue(gomock.Any(), at, 50).Return([]*domain.ScheduledTransaction{schedule}, nil)
+		mockedTransactionRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return(nil, nil)
+		mockedRepo.EXPECT().Update(gomock.Any(), schedule).DoAndReturn(update)
+
+		advanced, err := usecase.Execute(context.Background(), at)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 1, advanced)
+		assert.Zero(t, schedule.LastTransactionID)
+	})
+
+	t.Run("does not post run twice after a crash", func(t *testing.T) {
+		// given
+		schedule := monthly(4, domain.ScheduleAmountFixed)
+
+		// when
+		mockedRepo.EXPECT().ListDue(gomock.Any(), at, 50).Return([]*domain.ScheduledTransaction{schedule}, nil)
+		mockedTransactions.EXPECT().
+			Execute(gomock.Any(), int64(1), int(domain.OperationTypePayment), 100.0, gomock.Any()).
+			Return(nil, domain.ErrTransactionAlreadyExists)
+		mockedTransactionRepo.EXPECT().FindByExternalID(gomock.Any(), int64(1), schedule.RunExternalID()).Return(&domain.Transaction{ID: 22}, nil)
+		mockedRepo.EXPECT().Update(gomock.Any(), schedule).DoAndReturn(update)
+
+		advanced, err := usecase.Execute(context.Background(), at)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 1, advanced)
+		assert.Equal(t, int64(22), schedule.LastTransactionID)
+	})
+
+	t.Run("records declined run and moves on", func(t *testing.T) {
+		// given
+		schedule := monthly(5, domain.ScheduleAmountFixed)
+
+		// when
+		mockedRepo.EXPECT().ListDue(gomock.Any(), at, 50).Return([]*domain.ScheduledTransaction{schedule}, nil)
+		mockedTransactions.EXPECT().
+			Execute(gomock.Any(), int64(1), int(domain.OperationTypePayment), 100.0, gomock.Any()).
+			Return(nil, domain.ErrOperationTypeNotAllowed)
+		mockedRepo.EXPECT().Update(gomock.Any(), schedule).DoAndReturn(update)
+
+		advanced, err := usecase.Execute(context.Background(), at)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 1, advanced)
+		assert.Equal(t, domain.ErrOperationTypeNotAllowed.Message, schedule.LastError)
+		assert.Equal(t, time.April, schedule.NextRunAt.Month())
+	})
+
+	t.Run("leaves run due when posting fails unexpectedly", func(t *testing.T) {
+		// given
+		schedule := monthly(6, domain.ScheduleAmountFixed)
+
+		// when
+		mockedRepo.EXPECT().ListDue(gomock.Any(), at, 50).Return([]*domain.ScheduledTransaction{schedule}, nil)
+		mockedTransactions.EXPECT().
+			Execute(gomock.Any(), int64(1), int(domain.OperationTypePayment), 100.0, gomock.Any()).
+			Return(nil, errors.New("connection refused"))
+
+		advanced, err := usecase.Execute(context.Background(), at)
+
+		// then
+		assert.NoError(t, err)
+		assert.Zero(t, advanced)
+		assert.Equal(t, dueAt, schedule.NextRunAt)
+	})
+
+	t.Run("returns error when due schedules cannot be listed", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().ListDue(gomock.Any(), at, 50).Return(nil, errors.New("connection refused"))
+
+		advanced, err := usecase.Execute(context.Background(), at)
+
+		// then
+		assert.Error(t, err)
+		assert.Zero(t, advanced)
+	})
+}
@@ -0,0 +1,38 @@
+package scheduledtransaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type UpdateScheduledTransaction struct {
+	repo domain.ScheduledTransactionRepository
+}
+
+func NewUpdateScheduledTransaction(repo domain.ScheduledTransactionRepository) *UpdateScheduledTransaction {
+	return &UpdateScheduledTransaction{repo: repo}
+}
+
+// Execute replaces the schedule definition. The next run is recomputed from
+// the new definition, runs already posted are kept.
+func (u *UpdateScheduledTransaction) Execute(ctx context.Context, ID int64, definition domain.ScheduleDefinition) (_ *domain.ScheduledTransaction, err error) {
+	ctx, span := tracing.Start(ctx, "UpdateScheduledTransaction.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	schedule, err := u.repo.FindByID(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.AuthorizeAccount(ctx, schedule.AccountID); err != nil {
+		return nil, err
+	}
+
+	if err := schedule.Redefine(definition, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	return u.repo.Update(ctx, schedule)
+}
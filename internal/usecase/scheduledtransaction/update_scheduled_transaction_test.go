@@ -0,0 +1,80 @@
+package scheduledtransaction
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateScheduledTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedRepo := mocks.NewMockScheduledTransactionRepository(ctrl)
+	usecase := NewUpdateScheduledTransaction(mockedRepo)
+
+	definition := domain.ScheduleDefinition{
+		OperationTypeID: domain.OperationTypePayment,
+		Amount:          200.0,
+		AmountType:      domain.ScheduleAmountFixed,
+		Recurrence:      domain.ScheduleRecurrenceOnce,
+		StartsAt:        time.Now().Add(24 * time.Hour),
+	}
+
+	t.Run("replaces definition and reschedules", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&domain.ScheduledTransaction{ID: 1, AccountID: 1, Status: domain.ScheduleStatusActive}, nil)
+		mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, schedule *domain.ScheduledTransaction) (*domain.ScheduledTransaction, error) {
+				return schedule, nil
+			},
+		)
+
+		schedule, err := usecase.Execute(context.Background(), 1, definition)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 200.0, schedule.Amount)
+		assert.True(t, schedule.NextRunAt.Equal(definition.StartsAt))
+	})
+
+	t.Run("returns error when schedule is completed", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(2)).Return(&domain.ScheduledTransaction{ID: 2, Status: domain.ScheduleStatusCompleted}, nil)
+
+		schedule, err := usecase.Execute(context.Background(), 2, definition)
+
+		// then
+		assert.Nil(t, schedule)
+		assert.ErrorIs(t, err, domain.ErrScheduleNotActive)
+	})
+
+	t.Run("returns error when schedule does not exist", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(999)).Return(nil, domain.ErrScheduledTransactionNotFound)
+
+		schedule, err := usecase.Execute(context.Background(), 999, definition)
+
+		// then
+		assert.Nil(t, schedule)
+		assert.ErrorIs(t, err, domain.ErrScheduledTransactionNotFound)
+	})
+
+	t.Run("denies callers restricted to another account", func(t *testing.T) {
+		// given
+		ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{ClientID: "mobile", AccountID: 2})
+
+		// when
+		mockedRepo.EXPECT().FindByID(gomock.Any(), int64(4)).Return(&domain.ScheduledTransaction{ID: 4, AccountID: 1, Status: domain.ScheduleStatusActive}, nil)
+
+		schedule, err := usecase.Execute(ctx, 4, definition)
+
+		// then
+		assert.Nil(t, schedule)
+		assert.ErrorIs(t, err, domain.ErrAccountAccessDenied)
+	})
+}
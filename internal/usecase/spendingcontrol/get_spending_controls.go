@@ -0,0 +1,39 @@
+package spendingcontrol
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type GetSpendingControls struct {
+	repo        domain.SpendingControlsRepository
+	accountRepo domain.AccountRepository
+}
+
+func NewGetSpendingControls(repo domain.SpendingControlsRepository, accountRepo domain.AccountRepository) *GetSpendingControls {
+	return &GetSpendingControls{
+		repo:        repo,
+		accountRepo: accountRepo,
+	}
+}
+
+// Execute returns the account's spending controls, or an empty rule set when
+// the account never had any configured.
+func (g *GetSpendingControls) Execute(ctx context.Context, accountID int64) (_ *domain.SpendingControls, err error) {
+	ctx, span := tracing.Start(ctx, "GetSpendingControls.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := g.accountRepo.FindByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	controls, err := g.repo.FindByAccountID(ctx, accountID)
+	if errors.Is(err, domain.ErrSpendingControlsNotFound) {
+		return domain.NewSpendingControls(accountID, nil, 0, nil, nil, "")
+	}
+
+	return controls, err
+}
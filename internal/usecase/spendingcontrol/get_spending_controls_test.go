@@ -0,0 +1,65 @@
+package spendingcontrol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetSpendingControls(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedRepo := mocks.NewMockSpendingControlsRepository(ctrl)
+	mockedAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	usecase := NewGetSpendingControls(mockedRepo, mockedAccountRepo)
+
+	t.Run("retrieves spending controls successfully", func(t *testing.T) {
+		// given
+		accountID := int64(1)
+		expectedControls := &domain.SpendingControls{AccountID: accountID, BlockedMCCs: []string{"7995"}}
+
+		// when
+		mockedAccountRepo.EXPECT().FindByID(gomock.Any(), accountID).Return(&domain.Account{ID: accountID}, nil)
+		mockedRepo.EXPECT().FindByAccountID(gomock.Any(), accountID).Return(expectedControls, nil)
+
+		controls, err := usecase.Execute(context.Background(), accountID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expectedControls, controls)
+	})
+
+	t.Run("returns empty controls when none were configured", func(t *testing.T) {
+		// given
+		accountID := int64(1)
+
+		// when
+		mockedAccountRepo.EXPECT().FindByID(gomock.Any(), accountID).Return(&domain.Account{ID: accountID}, nil)
+		mockedRepo.EXPECT().FindByAccountID(gomock.Any(), accountID).Return(nil, domain.ErrSpendingControlsNotFound)
+
+		controls, err := usecase.Execute(context.Background(), accountID)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, accountID, controls.AccountID)
+		assert.Empty(t, controls.BlockedMCCs)
+	})
+
+	t.Run("returns error when account does not exist", func(t *testing.T) {
+		// given
+		accountID := int64(999)
+
+		// when
+		mockedAccountRepo.EXPECT().FindByID(gomock.Any(), accountID).Return(nil, domain.ErrAccountNotFound)
+
+		controls, err := usecase.Execute(context.Background(), accountID)
+
+		// then
+		assert.Nil(t, controls)
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+}
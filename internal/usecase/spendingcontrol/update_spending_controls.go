@@ -0,0 +1,41 @@
+package spendingcontrol
+
+import (
+	"context"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type UpdateSpendingControls struct {
+	repo domain.SpendingControlsRepository
+}
+
+func NewUpdateSpendingControls(repo domain.SpendingControlsRepository) *UpdateSpendingControls {
+	return &UpdateSpendingControls{repo: repo}
+}
+
+func (u *UpdateSpendingControls) Execute(
+	ctx context.Context,
+	accountID int64,
+	blockedMCCs []string,
+	maxTransactionAmount float64,
+	allowedOperationTypes []int,
+	timeWindows []domain.TimeWindow,
+	timezone string,
+) (_ *domain.SpendingControls, err error) {
+	ctx, span := tracing.Start(ctx, "UpdateSpendingControls.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	operationTypes := make([]domain.OperationType, 0, len(allowedOperationTypes))
+	for _, operationType := range allowedOperationTypes {
+		operationTypes = append(operationTypes, domain.OperationType(operationType))
+	}
+
+	controls, err := domain.NewSpendingControls(accountID, blockedMCCs, maxTransactionAmount, operationTypes, timeWindows, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.repo.Save(ctx, controls)
+}
@@ -0,0 +1,60 @@
+package spendingcontrol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateSpendingControls(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockedRepo := mocks.NewMockSpendingControlsRepository(ctrl)
+	usecase := NewUpdateSpendingControls(mockedRepo)
+
+	t.Run("saves spending controls successfully", func(t *testing.T) {
+		// given
+		accountID := int64(1)
+		windows := []domain.TimeWindow{{Start: "08:00", End: "18:00"}}
+
+		// when
+		mockedRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, controls *domain.SpendingControls) (*domain.SpendingControls, error) {
+				return controls, nil
+			},
+		)
+
+		controls, err := usecase.Execute(context.Background(), accountID, []string{"7995"}, 500, []int{1, 4}, windows, "America/Sao_Paulo")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, accountID, controls.AccountID)
+		assert.Equal(t, []string{"7995"}, controls.BlockedMCCs)
+		assert.Equal(t, []domain.OperationType{domain.OperationTypePurchase, domain.OperationTypePayment}, controls.AllowedOperationTypes)
+		assert.Equal(t, windows, controls.TimeWindows)
+	})
+
+	t.Run("returns error when controls are invalid", func(t *testing.T) {
+		// when
+		controls, err := usecase.Execute(context.Background(), 1, nil, 0, []int{7}, nil, "")
+
+		// then
+		assert.Nil(t, controls)
+		assert.ErrorIs(t, err, domain.ErrInvalidOperationType)
+	})
+
+	t.Run("returns error when account does not exist", func(t *testing.T) {
+		// when
+		mockedRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil, domain.ErrAccountNotFound)
+
+		controls, err := usecase.Execute(context.Background(), 999, nil, 0, nil, nil, "")
+
+		// then
+		assert.Nil(t, controls)
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+}
@@ -2,53 +2,214 @@ package transaction
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 
 	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/logger"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
 )
 
 type CreateTransaction struct {
-	repo domain.TransactionRepository
+	repo         domain.TransactionRepository
+	accountRepo  domain.AccountRepository
+	cardRepo     domain.CardRepository
+	fxRates      domain.FXRateProvider
+	controlsRepo domain.SpendingControlsRepository
+	fraud        domain.FraudScreener
 }
 
-func NewCreateTransaction(repo domain.TransactionRepository) *CreateTransaction {
-	return &CreateTransaction{repo: repo}
+func NewCreateTransaction(
+	repo domain.TransactionRepository,
+	accountRepo domain.AccountRepository,
+	cardRepo domain.CardRepository,
+	fxRates domain.FXRateProvider,
+	controlsRepo domain.SpendingControlsRepository,
+	fraud domain.FraudScreener,
+) *CreateTransaction {
+	return &CreateTransaction{
+		repo:         repo,
+		accountRepo:  accountRepo,
+		cardRepo:     cardRepo,
+		fxRates:      fxRates,
+		controlsRepo: controlsRepo,
+		fraud:        fraud,
+	}
 }
 
-func (c *CreateTransaction) Execute(ctx context.Context, accountID int64, operationTypeID int, amount float64) (*domain.Transaction, error) {
-	var balance float64
-	operationType := domain.OperationType(operationTypeID)
-	if operationType.IsDebit() {
-		balance = -amount
-	} else {
-		balance = amount
+func (c *CreateTransaction) Execute(ctx context.Context, accountID int64, operationTypeID int, amount float64, details domain.TransactionDetails) (_ *domain.Transaction, err error) {
+	ctx, span := tracing.Start(ctx, "CreateTransaction.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	if err := domain.AuthorizeAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	// Validate before touching the repository so a rejected request never
+	// discharges past debits.
+	transaction, err := domain.NewTransaction(accountID, domain.OperationType(operationTypeID), amount, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := details.Validate(); err != nil {
+		return nil, err
+	}
+	transaction.TransactionDetails = details
+
+	if details.ExternalID != "" {
+		_, err := c.repo.FindByExternalID(ctx, accountID, details.ExternalID)
+		if err == nil {
+			return nil, domain.ErrTransactionAlreadyExists
+		}
+		if !errors.Is(err, domain.ErrTransactionNotFound) {
+			return nil, err
+		}
+	}
+
+	account, err := c.accountRepo.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.CardID != 0 {
+		if err := c.checkCard(ctx, transaction); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.convert(ctx, transaction, account.Currency); err != nil {
+		return nil, err
+	}
 
-		pastTransactions, err := c.repo.ListByAccountID(ctx, accountID)
+	// Adjustments are posted by the issuer, so customer rules do not apply.
+	if !transaction.OperationTypeID.IsAdjustment() {
+		if err := c.checkSpendingControls(ctx, transaction); err != nil {
+			return nil, err
+		}
+	}
+
+	if !transaction.OperationTypeID.IsDebit() {
+		balance, err := c.discharge(ctx, accountID, transaction.Amount)
 		if err != nil {
 			return nil, err
 		}
+		transaction.Balance = balance
 
-		for _, pastTransaction := range pastTransactions {
-			if pastTransaction.OperationTypeID.IsDebit() && pastTransaction.IsNegative() && balance > 0 {
-				if balance >= -pastTransaction.Balance {
-					balance = balance + pastTransaction.Balance
-					pastTransaction.Balance = 0
-				} else {
-					pastTransaction.Balance = pastTransaction.Balance + balance
-					balance = 0
-				}
-
-				_, err := c.repo.UpdateBalance(ctx, pastTransaction)
-				if err != nil {
-					return nil, err
-				}
-			}
+		return c.repo.Create(ctx, transaction)
+	}
+
+	if transaction.OperationTypeID.IsAdjustment() {
+		transaction.Balance = transaction.Amount
+		return c.repo.Create(ctx, transaction)
+	}
+
+	assessment, err := c.fraud.Assess(ctx, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if assessment.Decision == domain.FraudDecisionDecline {
+		if err := c.fraud.Record(ctx, assessment); err != nil {
+			return nil, err
 		}
+		return nil, domain.ErrTransactionDeclinedByFraud
 	}
 
-	transaction, err := domain.NewTransaction(accountID, domain.OperationType(operationTypeID), amount, balance)
+	transaction.Balance = transaction.Amount
+	created, err := c.repo.Create(ctx, transaction)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.repo.Create(ctx, transaction)
+	assessment.TransactionID = created.ID
+	if err := c.fraud.Record(ctx, assessment); err != nil {
+		// The debit is already stored, failing the request would make the
+		// client retry a transaction that went through.
+		logger.Error(ctx, "failed to record fraud assessment",
+			slog.Int64("transaction_id", created.ID),
+			slog.String("decision", string(assessment.Decision)),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return created, nil
+}
+
+// checkCard ensures the card belongs to the account and is usable at the
+// transaction's event date.
+func (c *CreateTransaction) checkCard(ctx context.Context, transaction *domain.Transaction) error {
+	card, err := c.cardRepo.FindByID(ctx, transaction.CardID)
+	if err != nil {
+		return err
+	}
+
+	if card.AccountID != transaction.AccountID {
+		return domain.ErrCardNotFound
+	}
+
+	return card.CanTransact(transaction.EventDate)
+}
+
+// convert brings the transaction amount to the account's settlement currency
+// using the rate in effect at the event date.
+func (c *CreateTransaction) convert(ctx context.Context, transaction *domain.Transaction, settlementCurrency string) error {
+	rate := 1.0
+	if transaction.Currency != "" && transaction.Currency != settlementCurrency {
+		var err error
+		rate, err = c.fxRates.Rate(ctx, transaction.Currency, settlementCurrency, transaction.EventDate)
+		if err != nil {
+			return err
+		}
+	}
+
+	transaction.Convert(settlementCurrency, rate)
+	return nil
+}
+
+// checkSpendingControls rejects the transaction when it violates a rule the
+// account owner configured. Accounts without controls accept everything.
+func (c *CreateTransaction) checkSpendingControls(ctx context.Context, transaction *domain.Transaction) error {
+	controls, err := c.controlsRepo.FindByAccountID(ctx, transaction.AccountID)
+	if errors.Is(err, domain.ErrSpendingControlsNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return controls.Evaluate(transaction)
+}
+
+// discharge uses a credit to pay off the account's outstanding debits, oldest
+// first, and returns what is left of the credit.
+func (c *CreateTransaction) discharge(ctx context.Context, accountID int64, amount float64) (float64, error) {
+	balance := amount
+
+	pastTransactions, err := c.repo.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return 0, err
+	}
+
+	var discharged []*domain.Transaction
+	for _, pastTransaction := range pastTransactions {
+		if pastTransaction.OperationTypeID.IsDebit() && pastTransaction.IsNegative() && balance > 0 {
+			if balance >= -pastTransaction.Balance {
+				balance = balance + pastTransaction.Balance
+				pastTransaction.Balance = 0
+			} else {
+				pastTransaction.Balance = pastTransaction.Balance + balance
+				balance = 0
+			}
+			discharged = append(discharged, pastTransaction)
+		}
+	}
+
+	if len(discharged) > 0 {
+		if err := c.repo.UpdateBalances(ctx, discharged); err != nil {
+			return 0, err
+		}
+	}
+
+	return balance, nil
 }
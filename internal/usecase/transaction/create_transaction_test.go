@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/nubank/pismo-code-assessment/internal/domain"
 	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/fx"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -16,7 +18,17 @@ func TestCreateTransaction(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockTransactionRepository(ctrl)
-	usecase := NewCreateTransaction(mockRepo)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	fxRates := fx.NewStaticRateProvider(map[string]float64{"USD/BRL": 5.0})
+	mockControlsRepo := mocks.NewMockSpendingControlsRepository(ctrl)
+	mockFraud := mocks.NewMockFraudScreener(ctrl)
+	mockCardRepo := mocks.NewMockCardRepository(ctrl)
+	usecase := NewCreateTransaction(mockRepo, mockAccountRepo, mockCardRepo, fxRates, mockControlsRepo, mockFraud)
+
+	mockAccountRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&domain.Account{ID: 1, Currency: "BRL"}, nil).AnyTimes()
+	mockControlsRepo.EXPECT().FindByAccountID(gomock.Any(), int64(1)).Return(nil, domain.ErrSpendingControlsNotFound).AnyTimes()
+	mockFraud.EXPECT().Assess(gomock.Any(), gomock.Any()).Return(&domain.FraudAssessment{Decision: domain.FraudDecisionApprove}, nil).AnyTimes()
+	mockFraud.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	t.Run("creates transaction successfully", func(t *testing.T) {
 		// given
@@ -25,6 +37,7 @@ func TestCreateTransaction(t *testing.T) {
 		amount := 123.45
 
 		// when
+		mockRepo.EXPECT().ListByAccountID(gomock.Any(), accountID).Return(nil, nil)
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
 				tx.ID = 1
@@ -32,7 +45,7 @@ func TestCreateTransaction(t *testing.T) {
 			},
 		)
 
-		transaction, err := usecase.Execute(context.Background(), accountID, operationTypeID, amount)
+		transaction, err := usecase.Execute(context.Background(), accountID, operationTypeID, amount, domain.TransactionDetails{})
 
 		// then
 		assert.NoError(t, err)
@@ -57,7 +70,7 @@ func TestCreateTransaction(t *testing.T) {
 			},
 		)
 
-		transaction, err := usecase.Execute(context.Background(), accountID, operationTypeID, amount)
+		transaction, err := usecase.Execute(context.Background(), accountID, operationTypeID, amount, domain.TransactionDetails{})
 
 		// then
 		assert.NoError(t, err)
@@ -69,9 +82,9 @@ func TestCreateTransaction(t *testing.T) {
 		accountID := int64(999)
 
 		// when
-		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, domain.ErrAccountNotFound)
+		mockAccountRepo.EXPECT().FindByID(gomock.Any(), accountID).Return(nil, domain.ErrAccountNotFound)
 
-		transaction, err := usecase.Execute(context.Background(), accountID, 1, 50.0)
+		transaction, err := usecase.Execute(context.Background(), accountID, 1, 50.0, domain.TransactionDetails{})
 
 		// then
 		assert.Nil(t, transaction)
@@ -83,7 +96,7 @@ func TestCreateTransaction(t *testing.T) {
 		accountID := int64(1)
 
 		// when - domain validation catches invalid operation type
-		transaction, err := usecase.Execute(context.Background(), accountID, 99, 50.0)
+		transaction, err := usecase.Execute(context.Background(), accountID, 99, 50.0, domain.TransactionDetails{})
 
 		// then
 		assert.Nil(t, transaction)
@@ -95,7 +108,7 @@ func TestCreateTransaction(t *testing.T) {
 		accountID := int64(1)
 
 		// when
-		transaction, err := usecase.Execute(context.Background(), accountID, 1, 0)
+		transaction, err := usecase.Execute(context.Background(), accountID, 1, 0, domain.TransactionDetails{})
 
 		// then
 		assert.Nil(t, transaction)
@@ -109,10 +122,310 @@ func TestCreateTransaction(t *testing.T) {
 		// when
 		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
 
-		transaction, err := usecase.Execute(context.Background(), accountID, 1, 50.0)
+		transaction, err := usecase.Execute(context.Background(), accountID, 1, 50.0, domain.TransactionDetails{})
 
 		// then
 		assert.Nil(t, transaction)
 		assert.Error(t, err)
 	})
+
+	t.Run("persists transaction details", func(t *testing.T) {
+		// given
+		accountID := int64(1)
+		details := domain.TransactionDetails{
+			ExternalID:           "auth-123",
+			Description:          "Coffee",
+			MerchantName:         "Coffee Shop",
+			MerchantCategoryCode: "5814",
+			Currency:             "BRL",
+			Metadata:             map[string]any{"terminal": "T1"},
+		}
+
+		// when
+		mockRepo.EXPECT().FindByExternalID(gomock.Any(), accountID, "auth-123").Return(nil, domain.ErrTransactionNotFound)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
+				tx.ID = 3
+				return tx, nil
+			},
+		)
+
+		transaction, err := usecase.Execute(context.Background(), accountID, 1, 10.0, details)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, details, transaction.TransactionDetails)
+	})
+
+	t.Run("returns error when external id was already used by the account", func(t *testing.T) {
+		// given
+		accountID := int64(1)
+		details := domain.TransactionDetails{ExternalID: "auth-123"}
+
+		// when
+		mockRepo.EXPECT().FindByExternalID(gomock.Any(), accountID, "auth-123").Return(&domain.Transaction{ID: 3}, nil)
+
+		transaction, err := usecase.Execute(context.Background(), accountID, 1, 10.0, details)
+
+		// then
+		assert.Nil(t, transaction)
+		assert.ErrorIs(t, err, domain.ErrTransactionAlreadyExists)
+	})
+
+	t.Run("returns error when currency is invalid", func(t *testing.T) {
+		// given
+		accountID := int64(1)
+		details := domain.TransactionDetails{Currency: "real"}
+
+		// when
+		transaction, err := usecase.Execute(context.Background(), accountID, 1, 10.0, details)
+
+		// then
+		assert.Nil(t, transaction)
+		assert.ErrorIs(t, err, domain.ErrInvalidCurrency)
+	})
+
+	t.Run("converts amount to the account settlement currency", func(t *testing.T) {
+		// given
+		accountID := int64(1)
+		details := domain.TransactionDetails{Currency: "USD"}
+
+		// when
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
+				tx.ID = 4
+				return tx, nil
+			},
+		)
+
+		transaction, err := usecase.Execute(context.Background(), accountID, 1, 10.0, details)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, -50.0, transaction.Amount)
+		assert.Equal(t, -50.0, transaction.Balance)
+		assert.Equal(t, -10.0, transaction.OriginalAmount)
+		assert.Equal(t, "USD", transaction.Currency)
+		assert.Equal(t, "BRL", transaction.SettlementCurrency)
+		assert.Equal(t, 5.0, transaction.FXRate)
+	})
+
+	t.Run("returns error when no exchange rate is available", func(t *testing.T) {
+		// given
+		accountID := int64(1)
+		details := domain.TransactionDetails{Currency: "EUR"}
+
+		// when
+		transaction, err := usecase.Execute(context.Background(), accountID, 1, 10.0, details)
+
+		// then
+		assert.Nil(t, transaction)
+		assert.ErrorIs(t, err, domain.ErrFXRateUnavailable)
+	})
+
+	t.Run("returns error when transaction violates spending controls", func(t *testing.T) {
+		// given
+		accountID := int64(2)
+		details := domain.TransactionDetails{MerchantCategoryCode: "7995"}
+		controls := &domain.SpendingControls{AccountID: accountID, BlockedMCCs: []string{"7995"}}
+
+		// when
+		mockAccountRepo.EXPECT().FindByID(gomock.Any(), accountID).Return(&domain.Account{ID: accountID, Currency: "BRL"}, nil)
+		mockControlsRepo.EXPECT().FindByAccountID(gomock.Any(), accountID).Return(controls, nil)
+
+		transaction, err := usecase.Execute(context.Background(), accountID, 1, 10.0, details)
+
+		// then
+		assert.Nil(t, transaction)
+		assert.ErrorIs(t, err, domain.ErrMerchantCategoryBlocked)
+	})
+
+	t.Run("creates transaction with a usable card", func(t *testing.T) {
+		// given
+		accountID := int64(1)
+		card := &domain.Card{ID: 7, AccountID: accountID, Status: domain.CardStatusActive, ExpiresAt: time.Now().AddDate(1, 0, 0)}
+
+		// when
+		mockCardRepo.EXPECT().FindByID(gomock.Any(), int64(7)).Return(card, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
+				tx.ID = 5
+				return tx, nil
+			},
+		)
+
+		transaction, err := usecase.Execute(context.Background(), accountID, 1, 10.0, domain.TransactionDetails{CardID: 7})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), transaction.CardID)
+	})
+
+	t.Run("returns error when card is blocked", func(t *testing.T) {
+		// given
+		accountID := int64(1)
+		card := &domain.Card{ID: 8, AccountID: accountID, Status: domain.CardStatusBlocked, ExpiresAt: time.Now().AddDate(1, 0, 0)}
+
+		// when
+		mockCardRepo.EXPECT().FindByID(gomock.Any(), int64(8)).Return(card, nil)
+
+		transaction, err := usecase.Execute(context.Background(), accountID, 1, 10.0, domain.TransactionDetails{CardID: 8})
+
+		// then
+		assert.Nil(t, transaction)
+		assert.ErrorIs(t, err, domain.ErrCardBlocked)
+	})
+
+	t.Run("returns error when card is expired", func(t *testing.T) {
+		// given
+		accountID := int64(1)
+		card := &domain.Card{ID: 9, AccountID: accountID, Status: domain.CardStatusActive, ExpiresAt: time.Now().AddDate(0, -1, 0)}
+
+		// when
+		mockCardRepo.EXPECT().FindByID(gomock.Any(), int64(9)).Return(card, nil)
+
+		transaction, err := usecase.Execute(context.Background(), accountID, 1, 10.0, domain.TransactionDetails{CardID: 9})
+
+		// then
+		assert.Nil(t, transaction)
+		assert.ErrorIs(t, err, domain.ErrCardExpired)
+	})
+
+	t.Run("returns error when card belongs to another account", func(t *testing.T) {
+		// given
+		accountID := int64(1)
+		card := &domain.Card{ID: 10, AccountID: 2, Status: domain.CardStatusActive, ExpiresAt: time.Now().AddDate(1, 0, 0)}
+
+		// when
+		mockCardRepo.EXPECT().FindByID(gomock.Any(), int64(10)).Return(card, nil)
+
+		transaction, err := usecase.Execute(context.Background(), accountID, 1, 10.0, domain.TransactionDetails{CardID: 10})
+
+		// then
+		assert.Nil(t, transaction)
+		assert.ErrorIs(t, err, domain.ErrCardNotFound)
+	})
+
+	t.Run("denies callers restricted to another account", func(t *testing.T) {
+		// given
+		ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{ClientID: "mobile", AccountID: 2})
+
+		// when
+		transaction, err := usecase.Execute(ctx, 1, 4, 50.0, domain.TransactionDetails{})
+
+		// then
+		assert.Nil(t, transaction)
+		assert.ErrorIs(t, err, domain.ErrAccountAccessDenied)
+	})
+}
+
+func TestCreateTransaction_FraudScreening(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	mockControlsRepo := mocks.NewMockSpendingControlsRepository(ctrl)
+	mockFraud := mocks.NewMockFraudScreener(ctrl)
+	usecase := NewCreateTransaction(mockRepo, mockAccountRepo, mocks.NewMockCardRepository(ctrl), fx.NewStaticRateProvider(nil), mockControlsRepo, mockFraud)
+
+	mockAccountRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&domain.Account{ID: 1, Currency: "BRL"}, nil).AnyTimes()
+	mockControlsRepo.EXPECT().FindByAccountID(gomock.Any(), int64(1)).Return(nil, domain.ErrSpendingControlsNotFound).AnyTimes()
+
+	t.Run("records assessment with the created transaction", func(t *testing.T) {
+		// given
+		assessment := &domain.FraudAssessment{AccountID: 1, Decision: domain.FraudDecisionReview, TriggeredRules: []string{"amount_spike"}}
+
+		// when
+		mockFraud.EXPECT().Assess(gomock.Any(), gomock.Any()).Return(assessment, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
+				tx.ID = 10
+				return tx, nil
+			},
+		)
+		mockFraud.EXPECT().Record(gomock.Any(), assessment).Return(nil)
+
+		transaction, err := usecase.Execute(context.Background(), 1, 1, 50.0, domain.TransactionDetails{})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), transaction.ID)
+		assert.Equal(t, int64(10), assessment.TransactionID)
+	})
+
+	t.Run("declines debit and records the decision", func(t *testing.T) {
+		// given
+		assessment := &domain.FraudAssessment{AccountID: 1, Decision: domain.FraudDecisionDecline, TriggeredRules: []string{"debits_per_10_minutes"}}
+
+		// when
+		mockFraud.EXPECT().Assess(gomock.Any(), gomock.Any()).Return(assessment, nil)
+		mockFraud.EXPECT().Record(gomock.Any(), assessment).Return(nil)
+
+		transaction, err := usecase.Execute(context.Background(), 1, 3, 50.0, domain.TransactionDetails{})
+
+		// then
+		assert.Nil(t, transaction)
+		assert.ErrorIs(t, err, domain.ErrTransactionDeclinedByFraud)
+	})
+
+	t.Run("does not screen payments", func(t *testing.T) {
+		// when
+		mockRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return(nil, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
+				tx.ID = 11
+				return tx, nil
+			},
+		)
+
+		transaction, err := usecase.Execute(context.Background(), 1, 4, 50.0, domain.TransactionDetails{})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int64(11), transaction.ID)
+	})
+
+	t.Run("discharges outstanding debits in a single update", func(t *testing.T) {
+		// given
+		pastTransactions := []*domain.Transaction{
+			{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -30.0, Balance: -30.0},
+			{ID: 2, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -40.0, Balance: -40.0},
+		}
+
+		// when
+		mockRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return(pastTransactions, nil)
+		mockRepo.EXPECT().UpdateBalances(gomock.Any(), pastTransactions).Return(nil)
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
+				tx.ID = 13
+				return tx, nil
+			},
+		)
+
+		transaction, err := usecase.Execute(context.Background(), 1, 4, 50.0, domain.TransactionDetails{})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, transaction.Balance)
+		assert.Equal(t, 0.0, pastTransactions[0].Balance)
+		assert.Equal(t, -20.0, pastTransactions[1].Balance)
+	})
+
+	t.Run("does not screen dispute reversals", func(t *testing.T) {
+		// when
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
+				tx.ID = 12
+				return tx, nil
+			},
+		)
+
+		transaction, err := usecase.Execute(context.Background(), 1, int(domain.OperationTypeDisputeReversal), 50.0, domain.TransactionDetails{})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, -50.0, transaction.Amount)
+		assert.Equal(t, -50.0, transaction.Balance)
+	})
 }
@@ -0,0 +1,36 @@
+package transaction
+
+import (
+	"context"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type GetBalance struct {
+	repo        domain.TransactionRepository
+	accountRepo domain.AccountRepository
+}
+
+func NewGetBalance(repo domain.TransactionRepository, accountRepo domain.AccountRepository) *GetBalance {
+	return &GetBalance{
+		repo:        repo,
+		accountRepo: accountRepo,
+	}
+}
+
+func (g *GetBalance) Execute(ctx context.Context, accountID int64) (_ *domain.AccountBalance, err error) {
+	ctx, span := tracing.Start(ctx, "GetBalance.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := g.accountRepo.FindByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	transactions, err := g.repo.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewAccountBalance(accountID, transactions), nil
+}
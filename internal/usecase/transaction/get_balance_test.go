@@ -0,0 +1,63 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	usecase := NewGetBalance(mockRepo, mockAccountRepo)
+
+	t.Run("summarizes the account's balances", func(t *testing.T) {
+		// given
+		transactions := []*domain.Transaction{
+			{ID: 1, AccountID: 1, Amount: -50.0, Balance: -30.0},
+			{ID: 2, AccountID: 1, Amount: 20.0, Balance: 0},
+			{ID: 3, AccountID: 1, Amount: 15.0, Balance: 15.0},
+		}
+
+		// when
+		mockAccountRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&domain.Account{ID: 1}, nil)
+		mockRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return(transactions, nil)
+
+		balance, err := usecase.Execute(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.AccountBalance{AccountID: 1, Outstanding: 30.0, Credit: 15.0}, balance)
+	})
+
+	t.Run("returns error when account does not exist", func(t *testing.T) {
+		// when
+		mockAccountRepo.EXPECT().FindByID(gomock.Any(), int64(999)).Return(nil, domain.ErrAccountNotFound)
+
+		balance, err := usecase.Execute(context.Background(), 999)
+
+		// then
+		assert.Nil(t, balance)
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+
+	t.Run("returns error when listing transactions fails", func(t *testing.T) {
+		// when
+		mockAccountRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&domain.Account{ID: 1}, nil)
+		mockRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return(nil, errors.New("database error"))
+
+		balance, err := usecase.Execute(context.Background(), 1)
+
+		// then
+		assert.Nil(t, balance)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,32 @@
+package transaction
+
+import (
+	"context"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+type ListTransactions struct {
+	repo        domain.TransactionRepository
+	accountRepo domain.AccountRepository
+}
+
+func NewListTransactions(repo domain.TransactionRepository, accountRepo domain.AccountRepository) *ListTransactions {
+	return &ListTransactions{
+		repo:        repo,
+		accountRepo: accountRepo,
+	}
+}
+
+// Execute returns the account's ledger in chronological order.
+func (l *ListTransactions) Execute(ctx context.Context, accountID int64) (_ []*domain.Transaction, err error) {
+	ctx, span := tracing.Start(ctx, "ListTransactions.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := l.accountRepo.FindByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	return l.repo.ListByAccountID(ctx, accountID)
+}
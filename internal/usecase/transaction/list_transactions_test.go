@@ -0,0 +1,49 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	usecase := NewListTransactions(mockRepo, mockAccountRepo)
+
+	t.Run("lists the account's transactions", func(t *testing.T) {
+		// given
+		transactions := []*domain.Transaction{
+			{ID: 1, AccountID: 1, Amount: -50.0},
+			{ID: 2, AccountID: 1, Amount: 60.0},
+		}
+
+		// when
+		mockAccountRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&domain.Account{ID: 1}, nil)
+		mockRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return(transactions, nil)
+
+		result, err := usecase.Execute(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, transactions, result)
+	})
+
+	t.Run("returns error when account does not exist", func(t *testing.T) {
+		// when
+		mockAccountRepo.EXPECT().FindByID(gomock.Any(), int64(999)).Return(nil, domain.ErrAccountNotFound)
+
+		result, err := usecase.Execute(context.Background(), 999)
+
+		// then
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+}
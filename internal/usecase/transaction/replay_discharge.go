@@ -0,0 +1,48 @@
+package transaction
+
+import (
+	"context"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/pkg/tracing"
+)
+
+// ReplayDischarge recomputes an account's balances from its ledger, as if every
+// credit had discharged the debits before it when it was posted. It repairs
+// balances left inconsistent by a failed discharge or a manual correction.
+type ReplayDischarge struct {
+	repo        domain.TransactionRepository
+	accountRepo domain.AccountRepository
+}
+
+func NewReplayDischarge(repo domain.TransactionRepository, accountRepo domain.AccountRepository) *ReplayDischarge {
+	return &ReplayDischarge{
+		repo:        repo,
+		accountRepo: accountRepo,
+	}
+}
+
+// Execute stores the recomputed balances and returns the transactions whose
+// balance changed.
+func (r *ReplayDischarge) Execute(ctx context.Context, accountID int64) (_ []*domain.Transaction, err error) {
+	ctx, span := tracing.Start(ctx, "ReplayDischarge.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := r.accountRepo.FindByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	transactions, err := r.repo.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := domain.Rebalance(transactions)
+	if len(changed) > 0 {
+		if err := r.repo.UpdateBalances(ctx, changed); err != nil {
+			return nil, err
+		}
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,91 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReplayDischarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTransactionRepository(ctrl)
+	mockAccountRepo := mocks.NewMockAccountRepository(ctrl)
+	usecase := NewReplayDischarge(mockRepo, mockAccountRepo)
+
+	t.Run("stores the balances that changed", func(t *testing.T) {
+		// given
+		transactions := []*domain.Transaction{
+			{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.0, Balance: -50.0},
+			{ID: 2, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -10.0, Balance: -10.0},
+			{ID: 3, AccountID: 1, OperationTypeID: domain.OperationTypePayment, Amount: 55.0, Balance: 55.0},
+		}
+
+		// when
+		mockAccountRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&domain.Account{ID: 1}, nil)
+		mockRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return(transactions, nil)
+		mockRepo.EXPECT().UpdateBalances(gomock.Any(), transactions).Return(nil)
+
+		changed, err := usecase.Execute(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, changed, 3)
+		assert.Equal(t, 0.0, transactions[0].Balance)
+		assert.Equal(t, -5.0, transactions[1].Balance)
+		assert.Equal(t, 0.0, transactions[2].Balance)
+	})
+
+	t.Run("does nothing when balances are consistent", func(t *testing.T) {
+		// given
+		transactions := []*domain.Transaction{
+			{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.0, Balance: 0},
+			{ID: 2, AccountID: 1, OperationTypeID: domain.OperationTypePayment, Amount: 50.0, Balance: 0},
+		}
+
+		// when
+		mockAccountRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&domain.Account{ID: 1}, nil)
+		mockRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return(transactions, nil)
+
+		changed, err := usecase.Execute(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Empty(t, changed)
+	})
+
+	t.Run("returns error when account does not exist", func(t *testing.T) {
+		// when
+		mockAccountRepo.EXPECT().FindByID(gomock.Any(), int64(999)).Return(nil, domain.ErrAccountNotFound)
+
+		changed, err := usecase.Execute(context.Background(), 999)
+
+		// then
+		assert.Nil(t, changed)
+		assert.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+
+	t.Run("returns error when updating a balance fails", func(t *testing.T) {
+		// given
+		transactions := []*domain.Transaction{
+			{ID: 1, AccountID: 1, OperationTypeID: domain.OperationTypePurchase, Amount: -50.0, Balance: -20.0},
+		}
+
+		// when
+		mockAccountRepo.EXPECT().FindByID(gomock.Any(), int64(1)).Return(&domain.Account{ID: 1}, nil)
+		mockRepo.EXPECT().ListByAccountID(gomock.Any(), int64(1)).Return(transactions, nil)
+		mockRepo.EXPECT().UpdateBalances(gomock.Any(), transactions).Return(errors.New("database error"))
+
+		changed, err := usecase.Execute(context.Background(), 1)
+
+		// then
+		assert.Nil(t, changed)
+		assert.Error(t, err)
+	})
+}
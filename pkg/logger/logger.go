@@ -5,6 +5,8 @@ import (
 	"log/slog"
 	"os"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 type contextKey string
@@ -12,6 +14,9 @@ type contextKey string
 // RequestIDKey is the context key for request ID.
 const RequestIDKey contextKey = "request_id"
 
+// ClientIDKey is the context key for the authenticated client ID.
+const ClientIDKey contextKey = "client_id"
+
 var defaultLogger *slog.Logger
 
 // WithRequestID returns a new context with the request ID stored.
@@ -19,18 +24,24 @@ func WithRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, RequestIDKey, requestID)
 }
 
-func Init(env string) {
+// WithClientID returns a new context with the authenticated client ID stored.
+func WithClientID(ctx context.Context, clientID string) context.Context {
+	return context.WithValue(ctx, ClientIDKey, clientID)
+}
+
+// ClientID returns the authenticated client ID stored in the context, if any.
+func ClientID(ctx context.Context) string {
+	clientID, _ := ctx.Value(ClientIDKey).(string)
+	return clientID
+}
+
+// Init sets up the default logger, redacting sensitive values as configured.
+func Init(env string, redaction Redaction) {
+	setRedaction(redaction)
+
 	opts := &slog.HandlerOptions{
-		Level: slog.LevelDebug,
-		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-			// Ensure timestamp is always in RFC3339 format
-			if a.Key == slog.TimeKey {
-				if t, ok := a.Value.Any().(time.Time); ok {
-					return slog.String(slog.TimeKey, t.Format(time.RFC3339))
-				}
-			}
-			return a
-		},
+		Level:       slog.LevelDebug,
+		ReplaceAttr: replaceAttr,
 	}
 
 	var handler slog.Handler
@@ -44,10 +55,20 @@ func Init(env string) {
 	defaultLogger = slog.New(handler)
 }
 
+func replaceAttr(groups []string, a slog.Attr) slog.Attr {
+	// Ensure timestamp is always in RFC3339 format
+	if a.Key == slog.TimeKey {
+		if t, ok := a.Value.Any().(time.Time); ok {
+			return slog.String(slog.TimeKey, t.Format(time.RFC3339))
+		}
+	}
+	return redactAttr(a)
+}
+
 // Default returns the singleton logger instance.
 func Default() *slog.Logger {
 	if defaultLogger == nil {
-		Init("development")
+		Init("development", Redaction{Mode: RedactMask})
 	}
 	return defaultLogger
 }
@@ -55,27 +76,36 @@ func Default() *slog.Logger {
 func withRequestID(ctx context.Context) *slog.Logger {
 	log := Default()
 	if requestID, ok := ctx.Value(RequestIDKey).(string); ok && requestID != "" {
-		return log.With(slog.String("request_id", requestID))
+		log = log.With(slog.String("request_id", requestID))
+	}
+	if clientID := ClientID(ctx); clientID != "" {
+		log = log.With(slog.String("client_id", clientID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		log = log.With(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
 	}
 	return log
 }
 
-// Info logs at INFO level with request_id from context.
+// Info logs at INFO level with request_id, client_id and trace_id from context.
 func Info(ctx context.Context, msg string, args ...any) {
 	withRequestID(ctx).Info(msg, args...)
 }
 
-// Error logs at ERROR level with request_id from context.
+// Error logs at ERROR level with request_id, client_id and trace_id from context.
 func Error(ctx context.Context, msg string, args ...any) {
 	withRequestID(ctx).Error(msg, args...)
 }
 
-// Debug logs at DEBUG level with request_id from context.
+// Debug logs at DEBUG level with request_id, client_id and trace_id from context.
 func Debug(ctx context.Context, msg string, args ...any) {
 	withRequestID(ctx).Debug(msg, args...)
 }
 
-// Warn logs at WARN level with request_id from context.
+// Warn logs at WARN level with request_id, client_id and trace_id from context.
 func Warn(ctx context.Context, msg string, args ...any) {
 	withRequestID(ctx).Warn(msg, args...)
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestInfo(t *testing.T) {
@@ -32,6 +33,34 @@ func TestInfo(t *testing.T) {
 		assert.Contains(t, buf.String(), "test message")
 	})
 
+	t.Run("logs with client_id when present in context", func(t *testing.T) {
+		var buf bytes.Buffer
+		defaultLogger = slog.New(slog.NewTextHandler(&buf, nil))
+
+		ctx := WithClientID(WithRequestID(context.Background(), "test-request-id-123"), "billing")
+
+		Info(ctx, "test message")
+
+		assert.Contains(t, buf.String(), "request_id=test-request-id-123")
+		assert.Contains(t, buf.String(), "client_id=billing")
+	})
+
+	t.Run("logs with trace_id and span_id when a span is in context", func(t *testing.T) {
+		var buf bytes.Buffer
+		defaultLogger = slog.New(slog.NewTextHandler(&buf, nil))
+
+		traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+		ctx := trace.ContextWithSpanContext(WithRequestID(context.Background(), "test-request-id-123"),
+			trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+		Info(ctx, "test message")
+
+		assert.Contains(t, buf.String(), "request_id=test-request-id-123")
+		assert.Contains(t, buf.String(), "trace_id=4bf92f3577b34da6a3ce929d0e0e4736")
+		assert.Contains(t, buf.String(), "span_id=00f067aa0ba902b7")
+	})
+
 	t.Run("includes timestamp in logs", func(t *testing.T) {
 		var buf bytes.Buffer
 		defaultLogger = slog.New(slog.NewTextHandler(&buf, nil))
@@ -59,12 +88,12 @@ func TestError(t *testing.T) {
 
 func TestInit(t *testing.T) {
 	t.Run("creates JSON logger for production", func(t *testing.T) {
-		Init("production")
+		Init("production", Redaction{Mode: RedactHash})
 		assert.NotNil(t, defaultLogger)
 	})
 
 	t.Run("creates text logger for development", func(t *testing.T) {
-		Init("development")
+		Init("development", Redaction{Mode: RedactMask})
 		assert.NotNil(t, defaultLogger)
 	})
 }
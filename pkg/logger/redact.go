@@ -0,0 +1,179 @@
+package logger
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// RedactionMode says how sensitive values are written to the logs.
+type RedactionMode string
+
+const (
+	// RedactMask keeps just enough of a value to tell values apart, like the
+	// last digits of a document.
+	RedactMask RedactionMode = "mask"
+	// RedactHash replaces values by a keyed hash, so that log lines about the
+	// same value can be correlated without revealing it.
+	RedactHash RedactionMode = "hash"
+	// RedactDrop leaves sensitive attributes out of the logs.
+	RedactDrop RedactionMode = "drop"
+)
+
+// Redaction configures how sensitive values are logged.
+type Redaction struct {
+	Mode RedactionMode
+	// HashKey keys the hashes of RedactHash, so that they cannot be reversed
+	// by hashing every possible document number. A random key is used when
+	// empty, which only correlates the lines of one process.
+	HashKey []byte
+}
+
+// SensitiveKind says what a sensitive value is, which decides how it is masked.
+type SensitiveKind int
+
+const (
+	// KindDocument is a CPF or CNPJ.
+	KindDocument SensitiveKind = iota
+	KindCardNumber
+	// KindSecret is an API key, token or password, of which nothing is kept.
+	KindSecret
+)
+
+var (
+	redactionMu sync.RWMutex
+	redaction   = Redaction{Mode: RedactMask}
+
+	// sensitiveKeys are attribute keys whose string values are redacted even
+	// when they are not wrapped in Sensitive.
+	sensitiveKeys = map[string]SensitiveKind{
+		"document_number": KindDocument,
+		"card_number":     KindCardNumber,
+		"pan":             KindCardNumber,
+		"api_key":         KindSecret,
+		"authorization":   KindSecret,
+		"password":        KindSecret,
+		"secret":          KindSecret,
+		"token":           KindSecret,
+	}
+)
+
+// RegisterSensitiveKey redacts the string values of attributes with the key.
+// It is meant to be called from init functions.
+func RegisterSensitiveKey(key string, kind SensitiveKind) {
+	redactionMu.Lock()
+	defer redactionMu.Unlock()
+	sensitiveKeys[key] = kind
+}
+
+func setRedaction(r Redaction) {
+	if r.Mode == "" {
+		r.Mode = RedactMask
+	}
+	if r.Mode == RedactHash && len(r.HashKey) == 0 {
+		r.HashKey = make([]byte, 32)
+		rand.Read(r.HashKey)
+	}
+
+	redactionMu.Lock()
+	defer redactionMu.Unlock()
+	redaction = r
+}
+
+// Sensitive is a value that is redacted when logged.
+type Sensitive struct {
+	kind  SensitiveKind
+	value string
+}
+
+// Document marks a CPF or CNPJ as sensitive.
+func Document(value string) Sensitive {
+	return Sensitive{kind: KindDocument, value: value}
+}
+
+// CardNumber marks a card number as sensitive.
+func CardNumber(value string) Sensitive {
+	return Sensitive{kind: KindCardNumber, value: value}
+}
+
+// Secret marks an API key, token or password as sensitive.
+func Secret(value string) Sensitive {
+	return Sensitive{kind: KindSecret, value: value}
+}
+
+// LogValue redacts the value according to the configured mode. Dropped values
+// become empty groups, which slog leaves out.
+func (s Sensitive) LogValue() slog.Value {
+	redactionMu.RLock()
+	r := redaction
+	redactionMu.RUnlock()
+
+	switch r.Mode {
+	case RedactDrop:
+		return slog.GroupValue()
+	case RedactHash:
+		mac := hmac.New(sha256.New, r.HashKey)
+		mac.Write([]byte(s.value))
+		return slog.AnyValue(redacted("hmac:" + hex.EncodeToString(mac.Sum(nil))[:16]))
+	}
+
+	switch s.kind {
+	case KindDocument:
+		return slog.AnyValue(redacted(maskDigits(s.value, 0, 2)))
+	case KindCardNumber:
+		// Like the masked PAN on receipts, the BIN and last four digits.
+		if digits := onlyDigits(s.value); len(digits) >= 13 {
+			return slog.AnyValue(redacted(maskDigits(digits, 6, 4)))
+		}
+		return slog.AnyValue(redacted(maskDigits(s.value, 0, 4)))
+	}
+	return slog.AnyValue(redacted("[redacted]"))
+}
+
+// redacted is the type of redacted values, so that replaceAttr does not
+// redact them again.
+type redacted string
+
+func (r redacted) String() string { return string(r) }
+
+// redactAttr redacts plain string attributes with a sensitive key.
+func redactAttr(a slog.Attr) slog.Attr {
+	if a.Value.Kind() != slog.KindString {
+		return a
+	}
+
+	redactionMu.RLock()
+	kind, ok := sensitiveKeys[a.Key]
+	redactionMu.RUnlock()
+	if !ok {
+		return a
+	}
+
+	a.Value = Sensitive{kind: kind, value: a.Value.String()}.LogValue()
+	return a
+}
+
+func onlyDigits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+// maskDigits keeps the first and last characters of the digits of value and
+// masks the rest, masking everything when there are too few.
+func maskDigits(value string, first, last int) string {
+	digits := onlyDigits(value)
+	if len(digits) <= first+last {
+		return strings.Repeat("*", len(digits))
+	}
+	return fmt.Sprintf("%s%s%s", digits[:first], strings.Repeat("*", len(digits)-first-last), digits[len(digits)-last:])
+}
@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensitive(t *testing.T) {
+	t.Cleanup(func() { setRedaction(Redaction{Mode: RedactMask}) })
+
+	newLogger := func(redaction Redaction) *bytes.Buffer {
+		var buf bytes.Buffer
+		setRedaction(redaction)
+		defaultLogger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: replaceAttr}))
+		return &buf
+	}
+
+	t.Run("masks all but the last digits of documents", func(t *testing.T) {
+		// given
+		buf := newLogger(Redaction{Mode: RedactMask})
+
+		// when
+		Info(context.Background(), "account created", slog.Any("document_number", Document("123.456.789-00")))
+
+		// then
+		assert.Contains(t, buf.String(), "document_number=*********00")
+		assert.NotContains(t, buf.String(), "123")
+	})
+
+	t.Run("masks card numbers like receipts", func(t *testing.T) {
+		// given
+		buf := newLogger(Redaction{Mode: RedactMask})
+
+		// when
+		Info(context.Background(), "card issued", slog.Any("card", CardNumber("4000 0012 3456 7899")))
+
+		// then
+		assert.Contains(t, buf.String(), "card=400000******7899")
+	})
+
+	t.Run("masks secrets entirely", func(t *testing.T) {
+		// given
+		buf := newLogger(Redaction{Mode: RedactMask})
+
+		// when
+		Info(context.Background(), "key created", slog.Any("key", Secret("pk_live_123")))
+
+		// then
+		assert.Contains(t, buf.String(), "key=[redacted]")
+	})
+
+	t.Run("hashes values with the key", func(t *testing.T) {
+		// given
+		buf := newLogger(Redaction{Mode: RedactHash, HashKey: []byte("key")})
+
+		// when
+		Info(context.Background(), "account created", slog.Any("document_number", Document("12345678900")))
+		Info(context.Background(), "account created", slog.Any("document_number", Document("12345678900")))
+
+		// then
+		lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+		assert.Len(t, lines, 2)
+		assert.Regexp(t, `document_number=hmac:[0-9a-f]{16}`, string(lines[0]))
+		assert.Equal(t, lines[0][bytes.Index(lines[0], []byte("document_number")):], lines[1][bytes.Index(lines[1], []byte("document_number")):])
+		assert.NotContains(t, buf.String(), "12345678900")
+	})
+
+	t.Run("drops values", func(t *testing.T) {
+		// given
+		buf := newLogger(Redaction{Mode: RedactDrop})
+
+		// when
+		Info(context.Background(), "account created", slog.Any("document_number", Document("12345678900")))
+
+		// then
+		assert.Contains(t, buf.String(), "account created")
+		assert.NotContains(t, buf.String(), "document_number")
+	})
+
+	t.Run("redacts plain strings with a sensitive key", func(t *testing.T) {
+		// given
+		buf := newLogger(Redaction{Mode: RedactMask})
+
+		// when
+		Info(context.Background(), "account created", slog.String("document_number", "12345678900"))
+
+		// then
+		assert.Contains(t, buf.String(), "document_number=*********00")
+	})
+
+	t.Run("redacts plain strings with a registered key", func(t *testing.T) {
+		// given
+		buf := newLogger(Redaction{Mode: RedactMask})
+		RegisterSensitiveKey("client_secret", KindSecret)
+		t.Cleanup(func() { delete(sensitiveKeys, "client_secret") })
+
+		// when
+		Info(context.Background(), "client registered", slog.String("client_secret", "s3cr3t"))
+
+		// then
+		assert.Contains(t, buf.String(), "client_secret=[redacted]")
+	})
+}
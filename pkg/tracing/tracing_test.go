@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	t.Run("records the error of failed spans", func(t *testing.T) {
+		// given
+		_, span := tracer.Start(context.Background(), "failed")
+
+		// when
+		End(span, errors.New("connection refused"))
+
+		// then
+		ended := recorder.Ended()[len(recorder.Ended())-1]
+		assert.Equal(t, codes.Error, ended.Status().Code)
+		assert.Equal(t, "connection refused", ended.Status().Description)
+		require.Len(t, ended.Events(), 1)
+		assert.Equal(t, "exception", ended.Events()[0].Name)
+	})
+
+	t.Run("leaves the status of successful spans unset", func(t *testing.T) {
+		// given
+		_, span := tracer.Start(context.Background(), "succeeded")
+
+		// when
+		End(span, nil)
+
+		// then
+		ended := recorder.Ended()[len(recorder.Ended())-1]
+		assert.Equal(t, codes.Unset, ended.Status().Code)
+		assert.Empty(t, ended.Events())
+	})
+}
@@ -0,0 +1,135 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+)
+
+func TestAccountRestriction_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	ts := SetupTestServerWithAuth(t, ctx)
+	scopes := []domain.Scope{domain.ScopeAccountsRead, domain.ScopeAccountsWrite, domain.ScopeTransactionsWrite, domain.ScopeDisputesResolve}
+	backOffice := ts.Tokens.Add(&domain.Principal{ClientID: "back-office", Scopes: scopes})
+
+	send := func(token, method, path, body string) *http.Response {
+		req, err := http.NewRequest(method, ts.Server.URL+path, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	create := func(path, body string, created any) {
+		resp := send(backOffice, http.MethodPost, path, body)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode, path)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(created))
+	}
+
+	// Given a customer restricted to their account, and resources of another
+	var own, other dto.CreateAccountResponse
+	create("/accounts", `{"document_number": "71717171717"}`, &own)
+	create("/accounts", `{"document_number": "72727272727"}`, &other)
+	customer := ts.Tokens.Add(&domain.Principal{ClientID: "mobile", Scopes: scopes, AccountID: own.AccountID})
+
+	otherAccount := toString(other.AccountID)
+	var card dto.CardResponse
+	create("/accounts/"+otherAccount+"/cards", `{"type": "virtual"}`, &card)
+	var purchase dto.CreateTransactionResponse
+	create("/transactions", `{"account_id": `+otherAccount+`, "operation_type_id": 1, "amount": 80.0}`, &purchase)
+	startsAt := time.Now().UTC().Add(24 * time.Hour).Format(time.RFC3339)
+	scheduleBody := `{"operation_type_id": 4, "amount_type": "full_balance", "recurrence": "monthly", "day_of_month": 1, "starts_at": "` + startsAt + `"}`
+	var schedule dto.ScheduledTransactionResponse
+	create("/accounts/"+otherAccount+"/scheduled-transactions", scheduleBody, &schedule)
+	disputesPath := "/transactions/" + toString(purchase.TransactionID) + "/disputes"
+	var dispute dto.DisputeResponse
+	create(disputesPath, `{"reason": "item not received"}`, &dispute)
+
+	schedulePath := "/scheduled-transactions/" + toString(schedule.ScheduledTransactionID)
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodGet, "/accounts/" + otherAccount, ""},
+		{http.MethodPost, "/transactions", `{"account_id": ` + otherAccount + `, "operation_type_id": 1, "amount": 10.0}`},
+		{http.MethodPost, "/cards/" + toString(card.CardID) + "/block", ""},
+		{http.MethodGet, schedulePath, ""},
+		{http.MethodPut, schedulePath, scheduleBody},
+		{http.MethodDelete, schedulePath, ""},
+		{http.MethodPost, disputesPath, `{"reason": "duplicate"}`},
+		{http.MethodGet, disputesPath, ""},
+		{http.MethodPatch, disputesPath + "/" + toString(dispute.DisputeID), `{"status": "won"}`},
+	}
+	for _, tt := range tests {
+		t.Run("denies "+tt.method+" "+tt.path+" on another account", func(t *testing.T) {
+			// when
+			resp := send(customer, tt.method, tt.path, tt.body)
+			defer resp.Body.Close()
+
+			// then
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		})
+	}
+
+	t.Run("keeps customers from deciding their own disputes", func(t *testing.T) {
+		// given
+		var purchase dto.CreateTransactionResponse
+		create("/transactions", `{"account_id": `+toString(own.AccountID)+`, "operation_type_id": 1, "amount": 30.0}`, &purchase)
+		ownDisputesPath := "/transactions/" + toString(purchase.TransactionID) + "/disputes"
+		app := ts.Tokens.Add(&domain.Principal{
+			ClientID:  "mobile",
+			Scopes:    []domain.Scope{domain.ScopeAccountsRead, domain.ScopeTransactionsWrite},
+			AccountID: own.AccountID,
+		})
+		opened := send(app, http.MethodPost, ownDisputesPath, `{"reason": "item not received"}`)
+		defer opened.Body.Close()
+		require.Equal(t, http.StatusCreated, opened.StatusCode)
+		var dispute dto.DisputeResponse
+		require.NoError(t, json.NewDecoder(opened.Body).Decode(&dispute))
+
+		// when
+		resp := send(app, http.MethodPatch, ownDisputesPath+"/"+toString(dispute.DisputeID), `{"status": "won"}`)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("lets the customer post to their own account", func(t *testing.T) {
+		// when
+		resp := send(customer, http.MethodPost, "/transactions", `{"account_id": `+toString(own.AccountID)+`, "operation_type_id": 4, "amount": 10.0}`)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("leaves the resources of the other account untouched", func(t *testing.T) {
+		// when
+		var cardStatus, scheduleStatus, disputeStatus string
+		require.NoError(t, ts.DB.QueryRow("SELECT status FROM cards WHERE card_id = $1", card.CardID).Scan(&cardStatus))
+		require.NoError(t, ts.DB.QueryRow("SELECT status FROM scheduled_transactions WHERE scheduled_transaction_id = $1", schedule.ScheduledTransactionID).Scan(&scheduleStatus))
+		require.NoError(t, ts.DB.QueryRow("SELECT status FROM disputes WHERE dispute_id = $1", dispute.DisputeID).Scan(&disputeStatus))
+
+		// then
+		assert.Equal(t, "active", cardStatus)
+		assert.Equal(t, "active", scheduleStatus)
+		assert.Equal(t, "opened", disputeStatus)
+	})
+}
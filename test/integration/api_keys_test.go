@@ -0,0 +1,97 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/apikey"
+)
+
+func TestAPIKeys_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	ts := SetupTestServerWithAuth(t, ctx)
+	repo := database.NewAPIKeyRepository(ts.DB)
+	createAPIKey := apikey.NewCreateAPIKey(repo)
+
+	_, writer, err := createAPIKey.Execute(ctx, "onboarding", []domain.Scope{domain.ScopeAccountsWrite})
+	require.NoError(t, err)
+	readerKey, reader, err := createAPIKey.Execute(ctx, "reports", []domain.Scope{domain.ScopeAccountsRead})
+	require.NoError(t, err)
+
+	post := func(key, body string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, ts.Server.URL+"/accounts", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("accepts keys with the required scope", func(t *testing.T) {
+		// when
+		resp := post(writer, `{"document_number": "81818181818"}`)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("returns 401 without a key", func(t *testing.T) {
+		// when
+		resp := post("", `{"document_number": "82828282828"}`)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("returns 403 when the key lacks the scope", func(t *testing.T) {
+		// when
+		resp := post(reader, `{"document_number": "83838383838"}`)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("returns 401 once the key is revoked", func(t *testing.T) {
+		// given
+		require.NoError(t, apikey.NewRevokeAPIKey(repo).Execute(ctx, readerKey.ID))
+
+		req, err := http.NewRequest(http.MethodGet, ts.Server.URL+"/accounts/1", nil)
+		require.NoError(t, err)
+		req.Header.Set("X-API-Key", reader)
+
+		// when
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("keeps the health check public", func(t *testing.T) {
+		// when
+		resp, err := http.Get(ts.Server.URL + "/health")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
@@ -0,0 +1,115 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+)
+
+func TestCards_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	ts := SetupTestServer(t, ctx)
+
+	// Create an account first
+	accountBody := bytes.NewBufferString(`{"document_number": "12345678900"}`)
+	accountResp, err := http.Post(ts.Server.URL+"/accounts", "application/json", accountBody)
+	require.NoError(t, err)
+	defer accountResp.Body.Close()
+
+	var accountResponse dto.CreateAccountResponse
+	err = json.NewDecoder(accountResp.Body).Decode(&accountResponse)
+	require.NoError(t, err)
+
+	accountID := accountResponse.AccountID
+	var card dto.CardResponse
+
+	t.Run("issues card for the account", func(t *testing.T) {
+		// given
+		body := bytes.NewBufferString(`{"type": "virtual"}`)
+
+		// when
+		resp, err := http.Post(ts.Server.URL+"/accounts/"+toString(accountID)+"/cards", "application/json", body)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		err = json.NewDecoder(resp.Body).Decode(&card)
+		require.NoError(t, err)
+
+		assert.NotZero(t, card.CardID)
+		assert.Equal(t, accountID, card.AccountID)
+		assert.Len(t, card.Last4, 4)
+		assert.Equal(t, "active", card.Status)
+	})
+
+	t.Run("creates purchase with active card", func(t *testing.T) {
+		// given
+		body := bytes.NewBufferString(`{"account_id": ` + toString(accountID) + `, "operation_type_id": 1, "amount": 10.0, "card_id": ` + toString(card.CardID) + `}`)
+
+		// when
+		resp, err := http.Post(ts.Server.URL+"/transactions", "application/json", body)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response dto.CreateTransactionResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+
+		assert.Equal(t, card.CardID, response.CardID)
+	})
+
+	t.Run("blocks card", func(t *testing.T) {
+		// when
+		resp, err := http.Post(ts.Server.URL+"/cards/"+toString(card.CardID)+"/block", "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response dto.CardResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "blocked", response.Status)
+	})
+
+	t.Run("returns 422 when purchasing with blocked card", func(t *testing.T) {
+		// given
+		body := bytes.NewBufferString(`{"account_id": ` + toString(accountID) + `, "operation_type_id": 1, "amount": 10.0, "card_id": ` + toString(card.CardID) + `}`)
+
+		// when
+		resp, err := http.Post(ts.Server.URL+"/transactions", "application/json", body)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	t.Run("returns 404 when blocking unknown card", func(t *testing.T) {
+		// when
+		resp, err := http.Post(ts.Server.URL+"/cards/999999/block", "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/response"
 )
 
 func TestCreateAccount_Integration(t *testing.T) {
@@ -41,7 +42,7 @@ func TestCreateAccount_Integration(t *testing.T) {
 		assert.Equal(t, "12345678900", response.DocumentNumber)
 	})
 
-	t.Run("returns 422 when document number already exists", func(t *testing.T) {
+	t.Run("returns 409 when document number already exists", func(t *testing.T) {
 		// given
 		body := bytes.NewBufferString(`{"document_number": "99999999999"}`)
 
@@ -58,7 +59,13 @@ func TestCreateAccount_Integration(t *testing.T) {
 		require.NoError(t, err)
 		defer resp2.Body.Close()
 
-		assert.Equal(t, http.StatusUnprocessableEntity, resp2.StatusCode)
+		assert.Equal(t, http.StatusConflict, resp2.StatusCode)
+		assert.Equal(t, "application/problem+json", resp2.Header.Get("Content-Type"))
+
+		var problem response.Problem
+		require.NoError(t, json.NewDecoder(resp2.Body).Decode(&problem))
+		assert.Equal(t, "account_already_exists", problem.Code)
+		assert.NotEmpty(t, problem.Instance)
 	})
 
 	t.Run("returns 400 when document number is empty", func(t *testing.T) {
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/response"
 )
 
 func TestCreateTransaction_Integration(t *testing.T) {
@@ -88,7 +89,7 @@ func TestCreateTransaction_Integration(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 	})
 
-	t.Run("returns 422 when operation type is invalid", func(t *testing.T) {
+	t.Run("returns 400 when operation type is not documented", func(t *testing.T) {
 		// given
 		body := bytes.NewBufferString(`{"account_id": ` + toString(accountID) + `, "operation_type_id": 99, "amount": 50.0}`)
 
@@ -98,7 +99,27 @@ func TestCreateTransaction_Integration(t *testing.T) {
 		defer resp.Body.Close()
 
 		// then
-		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("returns 400 listing unknown and missing fields", func(t *testing.T) {
+		// given
+		body := bytes.NewBufferString(`{"account_id": ` + toString(accountID) + `, "amount": 50.0, "tip": 5}`)
+
+		// when
+		resp, err := http.Post(ts.Server.URL+"/transactions", "application/json", body)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		var problem response.Problem
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+		assert.ElementsMatch(t, []response.FieldError{
+			{Field: "tip", Code: "unknown", Message: "tip is not allowed"},
+			{Field: "operation_type_id", Code: "required", Message: "operation_type_id is required"},
+		}, problem.Errors)
 	})
 
 	t.Run("returns 400 when amount is zero", func(t *testing.T) {
@@ -114,6 +135,78 @@ func TestCreateTransaction_Integration(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 
+	t.Run("creates transaction with merchant details", func(t *testing.T) {
+		// given
+		body := bytes.NewBufferString(`{"account_id": ` + toString(accountID) + `, "operation_type_id": 1, "amount": 10.0, "external_id": "auth-001", "merchant_name": "Coffee Shop", "merchant_category_code": "5814", "currency": "BRL", "metadata": {"terminal": "T1"}}`)
+
+		// when
+		resp, err := http.Post(ts.Server.URL+"/transactions", "application/json", body)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response dto.CreateTransactionResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "auth-001", response.ExternalID)
+		assert.Equal(t, "Coffee Shop", response.MerchantName)
+		assert.Equal(t, "5814", response.MerchantCategoryCode)
+		assert.Equal(t, "BRL", response.Currency)
+		assert.Equal(t, "T1", response.Metadata["terminal"])
+	})
+
+	t.Run("returns 409 when external id was already used by the account", func(t *testing.T) {
+		// given
+		body := bytes.NewBufferString(`{"account_id": ` + toString(accountID) + `, "operation_type_id": 1, "amount": 10.0, "external_id": "auth-001"}`)
+
+		// when
+		resp, err := http.Post(ts.Server.URL+"/transactions", "application/json", body)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("converts foreign currency purchase to the account currency", func(t *testing.T) {
+		// given
+		body := bytes.NewBufferString(`{"account_id": ` + toString(accountID) + `, "operation_type_id": 1, "amount": 10.0, "currency": "USD"}`)
+
+		// when
+		resp, err := http.Post(ts.Server.URL+"/transactions", "application/json", body)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response dto.CreateTransactionResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+
+		assert.Equal(t, -50.0, response.Amount)
+		assert.Equal(t, -10.0, response.OriginalAmount)
+		assert.Equal(t, "USD", response.Currency)
+		assert.Equal(t, "BRL", response.SettlementCurrency)
+		assert.Equal(t, 5.0, response.FXRate)
+	})
+
+	t.Run("returns 422 when no exchange rate is available", func(t *testing.T) {
+		// given
+		body := bytes.NewBufferString(`{"account_id": ` + toString(accountID) + `, "operation_type_id": 1, "amount": 10.0, "currency": "JPY"}`)
+
+		// when
+		resp, err := http.Post(ts.Server.URL+"/transactions", "application/json", body)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
 	t.Run("returns 400 when body is invalid", func(t *testing.T) {
 		// given
 		body := bytes.NewBufferString(`invalid json`)
@@ -0,0 +1,233 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+)
+
+func TestDisputes_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	ts := SetupTestServer(t, ctx)
+
+	// Create an account first
+	accountBody := bytes.NewBufferString(`{"document_number": "12345678900"}`)
+	accountResp, err := http.Post(ts.Server.URL+"/accounts", "application/json", accountBody)
+	require.NoError(t, err)
+	defer accountResp.Body.Close()
+
+	var accountResponse dto.CreateAccountResponse
+	err = json.NewDecoder(accountResp.Body).Decode(&accountResponse)
+	require.NoError(t, err)
+
+	accountID := accountResponse.AccountID
+
+	// And a purchase to dispute
+	purchaseBody := bytes.NewBufferString(`{"account_id": ` + toString(accountID) + `, "operation_type_id": 1, "amount": 80.0}`)
+	purchaseResp, err := http.Post(ts.Server.URL+"/transactions", "application/json", purchaseBody)
+	require.NoError(t, err)
+	defer purchaseResp.Body.Close()
+
+	var purchase dto.CreateTransactionResponse
+	err = json.NewDecoder(purchaseResp.Body).Decode(&purchase)
+	require.NoError(t, err)
+
+	disputesURL := ts.Server.URL + "/transactions/" + toString(purchase.TransactionID) + "/disputes"
+	var dispute dto.DisputeResponse
+
+	t.Run("opens dispute with a provisional credit", func(t *testing.T) {
+		// given
+		body := bytes.NewBufferString(`{"reason": "item not received", "note": "customer called support"}`)
+
+		// when
+		resp, err := http.Post(disputesURL, "application/json", body)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		err = json.NewDecoder(resp.Body).Decode(&dispute)
+		require.NoError(t, err)
+
+		assert.Equal(t, "opened", dispute.Status)
+		assert.Equal(t, 80.0, dispute.Amount)
+		assert.NotZero(t, dispute.ProvisionalCreditTransactionID)
+
+		var purchaseBalance float64
+		err = ts.DB.QueryRow("SELECT balance FROM transactions WHERE transaction_id = $1", purchase.TransactionID).Scan(&purchaseBalance)
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, purchaseBalance)
+	})
+
+	t.Run("rejects a second open dispute", func(t *testing.T) {
+		// when
+		resp, err := http.Post(disputesURL, "application/json", bytes.NewBufferString(`{"reason": "duplicate"}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("moves dispute under review", func(t *testing.T) {
+		// when
+		resp := patch(t, disputesURL+"/"+toString(dispute.DisputeID), `{"status": "under_review", "note": "evidence requested"}`)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("reverses provisional credit when dispute is lost", func(t *testing.T) {
+		// when
+		resp := patch(t, disputesURL+"/"+toString(dispute.DisputeID), `{"status": "lost", "note": "proof of delivery"}`)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response dto.DisputeResponse
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "lost", response.Status)
+		assert.NotNil(t, response.ResolvedAt)
+		assert.NotZero(t, response.FinalTransactionID)
+		assert.NotEqual(t, dispute.ProvisionalCreditTransactionID, response.FinalTransactionID)
+
+		var operationTypeID int
+		var amount float64
+		err = ts.DB.QueryRow("SELECT operation_type_id, amount FROM transactions WHERE transaction_id = $1", response.FinalTransactionID).Scan(&operationTypeID, &amount)
+		require.NoError(t, err)
+		assert.Equal(t, 6, operationTypeID)
+		assert.Equal(t, -80.0, amount)
+	})
+
+	t.Run("lists disputes with their history", func(t *testing.T) {
+		// when
+		resp, err := http.Get(disputesURL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response []dto.DisputeResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+
+		require.Len(t, response, 1)
+		assert.Len(t, response[0].Events, 3)
+		assert.Equal(t, "customer called support", response[0].Events[0].Note)
+	})
+
+	t.Run("rejects changes to resolved dispute", func(t *testing.T) {
+		// when
+		resp := patch(t, disputesURL+"/"+toString(dispute.DisputeID), `{"status": "won"}`)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("refuses disputes of a transaction refunded by a won one", func(t *testing.T) {
+		// given
+		resp, err := http.Post(disputesURL, "application/json", bytes.NewBufferString(`{"reason": "new evidence"}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var second dto.DisputeResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&second))
+		won := patch(t, disputesURL+"/"+toString(second.DisputeID), `{"status": "won"}`)
+		defer won.Body.Close()
+		require.Equal(t, http.StatusOK, won.StatusCode)
+
+		// when
+		again, err := http.Post(disputesURL, "application/json", bytes.NewBufferString(`{"reason": "refund me twice"}`))
+		require.NoError(t, err)
+		defer again.Body.Close()
+		_, insertErr := ts.DB.Exec(`
+			INSERT INTO disputes (transaction_id, account_id, amount, reason, status)
+			VALUES ($1, $2, 80.0, 'bypassing the API', 'opened')
+		`, purchase.TransactionID, accountID)
+
+		// then
+		assert.Equal(t, http.StatusConflict, again.StatusCode)
+		assert.ErrorContains(t, insertErr, "disputes_open_or_won_transaction_id_key")
+	})
+
+	t.Run("resolves concurrently decided disputes only once", func(t *testing.T) {
+		for range 5 {
+			// given
+			purchaseResp, err := http.Post(ts.Server.URL+"/transactions", "application/json",
+				bytes.NewBufferString(`{"account_id": `+toString(accountID)+`, "operation_type_id": 1, "amount": 30.0}`))
+			require.NoError(t, err)
+			var debit dto.CreateTransactionResponse
+			require.NoError(t, json.NewDecoder(purchaseResp.Body).Decode(&debit))
+			purchaseResp.Body.Close()
+			url := ts.Server.URL + "/transactions/" + toString(debit.TransactionID) + "/disputes"
+			openResp, err := http.Post(url, "application/json", bytes.NewBufferString(`{"reason": "item not received"}`))
+			require.NoError(t, err)
+			var opened dto.DisputeResponse
+			require.NoError(t, json.NewDecoder(openResp.Body).Decode(&opened))
+			openResp.Body.Close()
+
+			// when
+			codes := make(chan int, 2)
+			for _, status := range []string{"won", "lost"} {
+				go func() {
+					req, _ := http.NewRequest(http.MethodPatch, url+"/"+toString(opened.DisputeID), bytes.NewBufferString(`{"status": "`+status+`"}`))
+					req.Header.Set("Content-Type", "application/json")
+					resp, err := http.DefaultClient.Do(req)
+					if err != nil {
+						codes <- 0
+						return
+					}
+					resp.Body.Close()
+					codes <- resp.StatusCode
+				}()
+			}
+			results := []int{<-codes, <-codes}
+
+			// then
+			assert.ElementsMatch(t, []int{http.StatusOK, http.StatusConflict}, results)
+			var status string
+			var reversals int
+			err = ts.DB.QueryRow(`
+				SELECT status, (SELECT COUNT(*) FROM transactions WHERE external_id = 'dispute-' || dispute_id || '-reversal')
+				FROM disputes WHERE dispute_id = $1
+			`, opened.DisputeID).Scan(&status, &reversals)
+			require.NoError(t, err)
+			if status == "won" {
+				assert.Zero(t, reversals)
+			} else {
+				assert.Equal(t, 1, reversals)
+			}
+		}
+	})
+}
+
+func patch(t *testing.T, url, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPatch, url, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
@@ -0,0 +1,134 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/grpc/pismov1"
+)
+
+func TestGRPC_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	ts := SetupTestServer(t, ctx)
+	client := pismov1.NewPismoServiceClient(ts.GRPC)
+
+	t.Run("creates an account and posts to its ledger", func(t *testing.T) {
+		// given
+		account, err := client.CreateAccount(ctx, &pismov1.CreateAccountRequest{DocumentNumber: "77777777777"})
+		require.NoError(t, err)
+
+		// when
+		_, err = client.CreateTransaction(ctx, &pismov1.CreateTransactionRequest{
+			AccountId:       account.GetAccountId(),
+			OperationTypeId: 1,
+			Amount:          50.0,
+		})
+		require.NoError(t, err)
+
+		payment, err := client.CreateTransaction(ctx, &pismov1.CreateTransactionRequest{
+			AccountId:       account.GetAccountId(),
+			OperationTypeId: 4,
+			Amount:          60.0,
+		})
+		require.NoError(t, err)
+
+		ledger, err := client.ListTransactions(ctx, &pismov1.ListTransactionsRequest{AccountId: account.GetAccountId()})
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, 10.0, payment.GetBalance())
+		require.Len(t, ledger.GetTransactions(), 2)
+		assert.Equal(t, -50.0, ledger.GetTransactions()[0].GetAmount())
+		assert.Equal(t, 0.0, ledger.GetTransactions()[0].GetBalance())
+
+		fetched, err := client.GetAccount(ctx, &pismov1.GetAccountRequest{AccountId: account.GetAccountId()})
+		require.NoError(t, err)
+		assert.Equal(t, "77777777777", fetched.GetDocumentNumber())
+	})
+
+	t.Run("returns NotFound when account does not exist", func(t *testing.T) {
+		// when
+		_, err := client.GetAccount(ctx, &pismov1.GetAccountRequest{AccountId: 999999})
+
+		// then
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("returns InvalidArgument for domain validation errors", func(t *testing.T) {
+		// when
+		_, err := client.CreateTransaction(ctx, &pismov1.CreateTransactionRequest{
+			AccountId:       1,
+			OperationTypeId: 99,
+			Amount:          10.0,
+		})
+
+		// then
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGRPCAuth_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	ts := SetupTestServerWithAuth(t, ctx)
+	client := pismov1.NewPismoServiceClient(ts.GRPC)
+	as := func(principal *domain.Principal) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+ts.Tokens.Add(principal))
+	}
+	backOffice := as(&domain.Principal{ClientID: "back-office", Scopes: []domain.Scope{domain.ScopeAccountsRead, domain.ScopeAccountsWrite}})
+
+	own, err := client.CreateAccount(backOffice, &pismov1.CreateAccountRequest{DocumentNumber: "73737373737"})
+	require.NoError(t, err)
+	other, err := client.CreateAccount(backOffice, &pismov1.CreateAccountRequest{DocumentNumber: "74747474747"})
+	require.NoError(t, err)
+
+	t.Run("rejects calls without credentials", func(t *testing.T) {
+		// when
+		_, err := client.GetAccount(ctx, &pismov1.GetAccountRequest{AccountId: own.GetAccountId()})
+
+		// then
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("rejects calls without the required scope", func(t *testing.T) {
+		// given
+		reader := as(&domain.Principal{ClientID: "reports", Scopes: []domain.Scope{domain.ScopeAccountsRead}})
+
+		// when
+		_, err := client.CreateTransaction(reader, &pismov1.CreateTransactionRequest{
+			AccountId:       own.GetAccountId(),
+			OperationTypeId: 4,
+			Amount:          10.0,
+		})
+
+		// then
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("restricts customers to their own account", func(t *testing.T) {
+		// given
+		customer := as(&domain.Principal{ClientID: "mobile", Scopes: []domain.Scope{domain.ScopeAccountsRead}, AccountID: own.GetAccountId()})
+
+		// when
+		_, ownErr := client.ListTransactions(customer, &pismov1.ListTransactionsRequest{AccountId: own.GetAccountId()})
+		_, otherErr := client.ListTransactions(customer, &pismov1.ListTransactionsRequest{AccountId: other.GetAccountId()})
+
+		// then
+		assert.NoError(t, ownErr)
+		assert.Equal(t, codes.PermissionDenied, status.Code(otherErr))
+	})
+}
@@ -0,0 +1,49 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/handler"
+)
+
+func TestHealth_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	ts := SetupTestServer(t, ctx)
+
+	for _, path := range []string{"/livez", "/readyz", "/startupz"} {
+		t.Run("answers "+path, func(t *testing.T) {
+			// when
+			resp, err := http.Get(ts.Server.URL + path)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			// then
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+
+	t.Run("lists the startup checks in verbose mode", func(t *testing.T) {
+		// when
+		resp, err := http.Get(ts.Server.URL + "/startupz?verbose")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		var health handler.HealthResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&health))
+		assert.Equal(t, "healthy", health.Status)
+		require.Len(t, health.Checks, 2)
+		assert.Equal(t, "database", health.Checks[0].Name)
+		assert.Equal(t, "migrations", health.Checks[1].Name)
+	})
+}
@@ -0,0 +1,100 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database"
+)
+
+func TestMigrations_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	ts := SetupTestServer(t, ctx)
+	migrations := ts.Migrator.Migrations()
+	latest := migrations[len(migrations)-1].Version
+
+	t.Run("reports every migration as applied", func(t *testing.T) {
+		// when
+		statuses, err := ts.Migrator.Status(ctx)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, statuses, len(migrations))
+		for _, status := range statuses {
+			assert.True(t, status.Applied, status.Name)
+		}
+	})
+
+	t.Run("does nothing when already up to date", func(t *testing.T) {
+		// when
+		ran, err := ts.Migrator.Up(ctx)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, ran)
+	})
+
+	t.Run("rolls back the last migration", func(t *testing.T) {
+		// when
+		ran, err := ts.Migrator.Down(ctx, 1)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, ran, 1)
+		assert.Equal(t, latest, ran[0].Version)
+
+		statuses, err := ts.Migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.False(t, statuses[len(statuses)-1].Applied)
+	})
+
+	t.Run("rolls everything back and reapplies it", func(t *testing.T) {
+		// when
+		rolledBack, err := ts.Migrator.To(ctx, 0)
+		require.NoError(t, err)
+
+		var tables int
+		err = ts.DB.QueryRow("SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = 'public' AND table_name <> 'schema_migrations'").Scan(&tables)
+		require.NoError(t, err)
+
+		applied, err := ts.Migrator.Up(ctx)
+		require.NoError(t, err)
+
+		// then
+		assert.Len(t, rolledBack, len(migrations)-1)
+		assert.Zero(t, tables)
+		assert.Len(t, applied, len(migrations))
+	})
+
+	t.Run("refuses to migrate tables created without tracking", func(t *testing.T) {
+		// given
+		_, err := ts.DB.Exec("DELETE FROM schema_migrations")
+		require.NoError(t, err)
+
+		// when
+		_, upErr := ts.Migrator.Up(ctx)
+		baselineErr := ts.Migrator.Baseline(ctx, latest)
+		ran, err := ts.Migrator.Up(ctx)
+
+		// then
+		assert.ErrorIs(t, upErr, database.ErrUntrackedSchema)
+		require.NoError(t, baselineErr)
+		require.NoError(t, err)
+		assert.Empty(t, ran)
+	})
+
+	t.Run("returns error for unknown version", func(t *testing.T) {
+		// when
+		_, err := ts.Migrator.To(ctx, 999)
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,69 @@
+package integration
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/ratelimit"
+)
+
+func TestPostgresRateLimitStore_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	ts := SetupTestServer(t, ctx)
+	limit := ratelimit.Limit{Requests: 5, Period: time.Minute}
+	now := time.Now()
+
+	t.Run("shares buckets across stores", func(t *testing.T) {
+		// given
+		stores := []*ratelimit.PostgresStore{ratelimit.NewPostgresStore(ts.DB), ratelimit.NewPostgresStore(ts.DB)}
+
+		// when
+		var allowed atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(store *ratelimit.PostgresStore) {
+				defer wg.Done()
+				result, err := store.Take(ctx, "shared", limit, now)
+				assert.NoError(t, err)
+				if result.Allowed {
+					allowed.Add(1)
+				}
+			}(stores[i%2])
+		}
+		wg.Wait()
+
+		// then
+		assert.Equal(t, int32(5), allowed.Load())
+	})
+
+	t.Run("refills tokens over time", func(t *testing.T) {
+		// given
+		store := ratelimit.NewPostgresStore(ts.DB)
+		for i := 0; i < 5; i++ {
+			_, err := store.Take(ctx, "refill", limit, now)
+			require.NoError(t, err)
+		}
+
+		// when
+		denied, err := store.Take(ctx, "refill", limit, now)
+		require.NoError(t, err)
+		refilled, err := store.Take(ctx, "refill", limit, now.Add(12*time.Second))
+		require.NoError(t, err)
+
+		// then
+		assert.False(t, denied.Allowed)
+		assert.Equal(t, 12*time.Second, denied.RetryAfter)
+		assert.True(t, refilled.Allowed)
+	})
+}
@@ -0,0 +1,63 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/config"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database/pgxdb"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/repositorytest"
+)
+
+// repositoryDriver holds the account and transaction repositories of one
+// database driver.
+type repositoryDriver struct {
+	name         string
+	accounts     domain.AccountRepository
+	transactions domain.TransactionRepository
+}
+
+// setupRepositoryDrivers returns the lib/pq and pgx repositories of a fresh
+// database, each backed by a pool of 25 connections.
+func setupRepositoryDrivers(tb testing.TB, ctx context.Context) []repositoryDriver {
+	tb.Helper()
+	db, connStr := setupDatabase(tb, ctx)
+	db.SetMaxOpenConns(25)
+	db.SetMaxIdleConns(25)
+	tb.Cleanup(func() { db.Close() })
+
+	pool, err := pgxdb.NewPool(ctx, config.DatabaseConfig{
+		URL:                    connStr,
+		MaxOpenConns:           25,
+		HealthCheckPeriod:      time.Minute,
+		StatementCacheCapacity: 512,
+	})
+	require.NoError(tb, err)
+	tb.Cleanup(pool.Close)
+
+	return []repositoryDriver{
+		{"pq", database.NewAccountRepository(db), database.NewTransactionRepository(db)},
+		{"pgx", pgxdb.NewAccountRepository(pool), pgxdb.NewTransactionRepository(pool)},
+	}
+}
+
+func TestRepositories_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	// Both drivers share one database, which subtests share too: starting a
+	// container for each would take longer than the whole suite.
+	for _, driver := range setupRepositoryDrivers(t, context.Background()) {
+		t.Run(driver.name, func(t *testing.T) {
+			repositorytest.Run(t, func(*testing.T) repositorytest.Repositories {
+				return repositorytest.Repositories{Accounts: driver.accounts, Transactions: driver.transactions}
+			})
+		})
+	}
+}
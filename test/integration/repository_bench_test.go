@@ -0,0 +1,107 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/domain"
+)
+
+// BenchmarkRepositories compares the lib/pq and pgx repositories against the
+// same database, through pools of the same size:
+//
+//	go test ./test/integration -run '^$' -bench Repositories
+func BenchmarkRepositories(b *testing.B) {
+	if testing.Short() {
+		b.Skip("skipping integration benchmark")
+	}
+
+	ctx := context.Background()
+	for _, driver := range setupRepositoryDrivers(b, ctx) {
+		// Each driver gets an account with 20 outstanding debits.
+		account, err := driver.accounts.Create(ctx, &domain.Account{DocumentNumber: "bench-" + driver.name, Currency: "BRL"})
+		require.NoError(b, err)
+		var debits []*domain.Transaction
+		for i := 0; i < 20; i++ {
+			debit, err := driver.transactions.Create(ctx, newDebit(account.ID))
+			require.NoError(b, err)
+			debits = append(debits, debit)
+		}
+
+		b.Run("create/"+driver.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := driver.transactions.Create(ctx, newDebit(account.ID)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run("find_by_id/"+driver.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := driver.transactions.FindByID(ctx, debits[i%len(debits)].ID); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run("list_by_account_id/"+driver.name, func(b *testing.B) {
+			listed, err := driver.accounts.Create(ctx, &domain.Account{DocumentNumber: "bench-list-" + driver.name, Currency: "BRL"})
+			require.NoError(b, err)
+			for i := 0; i < 20; i++ {
+				_, err := driver.transactions.Create(ctx, newDebit(listed.ID))
+				require.NoError(b, err)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := driver.transactions.ListByAccountID(ctx, listed.ID); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run("update_balances/"+driver.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, debit := range debits {
+					debit.Balance = -float64(i % 50)
+				}
+				if err := driver.transactions.UpdateBalances(ctx, debits); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run("parallel_find_by_id/"+driver.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if _, err := driver.transactions.FindByID(ctx, debits[i%len(debits)].ID); err != nil {
+						b.Error(err)
+						return
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+func newDebit(accountID int64) *domain.Transaction {
+	return &domain.Transaction{
+		AccountID:          accountID,
+		OperationTypeID:    domain.OperationTypePurchase,
+		Amount:             -50.0,
+		EventDate:          time.Now().UTC().Truncate(time.Microsecond),
+		Balance:            -50.0,
+		OriginalAmount:     -50.0,
+		SettlementCurrency: "BRL",
+		FXRate:             1,
+		TransactionDetails: domain.TransactionDetails{
+			Currency: "BRL",
+			Metadata: map[string]any{"channel": "pos"},
+		},
+	}
+}
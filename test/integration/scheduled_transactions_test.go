@@ -0,0 +1,147 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/scheduler"
+)
+
+func TestScheduledTransactions_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	ts := SetupTestServer(t, ctx)
+
+	// Create an account first
+	accountBody := bytes.NewBufferString(`{"document_number": "12345678900"}`)
+	accountResp, err := http.Post(ts.Server.URL+"/accounts", "application/json", accountBody)
+	require.NoError(t, err)
+	defer accountResp.Body.Close()
+
+	var accountResponse dto.CreateAccountResponse
+	err = json.NewDecoder(accountResp.Body).Decode(&accountResponse)
+	require.NoError(t, err)
+
+	accountID := accountResponse.AccountID
+	schedulesURL := ts.Server.URL + "/accounts/" + toString(accountID) + "/scheduled-transactions"
+
+	// And some debt for autopay to settle
+	for _, amount := range []string{"40.0", "60.5"} {
+		body := bytes.NewBufferString(`{"account_id": ` + toString(accountID) + `, "operation_type_id": 1, "amount": ` + amount + `}`)
+		resp, err := http.Post(ts.Server.URL+"/transactions", "application/json", body)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	var autopay dto.ScheduledTransactionResponse
+
+	t.Run("creates monthly autopay for the full balance", func(t *testing.T) {
+		// given
+		startsAt := time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)
+		body := bytes.NewBufferString(`{"operation_type_id": 4, "amount_type": "full_balance", "recurrence": "monthly", "day_of_month": 31, "starts_at": "` + startsAt + `"}`)
+
+		// when
+		resp, err := http.Post(schedulesURL, "application/json", body)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		err = json.NewDecoder(resp.Body).Decode(&autopay)
+		require.NoError(t, err)
+
+		assert.NotZero(t, autopay.ScheduledTransactionID)
+		assert.Equal(t, "active", autopay.Status)
+	})
+
+	t.Run("runs due autopay exactly once", func(t *testing.T) {
+		// given
+		_, err := ts.DB.Exec("UPDATE scheduled_transactions SET next_run_at = NOW() - INTERVAL '1 minute' WHERE scheduled_transaction_id = $1", autopay.ScheduledTransactionID)
+		require.NoError(t, err)
+
+		// when
+		ts.Scheduler.Tick(ctx)
+		ts.Scheduler.Tick(ctx)
+
+		// then
+		var payments int
+		var amount float64
+		err = ts.DB.QueryRow("SELECT COUNT(*), COALESCE(SUM(amount), 0) FROM transactions WHERE account_id = $1 AND operation_type_id = 4", accountID).Scan(&payments, &amount)
+		require.NoError(t, err)
+		assert.Equal(t, 1, payments)
+		assert.Equal(t, 100.5, amount)
+
+		resp, err := http.Get(ts.Server.URL + "/scheduled-transactions/" + toString(autopay.ScheduledTransactionID))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var response dto.ScheduledTransactionResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+
+		assert.NotZero(t, response.LastTransactionID)
+		assert.NotNil(t, response.LastRunAt)
+		assert.True(t, response.NextRunAt.After(time.Now()))
+	})
+
+	t.Run("runs only on the instance holding the leader lock", func(t *testing.T) {
+		// given
+		otherInstance := database.NewAdvisoryLock(ts.DB, scheduler.LeaderLockKey)
+
+		// when
+		acquired, err := otherInstance.TryAcquire(ctx)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, acquired)
+	})
+
+	t.Run("lists scheduled transactions of the account", func(t *testing.T) {
+		// when
+		resp, err := http.Get(schedulesURL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response []dto.ScheduledTransactionResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+
+		assert.Len(t, response, 1)
+	})
+
+	t.Run("cancels scheduled transaction", func(t *testing.T) {
+		// given
+		req, err := http.NewRequest(http.MethodDelete, ts.Server.URL+"/scheduled-transactions/"+toString(autopay.ScheduledTransactionID), nil)
+		require.NoError(t, err)
+
+		// when
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response dto.ScheduledTransactionResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "cancelled", response.Status)
+	})
+}
@@ -3,8 +3,10 @@ package integration
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"net"
 	"net/http/httptest"
-	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,17 +15,67 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
 
+	"github.com/nubank/pismo-code-assessment/docs"
+	"github.com/nubank/pismo-code-assessment/internal/domain"
 	"github.com/nubank/pismo-code-assessment/internal/infrastructure/database"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/fx"
+	grpcserver "github.com/nubank/pismo-code-assessment/internal/infrastructure/grpc/server"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/grpc/service"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/health"
 	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/handler"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/middleware"
 	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/router"
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/scheduler"
 	"github.com/nubank/pismo-code-assessment/internal/usecase/account"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/apikey"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/card"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/dispute"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/fraud"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/scheduledtransaction"
+	"github.com/nubank/pismo-code-assessment/internal/usecase/spendingcontrol"
 	"github.com/nubank/pismo-code-assessment/internal/usecase/transaction"
 )
 
 type TestServer struct {
-	Server *httptest.Server
-	DB     *sql.DB
+	Server    *httptest.Server
+	DB        *sql.DB
+	Scheduler *scheduler.Scheduler
+	Migrator  *database.Migrator
+	GRPC      *grpc.ClientConn
+	// Tokens issues the JWTs accepted when authentication is enabled.
+	Tokens *Tokens
+}
+
+// Tokens stands in for a JWT issuer, accepting the tokens tests add for a
+// principal.
+type Tokens struct {
+	mu         sync.Mutex
+	principals map[string]*domain.Principal
+}
+
+// Add returns a token, shaped like a JWT, that authenticates as principal.
+func (t *Tokens) Add(principal *domain.Principal) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	token := fmt.Sprintf("test.%d.token", len(t.principals))
+	t.principals[token] = principal
+	return token
+}
+
+func (t *Tokens) Verify(_ context.Context, token string) (*domain.Principal, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	principal, ok := t.principals[token]
+	if !ok {
+		return nil, domain.ErrInvalidToken
+	}
+	return principal, nil
 }
 
 func (ts *TestServer) Close() {
@@ -31,59 +83,172 @@ func (ts *TestServer) Close() {
 	ts.DB.Close()
 }
 
+// SetupTestServer starts the API with authentication disabled, so tests can
+// focus on behaviour rather than credentials.
 func SetupTestServer(t *testing.T, ctx context.Context) *TestServer {
-	postgresContainer, err := postgres.Run(ctx,
-		"postgres:16-alpine",
-		postgres.WithDatabase("pismo_test"),
-		postgres.WithUsername("pismo"),
-		postgres.WithPassword("pismo"),
-		postgres.WithInitScripts(
-			filepath.Join("..", "..", "internal", "infrastructure", "database", "migrations", "001_create_accounts.up.sql"),
-			filepath.Join("..", "..", "internal", "infrastructure", "database", "migrations", "002_create_transactions.up.sql"),
-		),
-		testcontainers.WithWaitStrategy(
-			wait.ForLog("database system is ready to accept connections").
-				WithOccurrence(2).
-				WithStartupTimeout(5*time.Second)),
-	)
-	require.NoError(t, err)
+	return setupTestServer(t, ctx, false)
+}
 
-	t.Cleanup(func() {
-		postgresContainer.Terminate(ctx)
-	})
+// SetupTestServerWithAuth starts the API requiring API keys, which tests
+// create through the API key repository, or tokens added to ts.Tokens.
+func SetupTestServerWithAuth(t *testing.T, ctx context.Context) *TestServer {
+	return setupTestServer(t, ctx, true)
+}
 
-	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
-	require.NoError(t, err)
+func setupTestServer(t *testing.T, ctx context.Context, authEnabled bool) *TestServer {
+	db, _ := setupDatabase(t, ctx)
 
-	db, err := sql.Open("postgres", connStr)
+	migrator, err := database.NewMigrator(db)
 	require.NoError(t, err)
 
 	// Repositories
 	accountRepo := database.NewAccountRepository(db)
 	transactionRepo := database.NewTransactionRepository(db)
+	spendingControlsRepo := database.NewSpendingControlsRepository(db)
+	cardRepo := database.NewCardRepository(db)
+	fraudRuleRepo := database.NewFraudRuleRepository(db)
+	fraudAssessmentRepo := database.NewFraudAssessmentRepository(db)
+	disputeRepo := database.NewDisputeRepository(db)
+	scheduledTransactionRepo := database.NewScheduledTransactionRepository(db)
+	apiKeyRepo := database.NewAPIKeyRepository(db)
 
 	// Account use cases and handler
 	createAccount := account.NewCreateAccount(accountRepo)
 	getAccount := account.NewGetAccount(accountRepo)
 	accountHandler := handler.NewAccountHandler(createAccount, getAccount)
 
+	// Card use cases and handler
+	issueCard := card.NewIssueCard(cardRepo)
+	blockCard := card.NewBlockCard(cardRepo)
+	cardHandler := handler.NewCardHandler(issueCard, blockCard)
+
 	// Transaction use cases and handler
-	createTransaction := transaction.NewCreateTransaction(transactionRepo)
+	fxRateProvider := fx.NewStaticRateProvider(map[string]float64{"USD/BRL": 5.0})
+	fraudEngine := fraud.NewEngine(fraudRuleRepo, fraudAssessmentRepo, transactionRepo, 0)
+	createTransaction := transaction.NewCreateTransaction(transactionRepo, accountRepo, cardRepo, fxRateProvider, spendingControlsRepo, fraudEngine)
+	listTransactions := transaction.NewListTransactions(transactionRepo, accountRepo)
 	transactionHandler := handler.NewTransactionHandler(createTransaction)
 
+	// Dispute use cases and handler
+	openDispute := dispute.NewOpenDispute(disputeRepo, transactionRepo, createTransaction)
+	listDisputes := dispute.NewListDisputes(disputeRepo, transactionRepo)
+	updateDispute := dispute.NewUpdateDispute(disputeRepo, transactionRepo, createTransaction)
+	disputeHandler := handler.NewDisputeHandler(openDispute, listDisputes, updateDispute)
+
+	// Scheduled transaction use cases and handler
+	createScheduledTransaction := scheduledtransaction.NewCreateScheduledTransaction(scheduledTransactionRepo)
+	listScheduledTransactions := scheduledtransaction.NewListScheduledTransactions(scheduledTransactionRepo, accountRepo)
+	getScheduledTransaction := scheduledtransaction.NewGetScheduledTransaction(scheduledTransactionRepo)
+	updateScheduledTransaction := scheduledtransaction.NewUpdateScheduledTransaction(scheduledTransactionRepo)
+	cancelScheduledTransaction := scheduledtransaction.NewCancelScheduledTransaction(scheduledTransactionRepo)
+	scheduledTransactionHandler := handler.NewScheduledTransactionHandler(
+		createScheduledTransaction,
+		listScheduledTransactions,
+		getScheduledTransaction,
+		updateScheduledTransaction,
+		cancelScheduledTransaction,
+	)
+
+	// Spending controls use cases and handler
+	getSpendingControls := spendingcontrol.NewGetSpendingControls(spendingControlsRepo, accountRepo)
+	updateSpendingControls := spendingcontrol.NewUpdateSpendingControls(spendingControlsRepo)
+	spendingControlsHandler := handler.NewSpendingControlsHandler(getSpendingControls, updateSpendingControls)
+
 	// Health handler
-	healthHandler := handler.NewHealthHandler(db)
+	checker := health.NewChecker(2 * time.Second)
+	checker.Register("database", health.Database(db), health.Ready, health.Startup)
+	checker.Register("migrations", health.Migrations(migrator), health.Startup)
+	healthHandler := handler.NewHealthHandler(checker)
+
+	// Scheduler, ticked by the tests instead of running in the background
+	runDueScheduledTransactions := scheduledtransaction.NewRunDueScheduledTransactions(scheduledTransactionRepo, transactionRepo, createTransaction, 100)
+	transactionScheduler := scheduler.New(database.NewAdvisoryLock(db, scheduler.LeaderLockKey), runDueScheduledTransactions, time.Minute)
+
+	tokens := &Tokens{principals: make(map[string]*domain.Principal)}
+	var auth *middleware.Auth
+	if authEnabled {
+		auth = middleware.NewAuth(apikey.NewAuthenticateAPIKey(apiKeyRepo), tokens)
+	}
+
+	// Responses are validated too, so that tests fail when handlers drift
+	// from the OpenAPI document.
+	validator, err := middleware.NewValidator(docs.OpenAPI, true)
+	require.NoError(t, err)
 
-	r := router.New(accountHandler, transactionHandler, healthHandler)
+	r := router.New(accountHandler, transactionHandler, healthHandler, spendingControlsHandler, cardHandler, disputeHandler, scheduledTransactionHandler, auth, nil, validator)
 
 	server := httptest.NewServer(r)
+
+	// gRPC server over an in-memory listener
+	listener := bufconn.Listen(1024 * 1024)
+	var grpcAuth grpcserver.Authenticator
+	if auth != nil {
+		grpcAuth = auth
+	}
+	grpcSrv := grpcserver.New("0", service.NewPismoService(createAccount, getAccount, createTransaction, listTransactions), grpcAuth)
+	go grpcSrv.Serve(listener)
+
+	grpcConn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
 	t.Cleanup(func() {
+		grpcConn.Close()
+		grpcSrv.Shutdown(ctx)
 		server.Close()
 		db.Close()
 	})
 
 	return &TestServer{
-		Server: server,
-		DB:     db,
+		Server:    server,
+		DB:        db,
+		Scheduler: transactionScheduler,
+		Migrator:  migrator,
+		GRPC:      grpcConn,
+		Tokens:    tokens,
 	}
 }
+
+// startPostgres starts a Postgres container and returns its connection string.
+// It takes a testing.TB so that benchmarks can use it too.
+func startPostgres(tb testing.TB, ctx context.Context) string {
+	tb.Helper()
+	postgresContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("pismo_test"),
+		postgres.WithUsername("pismo"),
+		postgres.WithPassword("pismo"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	require.NoError(tb, err)
+
+	tb.Cleanup(func() {
+		postgresContainer.Terminate(ctx)
+	})
+
+	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(tb, err)
+	return connStr
+}
+
+// setupDatabase starts Postgres, applies every migration and returns the
+// database along with its connection string.
+func setupDatabase(tb testing.TB, ctx context.Context) (*sql.DB, string) {
+	tb.Helper()
+	connStr := startPostgres(tb, ctx)
+	db, err := sql.Open("postgres", connStr)
+	require.NoError(tb, err)
+
+	migrator, err := database.NewMigrator(db)
+	require.NoError(tb, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(tb, err)
+	return db, connStr
+}
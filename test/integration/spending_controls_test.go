@@ -0,0 +1,129 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nubank/pismo-code-assessment/internal/infrastructure/http/dto"
+)
+
+func TestSpendingControls_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	ts := SetupTestServer(t, ctx)
+
+	// Create an account first
+	accountBody := bytes.NewBufferString(`{"document_number": "12345678900"}`)
+	accountResp, err := http.Post(ts.Server.URL+"/accounts", "application/json", accountBody)
+	require.NoError(t, err)
+	defer accountResp.Body.Close()
+
+	var accountResponse dto.CreateAccountResponse
+	err = json.NewDecoder(accountResp.Body).Decode(&accountResponse)
+	require.NoError(t, err)
+
+	accountID := accountResponse.AccountID
+	controlsURL := ts.Server.URL + "/accounts/" + toString(accountID) + "/controls"
+
+	t.Run("returns empty controls when none were configured", func(t *testing.T) {
+		// when
+		resp, err := http.Get(controlsURL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response dto.SpendingControlsResponse
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+
+		assert.Equal(t, accountID, response.AccountID)
+		assert.Empty(t, response.BlockedMCCs)
+	})
+
+	t.Run("updates spending controls", func(t *testing.T) {
+		// given
+		body := bytes.NewBufferString(`{"blocked_mccs": ["7995"], "max_transaction_amount": 100, "allowed_operation_types": [1, 4]}`)
+		req, err := http.NewRequest(http.MethodPut, controlsURL, body)
+		require.NoError(t, err)
+
+		// when
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		getResp, err := http.Get(controlsURL)
+		require.NoError(t, err)
+		defer getResp.Body.Close()
+
+		var response dto.SpendingControlsResponse
+		err = json.NewDecoder(getResp.Body).Decode(&response)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"7995"}, response.BlockedMCCs)
+		assert.Equal(t, 100.0, response.MaxTransactionAmount)
+		assert.Equal(t, []int{1, 4}, response.AllowedOperationTypes)
+	})
+
+	t.Run("declines purchase on blocked merchant category", func(t *testing.T) {
+		// given
+		body := bytes.NewBufferString(`{"account_id": ` + toString(accountID) + `, "operation_type_id": 1, "amount": 10.0, "merchant_category_code": "7995"}`)
+
+		// when
+		resp, err := http.Post(ts.Server.URL+"/transactions", "application/json", body)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	t.Run("declines purchase above the amount limit", func(t *testing.T) {
+		// given
+		body := bytes.NewBufferString(`{"account_id": ` + toString(accountID) + `, "operation_type_id": 1, "amount": 150.0}`)
+
+		// when
+		resp, err := http.Post(ts.Server.URL+"/transactions", "application/json", body)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	t.Run("declines operation type not allowed", func(t *testing.T) {
+		// given
+		body := bytes.NewBufferString(`{"account_id": ` + toString(accountID) + `, "operation_type_id": 3, "amount": 10.0}`)
+
+		// when
+		resp, err := http.Post(ts.Server.URL+"/transactions", "application/json", body)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	t.Run("returns 404 when account does not exist", func(t *testing.T) {
+		// when
+		resp, err := http.Get(ts.Server.URL + "/accounts/999999/controls")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		// then
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}